@@ -18,16 +18,12 @@ package groth16
 
 import (
 	"errors"
-	"fmt"
 	"github.com/consensys/gnark-crypto/ecc"
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/fft"
 	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/pedersen"
-	"github.com/consensys/gnark-crypto/utils"
-	"github.com/consensys/gnark-crypto/ecc/bls12-377/fr/hash_to_field"
 	"github.com/consensys/gnark/backend/groth16/internal"
-	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/constraint"
 	cs "github.com/consensys/gnark/constraint/bls12-377"
 	"math/big"
@@ -54,13 +50,6 @@ type ProvingKey struct {
 		B           []curve.G2Affine
 	}
 
-	// [E]_T
-	Gt struct {
-		E curve.GT
-	}
-
-	mu 					fr.Element
-
 	// if InfinityA[i] == true, the point G1.A[i] == infinity
 	InfinityA, InfinityB     []bool
 	NbInfinityA, NbInfinityB uint64
@@ -92,23 +81,6 @@ type VerifyingKey struct {
 	PublicAndCommitmentCommitted [][]int // indexes of public/commitment committed variables
 }
 
-type PublicWitness struct {
-	Public 	fr.Vector
-	E 		curve.GT
-	mu 		big.Int
-}
-
-type FoldedWitness struct {
-	H   curve.G1Affine
-	E   curve.GT
-	Mu  big.Int
-}
-
-type FoldingParameters struct {
-	T 		curve.GT
-	R 	    big.Int
-}
-
 // Setup constructs the SRS
 func Setup(r1cs *cs.R1CS, pk *ProvingKey, vk *VerifyingKey) error {
 	/*
@@ -368,88 +340,15 @@ func Setup(r1cs *cs.R1CS, pk *ProvingKey, vk *VerifyingKey) error {
 // This is meant to be called internally during setup or deserialization.
 func (vk *VerifyingKey) Precompute() error {
 	var err error
-	
 	vk.e, err = curve.Pair([]curve.G1Affine{vk.G1.Alpha}, []curve.G2Affine{vk.G2.Beta})
 	if err != nil {
 		return err
 	}
-
 	vk.G2.deltaNeg.Neg(&vk.G2.Delta)
 	vk.G2.gammaNeg.Neg(&vk.G2.Gamma)
 	return nil
 }
 
-func (foldedWitness *FoldedWitness) FoldWitnesses(publicWitness []PublicWitness, foldingParameters []FoldingParameters, vk VerifyingKey, proofs []Proof, opts ...backend.VerifierOption) error {
-	opt, err := backend.NewVerifierConfig(opts...)
-	if err != nil {
-		return fmt.Errorf("new verifier config: %w", err)
-	}
-	if opt.HashToFieldFn == nil {
-		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
-	}
-	for i := range publicWitness {
-		foldedWitness.Mu.Add(&foldedWitness.Mu, big.NewInt(0).Mul(&publicWitness[i].mu, &foldingParameters[i].R))
-		rr := big.NewInt(0).Mul(&foldingParameters[i].R, &foldingParameters[i].R)
-
-		foldedWitness.E = *make([]curve.GT, 1)[0].Mul(&foldedWitness.E, make([]curve.GT, 1)[0].Mul(
-			make([]curve.GT, 1)[0].Exp(foldingParameters[i].T, &foldingParameters[i].R), 
-			make([]curve.GT, 1)[0].Exp(publicWitness[i].E, rr),
-		))
-
-		maxNbPublicCommitted := 0
-		for _, s := range vk.PublicAndCommitmentCommitted { // iterate over commitments
-			maxNbPublicCommitted = utils.Max(maxNbPublicCommitted, len(s))
-		}
-		commitmentPrehashSerialized := make([]byte, curve.SizeOfG1AffineUncompressed+maxNbPublicCommitted*fr.Bytes)
-		for j := range vk.PublicAndCommitmentCommitted { // solveCommitmentWire
-			copy(commitmentPrehashSerialized, proofs[i].Commitments[j].Marshal())
-			offset := curve.SizeOfG1AffineUncompressed
-			for k := range vk.PublicAndCommitmentCommitted[j] {
-				copy(commitmentPrehashSerialized[offset:], publicWitness[i].Public[vk.PublicAndCommitmentCommitted[j][k]-1].Marshal())
-				offset += fr.Bytes
-			}
-			opt.HashToFieldFn.Write(commitmentPrehashSerialized[:offset])
-			hashBts := opt.HashToFieldFn.Sum(nil)
-			opt.HashToFieldFn.Reset()
-			nbBuf := fr.Bytes
-			if opt.HashToFieldFn.Size() < fr.Bytes {
-				nbBuf = opt.HashToFieldFn.Size()
-			}
-			var res fr.Element
-			res.SetBytes(hashBts[:nbBuf])
-			publicWitness[i].Public = append(publicWitness[i].Public, res)
-		}
-
-		var kSum curve.G1Jac
-		if _, err := kSum.MultiExp(vk.G1.K[1:], publicWitness[i].Public, ecc.MultiExpConfig{}); err != nil {
-			return err
-		}
-		kSum.AddMixed(&vk.G1.K[0])
-
-		for j := range proofs[i].Commitments {
-			kSum.AddMixed(&proofs[i].Commitments[j])
-		}
-
-		var kSumAff curve.G1Affine
-		kSumAff.FromJacobian(&kSum)
-
-		foldedWitness.H.Add(&foldedWitness.H, make([]curve.G1Affine, 1)[0].ScalarMultiplication(&kSumAff, &foldingParameters[i].R))
-	}
-
-	return nil
-}
-
-func (witness *PublicWitness) SetStartingParameters() error {
-	// E = [0]_T the point at infinity
-	var err error
-	witness.E, err = curve.Pair([]curve.G1Affine{*make([]curve.G1Affine, 1)[0].ScalarMultiplication(&make([]curve.G1Affine, 1)[0], big.NewInt(0))}, make([]curve.G2Affine, 1))
-	if err != nil {
-		return err
-	}
-	witness.mu = *big.NewInt(1)
-	return nil
-}
-
 func setupABC(r1cs *cs.R1CS, domain *fft.Domain, toxicWaste toxicWaste) (A []fr.Element, B []fr.Element, C []fr.Element) {
 
 	nbWires := r1cs.NbInternalVariables + r1cs.GetNbPublicVariables() + r1cs.GetNbSecretVariables()
@@ -37,6 +37,7 @@ import (
 var (
 	errPairingCheckFailed         = errors.New("pairing doesn't match")
 	errCorrectSubgroupCheckFailed = errors.New("points in the proof are not in the correct subgroup")
+	errNoProofToVerify            = errors.New("no proof to verify")
 )
 
 // Verify verifies a proof with given VerifyingKey and publicWitness
@@ -48,6 +49,7 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	if opt.HashToFieldFn == nil {
 		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
 	}
+
 	nbPublicVars := len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted)
 
 	if len(publicWitness) != nbPublicVars-1 {
@@ -139,206 +141,109 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	return nil
 }
 
-// Verify verifies a proof with given VerifyingKey and publicWitness
-func VerifyFolded(foldedProof *FoldedProof, foldingParameters []FoldingParameters, vk *VerifyingKey, proofs []Proof, publicWitness []fr.Vector) error {
-	
-	witness := make([]PublicWitness, len(publicWitness))
-	for i := range publicWitness {
-		witness[i].Public = publicWitness[i]
-		witness[i].SetStartingParameters()
-	}
-
-	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Str("backend", "groth16").Logger()
-	start := time.Now()
-
-	var doubleML curve.GT
-	chDone := make(chan error, 1)
-	startingFoldingPars := FoldingParameters{}
-	startingFoldingPars.R = *big.NewInt(1)
-	startingFoldingPars.T = *make([]curve.GT, 1)[0].SetOne()
-
-	// fold public witness
-	foldedWitness := FoldedWitness{}
-	foldedWitness.Mu = *big.NewInt(0)
-	foldedWitness.H = *make([]curve.G1Affine, 1)[0].ScalarMultiplication(&make([]curve.G1Affine, 1)[0], big.NewInt(0))
-	
-	foldedWitness.E = *make([]curve.GT, 1)[0].SetOne()
-	foldingParameters = append([]FoldingParameters{startingFoldingPars}, foldingParameters...)
-
-	err := foldedWitness.FoldWitnesses(witness, foldingParameters, *vk, proofs)
-	if err != nil {
-		return err
-	}
-
-	// compute (eKrsδ, eArBs, ealphaBeta)
-	go func() {
-		var errML error
-		krs_times_mu := make([]curve.G1Affine, 1)[0].ScalarMultiplication(&foldedProof.Krs, &foldedWitness.Mu)
-		doubleML, errML = curve.MillerLoop([]curve.G1Affine{*krs_times_mu, foldedProof.Ar}, []curve.G2Affine{vk.G2.deltaNeg, foldedProof.Bs})
-		chDone <- errML
-		close(chDone)
-	}()
-
-	gamma_neg_times_mu := make([]curve.G2Affine, 1)[0].ScalarMultiplication(&vk.G2.gammaNeg, &foldedWitness.Mu)
-	right, err := curve.MillerLoop([]curve.G1Affine{foldedWitness.H}, []curve.G2Affine{*gamma_neg_times_mu})
-	if err != nil {
-		return err
-	}
-
-	// wait for (eKrsδ, eArBs)
-	if err := <-chDone; err != nil {
-		return err
-	}
-
-	right = curve.FinalExponentiation(&right, &doubleML)
-
-	// vk.e is e(α, β), we want e(α, β)^{-mu^2}
-	mu_sqrd := new(big.Int).Mul(&foldedWitness.Mu, &foldedWitness.Mu)
-	vk.e.Exp(vk.e, mu_sqrd)
-	vk.e.Inverse(&vk.e)
-
-	vk.e.Mul(&right, &vk.e)
-
-	if !foldedWitness.E.Equal(&vk.e) {
-		return errPairingCheckFailed
-	}
-	log.Debug().Dur("took", time.Since(start)).Msg("verifier done")
-	return nil
-}
-
-// ExportSolidity not implemented for BLS12-377
-func (vk *VerifyingKey) ExportSolidity(w io.Writer) error {
-	return errors.New("not implemented")
-}
-
-func GetFoldingParameters(kSumAff1 curve.G1Affine, proof1 *FoldedProof, proof2 *Proof, vk *VerifyingKey, foldedWitness FoldedWitness, publicWitness2 fr.Vector, opts ...backend.VerifierOption) (*FoldingParameters, curve.G1Affine, error) {
-	witness2 := PublicWitness{}
-	witness2.Public = publicWitness2
-	witness2.SetStartingParameters()
-
+// BatchVerify verifies proofs with the same VerifyingKey. The pairing checks of
+// all the proofs are combined with random scalars ρᵢ into a single one
+//
+//	∏ e(ρᵢ·Arᵢ, Bsᵢ) · e(Σρᵢ·Krsᵢ, -δ) · e(Σρᵢ·Σx.[Kvk(t)]₁ᵢ, -γ) · e(-Σρᵢ·α, β) = 1
+//
+// and the proofs of knowledge of the commitments are combined the same way. If
+// the batch doesn't verify, the proofs are verified one by one to report the
+// first invalid one.
+func BatchVerify(proofs []Proof, vk *VerifyingKey, publicWitness []fr.Vector, opts ...backend.VerifierOption) error {
 	opt, err := backend.NewVerifierConfig(opts...)
 	if err != nil {
-		return nil, kSumAff1, fmt.Errorf("new verifier config: %w", err)
+		return fmt.Errorf("new verifier config: %w", err)
 	}
 	if opt.HashToFieldFn == nil {
 		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
 	}
-	C1C2 := make([]curve.G1Affine, 1)[0].Add(
-		make([]curve.G1Affine, 1)[0].ScalarMultiplication(&proof2.Krs, &foldedWitness.Mu),
-		make([]curve.G1Affine, 1)[0].ScalarMultiplication(&proof1.Krs, &witness2.mu),
-	)
-
-	maxNbPublicCommitted := 0
-	for _, s := range vk.PublicAndCommitmentCommitted { // iterate over commitments
-		maxNbPublicCommitted = utils.Max(maxNbPublicCommitted, len(s))
+	if len(proofs) == 0 {
+		return errNoProofToVerify
 	}
-	commitmentPrehashSerialized2 := make([]byte, curve.SizeOfG1AffineUncompressed+maxNbPublicCommitted*fr.Bytes)
-	for i := range vk.PublicAndCommitmentCommitted { // solveCommitmentWire
-		copy(commitmentPrehashSerialized2, proof2.Commitments[i].Marshal())
-		offset := curve.SizeOfG1AffineUncompressed
-		for j := range vk.PublicAndCommitmentCommitted[i] {
-			copy(commitmentPrehashSerialized2[offset:], witness2.Public[vk.PublicAndCommitmentCommitted[i][j]-1].Marshal())
-			offset += fr.Bytes
+	if len(publicWitness) != len(proofs) {
+		return fmt.Errorf("invalid number of public witnesses, got %d, expected %d", len(publicWitness), len(proofs))
+	}
+	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Str("backend", "groth16").Int("nbProofs", len(proofs)).Logger()
+	start := time.Now()
+
+	rho := make([]fr.Element, len(proofs))
+	var rhoSum fr.Element
+	for i := range rho {
+		if _, err := rho[i].SetRandom(); err != nil {
+			return err
 		}
-		opt.HashToFieldFn.Write(commitmentPrehashSerialized2[:offset])
-		hashBts2 := opt.HashToFieldFn.Sum(nil)
-		opt.HashToFieldFn.Reset()
-		nbBuf := fr.Bytes
-		if opt.HashToFieldFn.Size() < fr.Bytes {
-			nbBuf = opt.HashToFieldFn.Size()
+		rhoSum.Add(&rhoSum, &rho[i])
+	}
+
+	n := len(proofs)
+	g1 := make([]curve.G1Affine, n+3)
+	g2 := make([]curve.G2Affine, n+3)
+	krs := make([]curve.G1Affine, n)
+	kSums := make([]curve.G1Affine, n)
+	commitments := make([]curve.G1Affine, n)
+	poks := make([]curve.G1Affine, n)
+	var b big.Int
+	for i := range proofs {
+		// check that the points in the proof are in the correct subgroup
+		if !proofs[i].isValid() {
+			return fmt.Errorf("proof %d: %w", i, errCorrectSubgroupCheckFailed)
 		}
-		var res2 fr.Element
-		res2.SetBytes(hashBts2[:nbBuf])
-		witness2.Public = append(witness2.Public, res2)
+		kSums[i], commitments[i], err = computeKSum(vk, &proofs[i], publicWitness[i], opt.HashToFieldFn)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+		krs[i] = proofs[i].Krs
+		poks[i] = proofs[i].CommitmentPok
+		g1[i].ScalarMultiplication(&proofs[i].Ar, rho[i].BigInt(&b))
+		g2[i] = proofs[i].Bs
 	}
 
-	var kSum2 curve.G1Jac
-	if _, err := kSum2.MultiExp(vk.G1.K[1:], witness2.Public, ecc.MultiExpConfig{}); err != nil {
-		return nil, kSumAff1, err
+	var acc curve.G1Jac
+	if _, err := acc.MultiExp(krs, rho, ecc.MultiExpConfig{}); err != nil {
+		return err
 	}
-	kSum2.AddMixed(&vk.G1.K[0])
-	for i := range proof2.Commitments {
-		kSum2.AddMixed(&proof2.Commitments[i])
+	g1[n].FromJacobian(&acc)
+	g2[n] = vk.G2.deltaNeg
+	if _, err := acc.MultiExp(kSums, rho, ecc.MultiExpConfig{}); err != nil {
+		return err
 	}
-	var kSumAff2 curve.G1Affine
-	kSumAff2.FromJacobian(&kSum2)
+	g1[n+1].FromJacobian(&acc)
+	g2[n+1] = vk.G2.gammaNeg
+	g1[n+2].ScalarMultiplication(&vk.G1.Alpha, rhoSum.BigInt(&b))
+	g1[n+2].Neg(&g1[n+2])
+	g2[n+2] = vk.G2.Beta
 
-	H1H2 := make([]curve.G1Affine, 1)[0].Add(
-		make([]curve.G1Affine, 1)[0].ScalarMultiplication(&kSumAff2, &foldedWitness.Mu),
-		make([]curve.G1Affine, 1)[0].ScalarMultiplication(&kSumAff1, &witness2.mu),
-	)
-
-	mu1mu2 := new(big.Int).Mul(&foldedWitness.Mu, new(big.Int).Mul(&witness2.mu, big.NewInt(-2)))
-	alphamu1mu2 := make([]curve.G1Affine, 1)[0].ScalarMultiplication(&vk.G1.Alpha, mu1mu2)
-	all, err := curve.MillerLoop([]curve.G1Affine{proof1.Ar, proof2.Ar, *C1C2, *H1H2, *alphamu1mu2}, []curve.G2Affine{proof2.Bs, proof1.Bs, vk.G2.deltaNeg, vk.G2.gammaNeg, vk.G2.Beta})
+	ok, err := curve.PairingCheck(g1, g2)
 	if err != nil {
-		return nil, kSumAff1, err
-	}
-	T := curve.FinalExponentiation(&all)
-
-	r := big.NewInt(1)				// THIS SHOULD BE RANDOM!!! Fiat shamir?
-
-	foldingPars := &FoldingParameters{}
-	foldingPars.T = T
-	foldingPars.R = *r
-
-	return foldingPars, kSumAff2, nil
-}
-
-func GetkSumAff(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...backend.VerifierOption) (curve.G1Affine, error) {
-	opt, _ := backend.NewVerifierConfig(opts...)
-	if opt.HashToFieldFn == nil {
-		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
-	}
-
-	witness := PublicWitness{}
-	witness.Public = publicWitness
-	witness.SetStartingParameters()
-	maxNbPublicCommitted := 0
-	for _, s := range vk.PublicAndCommitmentCommitted { // iterate over commitments
-		maxNbPublicCommitted = utils.Max(maxNbPublicCommitted, len(s))
+		return err
 	}
-	commitmentPrehashSerialized := make([]byte, curve.SizeOfG1AffineUncompressed+maxNbPublicCommitted*fr.Bytes)
-	for i := range vk.PublicAndCommitmentCommitted { // solveCommitmentWire
-		copy(commitmentPrehashSerialized, proof.Commitments[i].Marshal())
-		offset := curve.SizeOfG1AffineUncompressed
-		for j := range vk.PublicAndCommitmentCommitted[i] {
-			copy(commitmentPrehashSerialized[offset:], witness.Public[vk.PublicAndCommitmentCommitted[i][j]-1].Marshal())
-			offset += fr.Bytes
+	if ok {
+		// the proofs of knowledge are linear in the commitments
+		var commitment, pok curve.G1Affine
+		if _, err := commitment.MultiExp(commitments, rho, ecc.MultiExpConfig{}); err != nil {
+			return err
 		}
-		opt.HashToFieldFn.Write(commitmentPrehashSerialized[:offset])
-		hashBts2 := opt.HashToFieldFn.Sum(nil)
-		opt.HashToFieldFn.Reset()
-		nbBuf := fr.Bytes
-		if opt.HashToFieldFn.Size() < fr.Bytes {
-			nbBuf = opt.HashToFieldFn.Size()
+		if _, err := pok.MultiExp(poks, rho, ecc.MultiExpConfig{}); err != nil {
+			return err
 		}
-		var res2 fr.Element
-		res2.SetBytes(hashBts2[:nbBuf])
-		witness.Public = append(witness.Public, res2)
+		ok = vk.CommitmentKey.Verify(commitment, pok) == nil
 	}
 
-	var kSum2 curve.G1Jac
-	if _, err := kSum2.MultiExp(vk.G1.K[1:], witness.Public, ecc.MultiExpConfig{}); err != nil {
-		return make([]curve.G1Affine, 1)[0], err
-	}
-	kSum2.AddMixed(&vk.G1.K[0])
-	for i := range proof.Commitments {
-		kSum2.AddMixed(&proof.Commitments[i])
+	if !ok {
+		// find the invalid proof
+		for i := range proofs {
+			if err := Verify(&proofs[i], vk, publicWitness[i], opts...); err != nil {
+				return fmt.Errorf("proof %d: %w", i, err)
+			}
+		}
+		return errPairingCheckFailed
 	}
-	var kSumAff curve.G1Affine
-	kSumAff.FromJacobian(&kSum2)
-	return kSumAff, nil
-}
 
-func FoldProof(proof1 *FoldedProof, proof2 *Proof, vk *VerifyingKey, opts ...backend.VerifierOption) (*FoldedProof, error) {
-	r := big.NewInt(1)				// THIS SHOULD BE RANDOM!!! Fiat shamir?
-	
-	//Compute the updated proof
-	foldedProof := &FoldedProof{}
-	foldedProof.Ar = *make([]curve.G1Affine, 1)[0].Add(&proof1.Ar, make([]curve.G1Affine, 1)[0].ScalarMultiplication(&proof2.Ar, r))
-	foldedProof.Bs = *make([]curve.G2Affine, 1)[0].Add(&proof1.Bs, make([]curve.G2Affine, 1)[0].ScalarMultiplication(&proof2.Bs, r))
-	foldedProof.Krs = *make([]curve.G1Affine, 1)[0].Add(&proof1.Krs, make([]curve.G1Affine, 1)[0].ScalarMultiplication(&proof2.Krs, r))
+	log.Debug().Dur("took", time.Since(start)).Msg("batch verifier done")
+	return nil
+}
 
-	return foldedProof, nil
+// ExportSolidity not implemented for BLS12-377
+func (vk *VerifyingKey) ExportSolidity(w io.Writer) error {
+	return errors.New("not implemented")
 }
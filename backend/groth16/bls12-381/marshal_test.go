@@ -19,6 +19,8 @@ package groth16
 import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls12-381"
 
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/fft"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/pedersen"
@@ -67,6 +69,99 @@ func TestProofSerialization(t *testing.T) {
 	properties.TestingRun(t, gopter.ConsoleReporter(false))
 }
 
+func TestFoldedProofSerialization(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("FoldedProof -> writer -> reader -> FoldedProof should stay constant", prop.ForAll(
+		func(ar, krs curve.G1Affine, bs curve.G2Affine) bool {
+			var proof FoldedProof
+
+			// create a random folded proof
+			proof.Ar = ar
+			proof.Krs = krs
+			proof.Bs = bs
+			proof.Commitments = make([]curve.G1Affine, 10)
+			for i := range proof.Commitments {
+				proof.Commitments[i] = ar
+			}
+			proof.CommitmentPok = ar
+
+			err := io.RoundTripCheck(&proof, func() any { return new(FoldedProof) })
+			return err == nil
+		},
+		GenG1(),
+		GenG1(),
+		GenG2(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestFoldingParametersSerialization(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("FoldingParameters -> writer -> reader -> FoldingParameters should stay constant", prop.ForAll(
+		func(p1 curve.G1Affine, p2 curve.G2Affine, r fr.Element) bool {
+			var foldingParameters FoldingParameters
+
+			// create random folding parameters
+			var err error
+			foldingParameters.T, err = curve.Pair([]curve.G1Affine{p1}, []curve.G2Affine{p2})
+			if err != nil {
+				t.Fatal(err)
+				return false
+			}
+			r.BigInt(&foldingParameters.R)
+
+			err = io.RoundTripCheck(&foldingParameters, func() any { return new(FoldingParameters) })
+			return err == nil
+		},
+		GenG1(),
+		GenG2(),
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
+func TestFoldedWitnessSerialization(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 10
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("FoldedWitness -> writer -> reader -> FoldedWitness should stay constant", prop.ForAll(
+		func(p1 curve.G1Affine, p2 curve.G2Affine, mu fr.Element) bool {
+			var witness FoldedWitness
+
+			// create a random folded witness
+			var err error
+			witness.E, err = curve.Pair([]curve.G1Affine{p1}, []curve.G2Affine{p2})
+			if err != nil {
+				t.Fatal(err)
+				return false
+			}
+			witness.H = p1
+			mu.BigInt(&witness.Mu)
+			witness.Commitment = p1
+
+			err = io.RoundTripCheck(&witness, func() any { return new(FoldedWitness) })
+			return err == nil
+		},
+		GenG1(),
+		GenG2(),
+		GenFr(),
+	))
+
+	properties.TestingRun(t, gopter.ConsoleReporter(false))
+}
+
 func TestVerifyingKeySerialization(t *testing.T) {
 
 	roundTrip := func(withCommitment bool) func(curve.G1Affine, curve.G2Affine) bool {
@@ -233,3 +328,14 @@ func GenG2() gopter.Gen {
 		return genResult
 	}
 }
+
+func GenFr() gopter.Gen {
+	return func(genParams *gopter.GenParameters) *gopter.GenResult {
+		var r fr.Element
+		r.SetUint64(genParams.NextUint64())
+		r.Inverse(&r) // spread over the whole field
+
+		genResult := gopter.NewGenResult(r, gopter.NoShrinker)
+		return genResult
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"time"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -36,6 +37,7 @@ import (
 var (
 	errPairingCheckFailed         = errors.New("pairing doesn't match")
 	errCorrectSubgroupCheckFailed = errors.New("points in the proof are not in the correct subgroup")
+	errNoProofToVerify            = errors.New("no proof to verify")
 )
 
 // Verify verifies a proof with given VerifyingKey and publicWitness
@@ -139,6 +141,108 @@ func Verify(proof *Proof, vk *VerifyingKey, publicWitness fr.Vector, opts ...bac
 	return nil
 }
 
+// BatchVerify verifies proofs with the same VerifyingKey. The pairing checks of
+// all the proofs are combined with random scalars ρᵢ into a single one
+//
+//	∏ e(ρᵢ·Arᵢ, Bsᵢ) · e(Σρᵢ·Krsᵢ, -δ) · e(Σρᵢ·Σx.[Kvk(t)]₁ᵢ, -γ) · e(-Σρᵢ·α, β) = 1
+//
+// and the proofs of knowledge of the commitments are combined the same way. If
+// the batch doesn't verify, the proofs are verified one by one to report the
+// first invalid one.
+func BatchVerify(proofs []Proof, vk *VerifyingKey, publicWitness []fr.Vector, opts ...backend.VerifierOption) error {
+	opt, err := backend.NewVerifierConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new verifier config: %w", err)
+	}
+	if opt.HashToFieldFn == nil {
+		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
+	}
+	if len(proofs) == 0 {
+		return errNoProofToVerify
+	}
+	if len(publicWitness) != len(proofs) {
+		return fmt.Errorf("invalid number of public witnesses, got %d, expected %d", len(publicWitness), len(proofs))
+	}
+	log := logger.Logger().With().Str("curve", vk.CurveID().String()).Str("backend", "groth16").Int("nbProofs", len(proofs)).Logger()
+	start := time.Now()
+
+	rho := make([]fr.Element, len(proofs))
+	var rhoSum fr.Element
+	for i := range rho {
+		if _, err := rho[i].SetRandom(); err != nil {
+			return err
+		}
+		rhoSum.Add(&rhoSum, &rho[i])
+	}
+
+	n := len(proofs)
+	g1 := make([]curve.G1Affine, n+3)
+	g2 := make([]curve.G2Affine, n+3)
+	krs := make([]curve.G1Affine, n)
+	kSums := make([]curve.G1Affine, n)
+	commitments := make([]curve.G1Affine, n)
+	poks := make([]curve.G1Affine, n)
+	var b big.Int
+	for i := range proofs {
+		// check that the points in the proof are in the correct subgroup
+		if !proofs[i].isValid() {
+			return fmt.Errorf("proof %d: %w", i, errCorrectSubgroupCheckFailed)
+		}
+		kSums[i], commitments[i], err = computeKSum(vk, &proofs[i], publicWitness[i], opt.HashToFieldFn)
+		if err != nil {
+			return fmt.Errorf("proof %d: %w", i, err)
+		}
+		krs[i] = proofs[i].Krs
+		poks[i] = proofs[i].CommitmentPok
+		g1[i].ScalarMultiplication(&proofs[i].Ar, rho[i].BigInt(&b))
+		g2[i] = proofs[i].Bs
+	}
+
+	var acc curve.G1Jac
+	if _, err := acc.MultiExp(krs, rho, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	g1[n].FromJacobian(&acc)
+	g2[n] = vk.G2.deltaNeg
+	if _, err := acc.MultiExp(kSums, rho, ecc.MultiExpConfig{}); err != nil {
+		return err
+	}
+	g1[n+1].FromJacobian(&acc)
+	g2[n+1] = vk.G2.gammaNeg
+	g1[n+2].ScalarMultiplication(&vk.G1.Alpha, rhoSum.BigInt(&b))
+	g1[n+2].Neg(&g1[n+2])
+	g2[n+2] = vk.G2.Beta
+
+	ok, err := curve.PairingCheck(g1, g2)
+	if err != nil {
+		return err
+	}
+	if ok {
+		// the proofs of knowledge are linear in the commitments
+		var commitment, pok curve.G1Affine
+		if _, err := commitment.MultiExp(commitments, rho, ecc.MultiExpConfig{}); err != nil {
+			return err
+		}
+		if _, err := pok.MultiExp(poks, rho, ecc.MultiExpConfig{}); err != nil {
+			return err
+		}
+		ok = vk.CommitmentKey.Verify(commitment, pok) == nil
+	}
+
+	if !ok {
+		// find the invalid proof
+		for i := range proofs {
+			if err := Verify(&proofs[i], vk, publicWitness[i], opts...); err != nil {
+				return fmt.Errorf("proof %d: %w", i, err)
+			}
+		}
+		return errPairingCheckFailed
+	}
+
+	log.Debug().Dur("took", time.Since(start)).Msg("batch verifier done")
+	return nil
+}
+
 // ExportSolidity not implemented for BLS24-315
 func (vk *VerifyingKey) ExportSolidity(w io.Writer) error {
 	return errors.New("not implemented")
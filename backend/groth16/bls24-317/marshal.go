@@ -19,6 +19,8 @@ package groth16
 import (
 	curve "github.com/consensys/gnark-crypto/ecc/bls24-317"
 
+	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr"
+
 	"github.com/consensys/gnark-crypto/ecc/bls24-317/fr/pedersen"
 	"github.com/consensys/gnark-crypto/utils/unsafe"
 	"github.com/consensys/gnark/internal/utils"
@@ -91,6 +93,199 @@ func (proof *Proof) ReadFrom(r io.Reader) (n int64, err error) {
 	return dec.BytesRead(), nil
 }
 
+// WriteTo writes binary encoding of the FoldedProof elements to writer
+// points are stored in compressed form Ar | Bs | Krs | Commitments | CommitmentPok
+// use WriteRawTo(...) to encode the proof without point compression
+func (proof *FoldedProof) WriteTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the FoldedProof elements to writer
+// points are stored in uncompressed form Ar | Bs | Krs | Commitments | CommitmentPok
+// use WriteTo(...) to encode the proof with point compression
+func (proof *FoldedProof) WriteRawTo(w io.Writer) (n int64, err error) {
+	return proof.writeTo(w, true)
+}
+
+func (proof *FoldedProof) writeTo(w io.Writer, raw bool) (int64, error) {
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	if err := enc.Encode(&proof.Ar); err != nil {
+		return enc.BytesWritten(), err
+	}
+	if err := enc.Encode(&proof.Bs); err != nil {
+		return enc.BytesWritten(), err
+	}
+	if err := enc.Encode(&proof.Krs); err != nil {
+		return enc.BytesWritten(), err
+	}
+	if err := enc.Encode(proof.Commitments); err != nil {
+		return enc.BytesWritten(), err
+	}
+	if err := enc.Encode(&proof.CommitmentPok); err != nil {
+		return enc.BytesWritten(), err
+	}
+
+	return enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode a FoldedProof from reader
+// FoldedProof must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+func (proof *FoldedProof) ReadFrom(r io.Reader) (n int64, err error) {
+
+	dec := curve.NewDecoder(r)
+
+	if err := dec.Decode(&proof.Ar); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&proof.Bs); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&proof.Krs); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&proof.Commitments); err != nil {
+		return dec.BytesRead(), err
+	}
+	if err := dec.Decode(&proof.CommitmentPok); err != nil {
+		return dec.BytesRead(), err
+	}
+
+	return dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the FoldingParameters to writer
+// T | R, T is encoded with GT.Bytes() and R as a field element
+func (foldingParameters *FoldingParameters) WriteTo(w io.Writer) (n int64, err error) {
+	return foldingParameters.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the FoldingParameters to writer
+// FoldingParameters have no points, so the encoding is the same as WriteTo(...)
+func (foldingParameters *FoldingParameters) WriteRawTo(w io.Writer) (n int64, err error) {
+	return foldingParameters.writeTo(w, true)
+}
+
+func (foldingParameters *FoldingParameters) writeTo(w io.Writer, raw bool) (int64, error) {
+	// GT elements are not handled by the encoder
+	t := foldingParameters.T.Bytes()
+	n, err := w.Write(t[:])
+	if err != nil {
+		return int64(n), err
+	}
+
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	var r fr.Element
+	r.SetBigInt(&foldingParameters.R)
+	if err := enc.Encode(&r); err != nil {
+		return int64(n) + enc.BytesWritten(), err
+	}
+
+	return int64(n) + enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode FoldingParameters from reader
+// FoldingParameters must be encoded through WriteTo or WriteRawTo
+func (foldingParameters *FoldingParameters) ReadFrom(r io.Reader) (int64, error) {
+	var t [curve.SizeOfGT]byte
+	n, err := io.ReadFull(r, t[:])
+	if err != nil {
+		return int64(n), err
+	}
+	if err := foldingParameters.T.SetBytes(t[:]); err != nil {
+		return int64(n), err
+	}
+
+	dec := curve.NewDecoder(r)
+
+	var challenge fr.Element
+	if err := dec.Decode(&challenge); err != nil {
+		return int64(n) + dec.BytesRead(), err
+	}
+	challenge.BigInt(&foldingParameters.R)
+
+	return int64(n) + dec.BytesRead(), nil
+}
+
+// WriteTo writes binary encoding of the FoldedWitness to writer
+// E | H | Mu | Commitment, E is encoded with GT.Bytes() and Mu as a field element
+// points are stored in compressed form, use WriteRawTo(...) to encode the
+// witness without point compression
+func (witness *FoldedWitness) WriteTo(w io.Writer) (n int64, err error) {
+	return witness.writeTo(w, false)
+}
+
+// WriteRawTo writes binary encoding of the FoldedWitness to writer
+// E | H | Mu | Commitment, points are stored in uncompressed form
+// use WriteTo(...) to encode the witness with point compression
+func (witness *FoldedWitness) WriteRawTo(w io.Writer) (n int64, err error) {
+	return witness.writeTo(w, true)
+}
+
+func (witness *FoldedWitness) writeTo(w io.Writer, raw bool) (int64, error) {
+	// GT elements are not handled by the encoder
+	e := witness.E.Bytes()
+	n, err := w.Write(e[:])
+	if err != nil {
+		return int64(n), err
+	}
+
+	var enc *curve.Encoder
+	if raw {
+		enc = curve.NewEncoder(w, curve.RawEncoding())
+	} else {
+		enc = curve.NewEncoder(w)
+	}
+
+	var mu fr.Element
+	mu.SetBigInt(&witness.Mu)
+	toEncode := []interface{}{&witness.H, &mu, &witness.Commitment}
+	for _, v := range toEncode {
+		if err := enc.Encode(v); err != nil {
+			return int64(n) + enc.BytesWritten(), err
+		}
+	}
+
+	return int64(n) + enc.BytesWritten(), nil
+}
+
+// ReadFrom attempts to decode a FoldedWitness from reader
+// FoldedWitness must be encoded through WriteTo (compressed) or WriteRawTo (uncompressed)
+func (witness *FoldedWitness) ReadFrom(r io.Reader) (int64, error) {
+	var e [curve.SizeOfGT]byte
+	n, err := io.ReadFull(r, e[:])
+	if err != nil {
+		return int64(n), err
+	}
+	if err := witness.E.SetBytes(e[:]); err != nil {
+		return int64(n), err
+	}
+
+	dec := curve.NewDecoder(r)
+
+	var mu fr.Element
+	toDecode := []interface{}{&witness.H, &mu, &witness.Commitment}
+	for _, v := range toDecode {
+		if err := dec.Decode(v); err != nil {
+			return int64(n) + dec.BytesRead(), err
+		}
+	}
+	mu.BigInt(&witness.Mu)
+
+	return int64(n) + dec.BytesRead(), nil
+}
+
 // WriteTo writes binary encoding of the key elements to writer
 // points are compressed
 // use WriteRawTo(...) to encode the key without point compression
@@ -0,0 +1,610 @@
+package groth16
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr/hash_to_field"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/logger"
+)
+
+var errFoldedSolidityCommitments = errors.New("the folded solidity verifier doesn't support commitments")
+
+// solidityFoldedBatchTemplate
+// this is an experimental feature and gnark solidity generator as not been thoroughly tested
+//
+// The contract batch-verifies the proofs folded with FoldProofs, using the
+// folding challenges rⱼ as randomness (r₀ = 1), and checks that the folded
+// proof is their fold. It doesn't check the folded pairing equation
+//
+//	e(Ar, Bs) = E · e(α, β)^{μ²} · e(μ·Krs, δ) · e(H, γ)^μ
+//
+// as the EVM can't operate on the target group elements E and T. The cross
+// terms T and the running error terms E of the foldingParameters argument, see
+// MarshalFoldingParametersSolidity, only enter the sha256 transcript of
+// deriveFoldingChallenge. So the contract accepts wrong T or E as long as the
+// proofs are valid and the folded proof is folded with the challenges derived
+// from them, which VerifyFolded rejects.
+//
+// Every other input is checked. The challenges bind all the proofs and public
+// inputs, the first proof through the running instance of the first folding
+// step. With a challenge c binding the folded proof and the challenges, the
+// contract checks for n proofs
+//
+//	Π e(rⱼ²·Aⱼ - cⱼ·rⱼ·G, Bⱼ) · Π e(dₖ·G, Sₖ) · e(Σ rⱼ²·Cⱼ, -δ) · e(Σ rⱼ²·Hⱼ, -γ) · e(Σ rⱼ²·α, -β) = 1
+//
+// where Hⱼ is the public input term of the j-th proof, cⱼ = c^max(j,1) (0 for a
+// single proof), Sₖ is the running Bs after k folding steps (the folded Bs for
+// k = n-1), dₖ = cᵏ - cᵏ⁺¹ and dₙ₋₁ = cⁿ⁻¹. The first product and the last three
+// pairings are the batched Groth16 equations, the others check
+// Sₖ = Sₖ₋₁ + rₖ·Bₖ with S₀ = B₀. This is 2n + 2 pairings, against 4n to verify
+// the proofs one by one.
+const solidityFoldedBatchTemplate = `
+{{- $numPublic := sub (len .G1.K) 1 }}
+// SPDX-License-Identifier: MIT
+
+pragma solidity ^0.8.0;
+
+/// @title Folded Groth16 batch verifier template.
+/// @notice Supports batch-verifying Groth16 proofs folded with
+/// groth16.FoldProofs, given the folded proof, the folded proofs, their public
+/// inputs and the folding parameters. The folded pairing equation itself is not
+/// checked.
+contract Verifier {
+
+    /// Some of the provided public input values are larger than the field modulus.
+    /// @dev Public input elements are not automatically reduced, as this is can be
+    /// a dangerous source of bugs.
+    error PublicInputNotInField();
+
+    /// The proof is invalid.
+    /// @dev This can mean that provided Groth16 proof points are not on their
+    /// curves, that the batched pairing equation fails, or that the proofs are
+    /// not for the provided public inputs.
+    error ProofInvalid();
+
+    /// The folding is invalid.
+    /// @dev This can mean that the number of proofs, public inputs and folding
+    /// parameters don't match, or that the folded proof is not the one folded
+    /// from the proofs.
+    error FoldingInvalid();
+
+    // Addresses of precompiles
+    uint256 constant PRECOMPILE_SHA256 = 0x02;
+    uint256 constant PRECOMPILE_ADD = 0x06;
+    uint256 constant PRECOMPILE_MUL = 0x07;
+    uint256 constant PRECOMPILE_VERIFY = 0x08;
+
+    // Scalar field Fr order R.
+    uint256 constant R = 0x30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001;
+
+    // Generator of G1
+    uint256 constant G1_X = 1;
+    uint256 constant G1_Y = 2;
+
+    // Groth16 alpha point in G1
+    uint256 constant ALPHA_X = {{.G1.Alpha.X.String}};
+    uint256 constant ALPHA_Y = {{.G1.Alpha.Y.String}};
+
+    // Groth16 beta point in G2 in powers of i
+    uint256 constant BETA_NEG_X_0 = {{.G2.Beta.X.A0.String}};
+    uint256 constant BETA_NEG_X_1 = {{.G2.Beta.X.A1.String}};
+    uint256 constant BETA_NEG_Y_0 = {{.G2.Beta.Y.A0.String}};
+    uint256 constant BETA_NEG_Y_1 = {{.G2.Beta.Y.A1.String}};
+
+    // Groth16 gamma point in G2 in powers of i
+    uint256 constant GAMMA_NEG_X_0 = {{.G2.Gamma.X.A0.String}};
+    uint256 constant GAMMA_NEG_X_1 = {{.G2.Gamma.X.A1.String}};
+    uint256 constant GAMMA_NEG_Y_0 = {{.G2.Gamma.Y.A0.String}};
+    uint256 constant GAMMA_NEG_Y_1 = {{.G2.Gamma.Y.A1.String}};
+
+    // Groth16 delta point in G2 in powers of i
+    uint256 constant DELTA_NEG_X_0 = {{.G2.Delta.X.A0.String}};
+    uint256 constant DELTA_NEG_X_1 = {{.G2.Delta.X.A1.String}};
+    uint256 constant DELTA_NEG_Y_0 = {{.G2.Delta.Y.A0.String}};
+    uint256 constant DELTA_NEG_Y_1 = {{.G2.Delta.Y.A1.String}};
+
+    // Constant and public input points
+    {{- $k0 := index .G1.K 0}}
+    uint256 constant CONSTANT_X = {{$k0.X.String}};
+    uint256 constant CONSTANT_Y = {{$k0.Y.String}};
+    {{- range $i, $ki := .G1.K }}
+        {{- if gt $i 0 }}
+    uint256 constant PUB_{{sub $i 1}}_X = {{$ki.X.String}};
+    uint256 constant PUB_{{sub $i 1}}_Y = {{$ki.Y.String}};
+        {{- end }}
+    {{- end }}
+
+    /// Multiply and add in G1.
+    /// @notice Reverts with ProofInvalid if a point is not on the curve.
+    /// @param ax The X coordinate of the point a.
+    /// @param ay The Y coordinate of the point a.
+    /// @param px The X coordinate of the point p.
+    /// @param py The Y coordinate of the point p.
+    /// @param s The scalar.
+    /// @return x The X coordinate of a + s⋅p.
+    /// @return y The Y coordinate of a + s⋅p.
+    function ecMulAdd(uint256 ax, uint256 ay, uint256 px, uint256 py, uint256 s)
+    internal view returns (uint256 x, uint256 y) {
+        bool success;
+        assembly ("memory-safe") {
+            let f := mload(0x40)
+            mstore(f, ax)
+            mstore(add(f, 0x20), ay)
+            mstore(add(f, 0x40), px)
+            mstore(add(f, 0x60), py)
+            mstore(add(f, 0x80), s)
+            success := staticcall(gas(), PRECOMPILE_MUL, add(f, 0x40), 0x60, add(f, 0x40), 0x40)
+            success := and(success, staticcall(gas(), PRECOMPILE_ADD, f, 0x80, f, 0x40))
+            x := mload(f)
+            y := mload(add(f, 0x20))
+        }
+        if (!success) {
+            // Point not on the curve.
+            revert ProofInvalid();
+        }
+    }
+
+    /// Compute the public input linear combination.
+    /// @notice Reverts with PublicInputNotInField if the input is not in the field.
+    /// @notice Computes the multi-scalar-multiplication of the public input
+    /// elements and the verification key including the constant term.
+    /// @param input The public inputs. These are elements of the scalar field Fr.
+    /// @return x The X coordinate of the resulting G1 point.
+    /// @return y The Y coordinate of the resulting G1 point.
+    function publicInputMSM(uint256[{{$numPublic}}] calldata input)
+    internal view returns (uint256 x, uint256 y) {
+        // Note: The ECMUL precompile does not reject unreduced values, so we check this.
+        // ECMUL has input (x, y, scalar) and output (x', y').
+        // ECADD has input (x1, y1, x2, y2) and output (x', y').
+        // We call them such that ecmul output is already in the second point
+        // argument to ECADD so we can have a tight loop.
+        bool success = true;
+        assembly ("memory-safe") {
+            let f := mload(0x40)
+            let g := add(f, 0x40)
+            let s
+            mstore(f, CONSTANT_X)
+            mstore(add(f, 0x20), CONSTANT_Y)
+            {{- range $i := intRange $numPublic }}
+            mstore(g, PUB_{{$i}}_X)
+            mstore(add(g, 0x20), PUB_{{$i}}_Y)
+            s := calldataload(add(input, {{mul $i 0x20}}))
+            mstore(add(g, 0x40), s)
+            success := and(success, lt(s, R))
+            success := and(success, staticcall(gas(), PRECOMPILE_MUL, g, 0x60, g, 0x40))
+            success := and(success, staticcall(gas(), PRECOMPILE_ADD, f, 0x80, f, 0x40))
+            {{- end }}
+
+            x := mload(f)
+            y := mload(add(f, 0x20))
+        }
+        if (!success) {
+            // Either Public input not in field, or verification key invalid.
+            // We assume the contract is correctly generated, so the verification key is valid.
+            revert PublicInputNotInField();
+        }
+    }
+
+    /// Derive the challenge of a folding step.
+    /// @notice Hashes the same transcript as groth16.FoldProofs with sha256:
+    /// "r" ‖ Ar ‖ Bs ‖ Krs ‖ CommitmentPok ‖ H ‖ E ‖ μ ‖ Commitment of the
+    /// running folded instance, then Ar ‖ Bs ‖ Krs ‖ CommitmentPok ‖ input of
+    /// the folded proof, then the cross term T. There are no commitments, so
+    /// CommitmentPok and Commitment are the point at infinity.
+    /// @param acc The running Ar, Krs, H and μ.
+    /// @param foldingParameters The cross term T, then the running E and Bs.
+    /// @param proof The folded proof.
+    /// @param input The public inputs of the folded proof.
+    /// @return r The folding challenge.
+    function foldingChallenge(
+        uint256[7] memory acc,
+        uint256[28] calldata foldingParameters,
+        uint256[8] calldata proof,
+        uint256[{{$numPublic}}] calldata input
+    ) internal view returns (uint256 r) {
+        bool success;
+        assembly ("memory-safe") {
+            let f := mload(0x40)
+            mstore8(f, 0x72) // "r"
+            let p := add(f, 1)
+
+            // Running folded proof
+            mstore(p, mload(acc))
+            mstore(add(p, 0x20), mload(add(acc, 0x20)))
+            calldatacopy(add(p, 0x40), add(foldingParameters, 0x300), 0x80)
+            mstore(add(p, 0xc0), mload(add(acc, 0x40)))
+            mstore(add(p, 0xe0), mload(add(acc, 0x60)))
+            mstore(add(p, 0x100), 0)
+            mstore(add(p, 0x120), 0)
+
+            // Running folded witness
+            mstore(add(p, 0x140), mload(add(acc, 0x80)))
+            mstore(add(p, 0x160), mload(add(acc, 0xa0)))
+            calldatacopy(add(p, 0x180), add(foldingParameters, 0x180), 0x180)
+            mstore(add(p, 0x300), mload(add(acc, 0xc0)))
+            mstore(add(p, 0x320), 0)
+            mstore(add(p, 0x340), 0)
+
+            // Folded proof and its public inputs
+            calldatacopy(add(p, 0x360), proof, 0x100)
+            mstore(add(p, 0x460), 0)
+            mstore(add(p, 0x480), 0)
+            calldatacopy(add(p, 0x4a0), input, {{mul $numPublic 0x20}})
+
+            // Cross term
+            calldatacopy(add(p, {{sum 0x4a0 (mul $numPublic 0x20)}}), foldingParameters, 0x180)
+
+            success := staticcall(gas(), PRECOMPILE_SHA256, f, {{sum 0x621 (mul $numPublic 0x20)}}, f, 0x20)
+            r := mod(mload(f), R)
+        }
+        if (!success) {
+            // Hashing failed.
+            // Should not happen.
+            revert FoldingInvalid();
+        }
+    }
+
+    /// Fold the proofs.
+    /// @notice Reverts with PublicInputNotInField if an input is not reduced
+    /// and with ProofInvalid if a point is not on the curve.
+    /// @param foldingParameters The folding parameters of every folding step.
+    /// @param proofs The folded proofs.
+    /// @param input The public inputs of the folded proofs.
+    /// @return r The folding challenges, starting with 1 for the first proof.
+    /// @return sums Σ rⱼ²⋅Krsⱼ, Σ rⱼ²⋅Hⱼ and Σ rⱼ².
+    /// @return acc The folded Ar, Krs, H and μ.
+    function fold(
+        uint256[28][] calldata foldingParameters,
+        uint256[8][] calldata proofs,
+        uint256[{{$numPublic}}][] calldata input
+    ) internal view returns (uint256[] memory r, uint256[5] memory sums, uint256[7] memory acc) {
+        r = new uint256[](proofs.length);
+        for (uint256 j = 0; j < proofs.length; j++) {
+            uint256 rj = 1;
+            if (j > 0) {
+                rj = foldingChallenge(acc, foldingParameters[j - 1], proofs[j], input[j]);
+            }
+            r[j] = rj;
+            (uint256 hx, uint256 hy) = publicInputMSM(input[j]);
+            if (j == 0) {
+                acc[0] = proofs[0][0];
+                acc[1] = proofs[0][1];
+                acc[2] = proofs[0][6];
+                acc[3] = proofs[0][7];
+                acc[4] = hx;
+                acc[5] = hy;
+                acc[6] = 1;
+            } else {
+                (acc[0], acc[1]) = ecMulAdd(acc[0], acc[1], proofs[j][0], proofs[j][1], rj);
+                (acc[2], acc[3]) = ecMulAdd(acc[2], acc[3], proofs[j][6], proofs[j][7], rj);
+                (acc[4], acc[5]) = ecMulAdd(acc[4], acc[5], hx, hy, rj);
+                acc[6] = addmod(acc[6], rj, R);
+            }
+            rj = mulmod(rj, rj, R);
+            (sums[0], sums[1]) = ecMulAdd(sums[0], sums[1], proofs[j][6], proofs[j][7], rj);
+            (sums[2], sums[3]) = ecMulAdd(sums[2], sums[3], hx, hy, rj);
+            sums[4] = addmod(sums[4], rj, R);
+        }
+    }
+
+    /// Check that the folded Ar and Krs are the fold of the proofs.
+    /// @notice Reverts with FoldingInvalid otherwise.
+    /// @param foldedProof The folded points (Ar, Bs, Krs).
+    /// @param foldingParameters The folding parameters of every folding step.
+    /// @param proofs The folded proofs.
+    /// @param input The public inputs of the folded proofs.
+    /// @return r The folding challenges, starting with 1 for the first proof.
+    /// @return sums Σ rⱼ²⋅Krsⱼ, Σ rⱼ²⋅Hⱼ and Σ rⱼ².
+    function checkFold(
+        uint256[8] calldata foldedProof,
+        uint256[28][] calldata foldingParameters,
+        uint256[8][] calldata proofs,
+        uint256[{{$numPublic}}][] calldata input
+    ) internal view returns (uint256[] memory r, uint256[5] memory sums) {
+        uint256[7] memory acc;
+        (r, sums, acc) = fold(foldingParameters, proofs, input);
+        if (acc[0] != foldedProof[0] || acc[1] != foldedProof[1] || acc[2] != foldedProof[6] || acc[3] != foldedProof[7]) {
+            revert FoldingInvalid();
+        }
+    }
+
+    /// Check that the running Bs of the first folding step is the B point of
+    /// the first proof, so that it is bound by the first folding challenge.
+    /// With a single proof, the folded Bs must be its B point.
+    /// @notice Reverts with FoldingInvalid otherwise.
+    /// @param foldedProof The folded points (Ar, Bs, Krs).
+    /// @param foldingParameters The folding parameters of every folding step.
+    /// @param proof The first proof.
+    function checkFirstBs(
+        uint256[8] calldata foldedProof,
+        uint256[28][] calldata foldingParameters,
+        uint256[8] calldata proof
+    ) internal pure {
+        bool success;
+        if (foldingParameters.length == 0) {
+            success = proof[2] == foldedProof[2] && proof[3] == foldedProof[3]
+                && proof[4] == foldedProof[4] && proof[5] == foldedProof[5];
+        } else {
+            uint256[28] calldata first = foldingParameters[0];
+            success = proof[2] == first[24] && proof[3] == first[25]
+                && proof[4] == first[26] && proof[5] == first[27];
+        }
+        if (!success) {
+            revert FoldingInvalid();
+        }
+    }
+
+    /// Set the pairings of the batched proofs, e(rⱼ²⋅Aⱼ - cⱼ⋅rⱼ⋅G, Bⱼ) with
+    /// cⱼ = c^max(j,1), at the start of pairings. With a single proof there is
+    /// no running Bs to check and cⱼ = 0.
+    /// @param proofs The folded proofs.
+    /// @param r The folding challenges.
+    /// @param c The challenge binding the running Bs.
+    /// @param pairings The input of the pairing precompile.
+    function setProofPairings(
+        uint256[8][] calldata proofs,
+        uint256[] memory r,
+        uint256 c,
+        uint256[] memory pairings
+    ) internal view {
+        uint256 cj = proofs.length > 1 ? c : 0;
+        for (uint256 j = 0; j < proofs.length; j++) {
+            if (j > 1) {
+                cj = mulmod(cj, c, R);
+            }
+            uint256 k = 6 * j;
+            (uint256 x, uint256 y) = ecMulAdd(0, 0, G1_X, G1_Y, R - mulmod(cj, r[j], R));
+            (pairings[k], pairings[k + 1]) = ecMulAdd(x, y, proofs[j][0], proofs[j][1], mulmod(r[j], r[j], R));
+            pairings[k + 2] = proofs[j][2];
+            pairings[k + 3] = proofs[j][3];
+            pairings[k + 4] = proofs[j][4];
+            pairings[k + 5] = proofs[j][5];
+        }
+    }
+
+    /// Set the pairings of the running Bs, e((cₖ - cₖ₊₁)⋅G, Sₖ) for the k-th
+    /// folding step, after the pairings of the proofs. The last running Bs is
+    /// the folded Bs.
+    /// @param foldedProof The folded points (Ar, Bs, Krs).
+    /// @param foldingParameters The folding parameters of every folding step.
+    /// @param c The challenge binding the running Bs.
+    /// @param pairings The input of the pairing precompile.
+    function setRunningBsPairings(
+        uint256[8] calldata foldedProof,
+        uint256[28][] calldata foldingParameters,
+        uint256 c,
+        uint256[] memory pairings
+    ) internal view {
+        uint256 n = foldingParameters.length;
+        uint256 ck = c;
+        for (uint256 k = 1; k <= n; k++) {
+            if (k > 1) {
+                ck = mulmod(ck, c, R);
+            }
+            uint256 m = 6 * (n + k);
+            if (k < n) {
+                (pairings[m], pairings[m + 1]) = ecMulAdd(0, 0, G1_X, G1_Y, addmod(ck, R - mulmod(ck, c, R), R));
+                pairings[m + 2] = foldingParameters[k][24];
+                pairings[m + 3] = foldingParameters[k][25];
+                pairings[m + 4] = foldingParameters[k][26];
+                pairings[m + 5] = foldingParameters[k][27];
+            } else {
+                (pairings[m], pairings[m + 1]) = ecMulAdd(0, 0, G1_X, G1_Y, ck);
+                pairings[m + 2] = foldedProof[2];
+                pairings[m + 3] = foldedProof[3];
+                pairings[m + 4] = foldedProof[4];
+                pairings[m + 5] = foldedProof[5];
+            }
+        }
+    }
+
+    /// Check the batched pairing equation.
+    /// @notice Reverts with ProofInvalid if the pairing equation fails.
+    /// @param foldedProof The folded points (Ar, Bs, Krs).
+    /// @param foldingParameters The folding parameters of every folding step.
+    /// @param proofs The folded proofs.
+    /// @param r The folding challenges.
+    /// @param sums Σ rⱼ²⋅Krsⱼ, Σ rⱼ²⋅Hⱼ and Σ rⱼ².
+    function checkBatchedPairing(
+        uint256[8] calldata foldedProof,
+        uint256[28][] calldata foldingParameters,
+        uint256[8][] calldata proofs,
+        uint256[] memory r,
+        uint256[5] memory sums
+    ) internal view {
+        // c binds the running Bs, through the challenges, and the folded Bs.
+        uint256 c = uint256(keccak256(abi.encodePacked(foldedProof, r))) % R;
+
+        // Note: The precompile expects the F2 coefficients in big-endian order.
+        // Note: The pairing precompile rejects unreduced values, so we won't check that here.
+        uint256[] memory pairings = new uint256[](6 * (2 * proofs.length + 2));
+        setProofPairings(proofs, r, c, pairings);
+        setRunningBsPairings(foldedProof, foldingParameters, c, pairings);
+
+        uint256 k = 6 * (2 * proofs.length - 1);
+        // e(Σ rⱼ²⋅Cⱼ, -δ)
+        pairings[k] = sums[0];
+        pairings[k + 1] = sums[1];
+        pairings[k + 2] = DELTA_NEG_X_1;
+        pairings[k + 3] = DELTA_NEG_X_0;
+        pairings[k + 4] = DELTA_NEG_Y_1;
+        pairings[k + 5] = DELTA_NEG_Y_0;
+        // e(Σ rⱼ²⋅L_pubⱼ, -γ)
+        pairings[k + 6] = sums[2];
+        pairings[k + 7] = sums[3];
+        pairings[k + 8] = GAMMA_NEG_X_1;
+        pairings[k + 9] = GAMMA_NEG_X_0;
+        pairings[k + 10] = GAMMA_NEG_Y_1;
+        pairings[k + 11] = GAMMA_NEG_Y_0;
+        // e(Σ rⱼ²⋅α, -β)
+        (pairings[k + 12], pairings[k + 13]) = ecMulAdd(0, 0, ALPHA_X, ALPHA_Y, sums[4]);
+        pairings[k + 14] = BETA_NEG_X_1;
+        pairings[k + 15] = BETA_NEG_X_0;
+        pairings[k + 16] = BETA_NEG_Y_1;
+        pairings[k + 17] = BETA_NEG_Y_0;
+
+        // Check pairing equation.
+        bool success;
+        assembly ("memory-safe") {
+            let f := mload(0x40)
+            success := staticcall(gas(), PRECOMPILE_VERIFY, add(pairings, 0x20), mul(mload(pairings), 0x20), f, 0x20)
+            // Also check returned value (both are either 1 or 0).
+            success := and(success, mload(f))
+        }
+        if (!success) {
+            // Either proof or verification key invalid.
+            // We assume the contract is correctly generated, so the verification key is valid.
+            revert ProofInvalid();
+        }
+    }
+
+    /// Batch-verify the proofs a folded Groth16 proof was folded from.
+    /// @notice Reverts with ProofInvalid if a proof is invalid, with
+    /// FoldingInvalid if the folded proof is not folded from the proofs, or with
+    /// PublicInputNotInField if a public input is not reduced.
+    /// @notice The cross terms T and the running error terms E are not checked,
+    /// they only enter the derivation of the folding challenges.
+    /// @notice There is no return value. If the function does not revert, the
+    /// proofs were successfully verified.
+    /// @param foldedProof the folded points (Ar, Bs, Krs) in EIP-197 format.
+    /// @param foldingParameters for every proof but the first, the cross term T
+    /// of folding it, then the error term E and Bs of the running folded
+    /// instance it is folded into, as returned by MarshalFoldingParametersSolidity.
+    /// @param proofs the folded proofs, points (A, B, C) in EIP-197 format.
+    /// @param input the public inputs of the folded proofs. Elements must be reduced.
+    function verifyFoldedBatch(
+        uint256[8] calldata foldedProof,
+        uint256[28][] calldata foldingParameters,
+        uint256[8][] calldata proofs,
+        uint256[{{$numPublic}}][] calldata input
+    ) public view {
+        if (proofs.length == 0 || input.length != proofs.length || foldingParameters.length + 1 != proofs.length) {
+            revert FoldingInvalid();
+        }
+        checkFirstBs(foldedProof, foldingParameters, proofs[0]);
+        (uint256[] memory r, uint256[5] memory sums) = checkFold(foldedProof, foldingParameters, proofs, input);
+        checkBatchedPairing(foldedProof, foldingParameters, proofs, r, sums);
+    }
+}
+`
+
+// ExportFoldedBatchSolidity writes a solidity Verifier contract for proofs
+// folded with FoldProofs on provided writer. Its verifyFoldedBatch function
+// batch-verifies the proofs, using the folding challenges as randomness, and
+// checks that the folded proof is their fold.
+//
+// Unlike VerifyFolded, the contract doesn't check the folded pairing equation:
+// the cross terms and the error terms of the folding parameters are elements of
+// the target group, which the EVM can't operate on, and only enter the
+// derivation of the folding challenges.
+//
+// The folding challenges must be derived with the default `sha256` challenge
+// hash, and commitments are not supported.
+// This is an experimental feature and gnark solidity generator as not been thoroughly tested.
+func (vk *VerifyingKey) ExportFoldedBatchSolidity(w io.Writer) error {
+	helpers := template.FuncMap{
+		"sum": func(a, b int) int {
+			return a + b
+		},
+		"sub": func(a, b int) int {
+			return a - b
+		},
+		"mul": func(a, b int) int {
+			return a * b
+		},
+		"intRange": func(max int) []int {
+			out := make([]int, max)
+			for i := 0; i < max; i++ {
+				out[i] = i
+			}
+			return out
+		},
+	}
+
+	if len(vk.PublicAndCommitmentCommitted) > 0 {
+		return errFoldedSolidityCommitments
+	}
+	log := logger.Logger()
+	log.Warn().Msg("exporting folded solidity verifier only supports `sha256` as challenge hash. The generated contract may not work for proofs folded with other hash functions.")
+
+	tmpl, err := template.New("").Funcs(helpers).Parse(solidityFoldedBatchTemplate)
+	if err != nil {
+		return err
+	}
+
+	// negate Beta, Gamma and Delta, to avoid negating proof elements in the
+	// verifier. vk may be shared, so we write to a copy.
+	_vk := *vk
+	_vk.G2.Beta.Neg(&vk.G2.Beta)
+	_vk.G2.Gamma, _vk.G2.Delta = vk.G2.gammaNeg, vk.G2.deltaNeg
+
+	return tmpl.Execute(w, &_vk)
+}
+
+// MarshalSolidity returns the folded points (Ar, Bs, Krs) in the format of
+// the foldedProof argument of the contract exported by
+// ExportFoldedBatchSolidity.
+func (proof *FoldedProof) MarshalSolidity() []byte {
+	var buf bytes.Buffer
+	buf.Write(proof.Ar.Marshal())
+	buf.Write(proof.Bs.Marshal())
+	buf.Write(proof.Krs.Marshal())
+	return buf.Bytes()
+}
+
+// MarshalFoldingParametersSolidity returns the foldingParameters argument of
+// the contract exported by ExportFoldedBatchSolidity, for proofs folded with
+// foldingParameters. For every folding step it holds the cross term T, then
+// the error term E and Bs of the running folded instance, which the contract
+// needs to derive the folding challenge but can't compute.
+func MarshalFoldingParametersSolidity(foldingParameters []FoldingParameters, vk *VerifyingKey, proofs []Proof, publicWitness []fr.Vector, opts ...backend.VerifierOption) ([]byte, error) {
+	opt, err := backend.NewVerifierConfig(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("new verifier config: %w", err)
+	}
+	if opt.HashToFieldFn == nil {
+		opt.HashToFieldFn = hash_to_field.New([]byte(constraint.CommitmentDst))
+	}
+	if len(proofs) == 0 {
+		return nil, errNoProofToFold
+	}
+	if len(publicWitness) != len(proofs) {
+		return nil, errInvalidFoldingPublics
+	}
+	if len(foldingParameters) != len(proofs)-1 {
+		return nil, fmt.Errorf("invalid number of folding parameters, got %d, expected %d", len(foldingParameters), len(proofs)-1)
+	}
+
+	kSumAff, commitment, err := computeKSum(vk, &proofs[0], publicWitness[0], opt.HashToFieldFn)
+	if err != nil {
+		return nil, err
+	}
+	foldedWitness := newFoldedWitness(&kSumAff, &commitment)
+	foldedProof := newFoldedProof(&proofs[0])
+	var buf bytes.Buffer
+	for i := 1; i < len(proofs); i++ {
+		t, e := foldingParameters[i-1].T.Bytes(), foldedWitness.E.Bytes()
+		buf.Write(t[:])
+		buf.Write(e[:])
+		buf.Write(foldedProof.Bs.Marshal())
+
+		foldingPars := FoldingParameters{T: foldingParameters[i-1].T}
+		foldingPars.R, err = deriveFoldingChallenge(opt.ChallengeHash, foldedProof, &foldedWitness, &proofs[i], publicWitness[i], &foldingPars.T)
+		if err != nil {
+			return nil, err
+		}
+		if kSumAff, commitment, err = computeKSum(vk, &proofs[i], publicWitness[i], opt.HashToFieldFn); err != nil {
+			return nil, err
+		}
+		foldedWitness.fold(&kSumAff, &commitment, &startingWitness, &foldingPars)
+		foldedProof = FoldProof(foldedProof, &proofs[i], &foldingPars)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+//go:build solccheck
+
+package groth16_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+// TestFoldedBatchSolidity compiles the folded batch solidity verifier with
+// gnark-solidity-checker, see test.WithSolidity, and calls it on a simulated
+// EVM with the calldata returned by the marshal functions. gnark-solidity-checker
+// verify only knows the verifyProof functions of the Groth16 and PLONK
+// verifiers, so the contract is compiled with solc and called with the
+// testdata/evmrunner command.
+func TestFoldedBatchSolidity(t *testing.T) {
+	const nbProofs = 3
+	vk, proofs, publicWitness := foldedSolidityInstances(t, nbProofs)
+	foldedProof, foldingParameters, err := groth16_bn254.FoldProofs(proofs, vk, publicWitness)
+	require.NoError(t, err)
+	bFoldingParameters, err := groth16_bn254.MarshalFoldingParametersSolidity(foldingParameters, vk, proofs, publicWitness)
+	require.NoError(t, err)
+
+	tmpDir, err := os.MkdirTemp("", "gnark-solidity-check*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	fSolidity, err := os.Create(filepath.Join(tmpDir, "gnark_verifier.sol"))
+	require.NoError(t, err)
+	require.NoError(t, vk.ExportFoldedBatchSolidity(fSolidity))
+	require.NoError(t, fSolidity.Close())
+
+	run := func(dir, name string, args ...string) []byte {
+		cmd := exec.Command(name, args...)
+		cmd.Dir = dir
+		t.Log("running ", cmd.String())
+		out, err := cmd.Output()
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			require.NoError(t, err, string(exitErr.Stderr))
+		}
+		require.NoError(t, err)
+		return out
+	}
+	run(tmpDir, "gnark-solidity-checker", "generate", "--dir", tmpDir, "--solidity", "gnark_verifier.sol")
+
+	// bytecode of the contract
+	var combined struct {
+		Contracts map[string]struct {
+			Bin string `json:"bin"`
+		} `json:"contracts"`
+	}
+	require.NoError(t, json.Unmarshal(run(tmpDir, "solc", "--combined-json", "bin", "gnark_verifier.sol"), &combined))
+	bytecode := combined.Contracts["gnark_verifier.sol:Verifier"].Bin
+	require.NotEmpty(t, bytecode)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "bytecode.hex"), []byte(bytecode), 0o600))
+
+	// EVM runner
+	runnerDir := filepath.Join(tmpDir, "evmrunner")
+	require.NoError(t, os.Mkdir(runnerDir, 0o700))
+	for _, f := range []string{"main.go", "go.mod", "go.sum"} {
+		b, err := os.ReadFile(filepath.Join("testdata", "evmrunner", f))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(runnerDir, f), b, 0o600))
+	}
+	runner := filepath.Join(tmpDir, "evmrunner.bin")
+	run(runnerDir, "go", "build", "-o", runner, ".")
+
+	call := func(foldingParameters []byte) error {
+		calldata := encodeVerifyFoldedBatch(foldedProof.MarshalSolidity(), foldingParameters, proofs, publicWitness)
+		fCalldata := filepath.Join(tmpDir, "calldata.hex")
+		if err := os.WriteFile(fCalldata, []byte(hex.EncodeToString(calldata)), 0o600); err != nil {
+			return err
+		}
+		out, err := exec.Command(runner, filepath.Join(tmpDir, "bytecode.hex"), fCalldata).CombinedOutput()
+		t.Log(string(out))
+		return err
+	}
+	assert.NoError(t, call(bFoldingParameters), "valid folding")
+
+	// the cross term T of the first folding step
+	tampered := bytes.Clone(bFoldingParameters)
+	tampered[fr.Bytes-1] ^= 1
+	assert.Error(t, call(tampered), "tampered T")
+
+	// the running error term E of the first folding step
+	tampered = bytes.Clone(bFoldingParameters)
+	tampered[13*fr.Bytes-1] ^= 1
+	assert.Error(t, call(tampered), "tampered E")
+}
+
+// encodeVerifyFoldedBatch returns the ABI encoded call to verifyFoldedBatch.
+func encodeVerifyFoldedBatch(foldedProof, foldingParameters []byte, proofs []groth16_bn254.Proof, publicWitness []fr.Vector) []byte {
+	nbPublic := len(publicWitness[0])
+	var buf bytes.Buffer
+	writeUint := func(v int) {
+		var w [32]byte
+		binary.BigEndian.PutUint64(w[24:], uint64(v))
+		buf.Write(w[:])
+	}
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write([]byte("verifyFoldedBatch(uint256[8],uint256[28][],uint256[8][],uint256[" + strconv.Itoa(nbPublic) + "][])"))
+	buf.Write(h.Sum(nil)[:4])
+
+	// head: the folded proof, then the offsets of the dynamic arrays
+	buf.Write(foldedProof)
+	offset := 11 * 32
+	writeUint(offset)
+	offset += 32 + len(foldingParameters)
+	writeUint(offset)
+	offset += 32 + len(proofs)*8*32
+	writeUint(offset)
+
+	// tail
+	writeUint(len(foldingParameters) / (28 * 32))
+	buf.Write(foldingParameters)
+	writeUint(len(proofs))
+	for i := range proofs {
+		buf.Write(proofs[i].MarshalSolidity())
+	}
+	writeUint(len(publicWitness))
+	for i := range publicWitness {
+		for j := range publicWitness[i] {
+			b := publicWitness[i][j].Bytes()
+			buf.Write(b[:])
+		}
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,187 @@
+package groth16_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	curve "github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
+)
+
+type foldedSolidityCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+	Z frontend.Variable `gnark:",public"`
+}
+
+func (c *foldedSolidityCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X, c.X), c.Y)
+	api.AssertIsEqual(api.Add(c.X, 1), c.Z)
+	return nil
+}
+
+func foldedSolidityInstances(t *testing.T, nbProofs int) (*groth16_bn254.VerifyingKey, []groth16_bn254.Proof, []fr.Vector) {
+	_r1cs, pk, vk := setup(t, &foldedSolidityCircuit{})
+	proofs := make([]groth16_bn254.Proof, nbProofs)
+	publicWitness := make([]fr.Vector, nbProofs)
+	for i := range proofs {
+		x := i + 2
+		public, proof := prove(t, &foldedSolidityCircuit{X: x, Y: x * x * x, Z: x + 1}, _r1cs, pk)
+		proofs[i] = *proof.(*groth16_bn254.Proof)
+		publicWitness[i] = public.Vector().(fr.Vector)
+	}
+	return vk.(*groth16_bn254.VerifyingKey), proofs, publicWitness
+}
+
+// TestFoldedSolidityCalldata replays the checks of the folded batch solidity
+// verifier on the calldata returned by the marshal functions.
+func TestFoldedSolidityCalldata(t *testing.T) {
+	const nbProofs = 3
+	vk, proofs, publicWitness := foldedSolidityInstances(t, nbProofs)
+	foldedProof, foldingParameters, err := groth16_bn254.FoldProofs(proofs, vk, publicWitness)
+	assert.NoError(t, err)
+	bFoldingParameters, err := groth16_bn254.MarshalFoldingParametersSolidity(foldingParameters, vk, proofs, publicWitness)
+	assert.NoError(t, err)
+	assert.Len(t, bFoldingParameters, (nbProofs-1)*28*fr.Bytes)
+
+	// fold the proofs, deriving the challenges as verifyFoldedBatch
+	var ar, krs, h curve.G1Affine
+	var mu fr.Element
+	r := make([]fr.Element, nbProofs)
+	hs := make([]curve.G1Affine, nbProofs)
+	zero := make([]byte, 2*fr.Bytes)
+	for j := range proofs {
+		hs[j], err = groth16_bn254.GetkSumAff(&proofs[j], vk, publicWitness[j])
+		assert.NoError(t, err)
+		if j == 0 {
+			ar, krs, h = proofs[0].Ar, proofs[0].Krs, hs[0]
+			mu.SetOne()
+			r[0].SetOne()
+			continue
+		}
+		pars := bFoldingParameters[(j-1)*28*fr.Bytes : j*28*fr.Bytes]
+		var transcript bytes.Buffer
+		transcript.WriteString("r")
+		transcript.Write(ar.Marshal())
+		transcript.Write(pars[24*fr.Bytes:])
+		transcript.Write(krs.Marshal())
+		transcript.Write(zero)
+		transcript.Write(h.Marshal())
+		transcript.Write(pars[12*fr.Bytes : 24*fr.Bytes])
+		transcript.Write(mu.Marshal())
+		transcript.Write(zero)
+		transcript.Write(proofs[j].MarshalSolidity())
+		transcript.Write(zero)
+		for k := range publicWitness[j] {
+			transcript.Write(publicWitness[j][k].Marshal())
+		}
+		transcript.Write(pars[:12*fr.Bytes])
+		digest := sha256.Sum256(transcript.Bytes())
+		r[j].SetBytes(digest[:])
+		assert.Equal(t, foldingParameters[j-1].R, *r[j].BigInt(new(big.Int)), "challenge %d", j)
+
+		var rj big.Int
+		r[j].BigInt(&rj)
+		ar.Add(&ar, new(curve.G1Affine).ScalarMultiplication(&proofs[j].Ar, &rj))
+		krs.Add(&krs, new(curve.G1Affine).ScalarMultiplication(&proofs[j].Krs, &rj))
+		h.Add(&h, new(curve.G1Affine).ScalarMultiplication(&hs[j], &rj))
+		mu.Add(&mu, &r[j])
+	}
+	bFoldedProof := foldedProof.MarshalSolidity()
+	assert.Equal(t, ar.Marshal(), bFoldedProof[:2*fr.Bytes])
+	assert.Equal(t, krs.Marshal(), bFoldedProof[6*fr.Bytes:])
+	// the first running Bs is the B point of the first proof
+	assert.Equal(t, proofs[0].Bs.Marshal(), bFoldingParameters[24*fr.Bytes:28*fr.Bytes])
+
+	// c = keccak256(foldedProof ‖ r) mod R
+	h3 := sha3.NewLegacyKeccak256()
+	h3.Write(bFoldedProof)
+	for j := range r {
+		h3.Write(r[j].Marshal())
+	}
+	var c fr.Element
+	c.SetBytes(h3.Sum(nil))
+
+	// Π e(rⱼ²⋅Aⱼ - cⱼ⋅rⱼ⋅G, Bⱼ) ⋅ Π e(dₖ⋅G, Sₖ) ⋅ e(Σ rⱼ²⋅Cⱼ, -δ) ⋅ e(Σ rⱼ²⋅Hⱼ, -γ) ⋅ e(Σ rⱼ²⋅α, -β) = 1
+	_, _, g1, _ := curve.Generators()
+	var cj, w, s fr.Element
+	var P []curve.G1Affine
+	var Q []curve.G2Affine
+	var sumC, sumH curve.G1Affine
+	cj.Set(&c)
+	for j := range proofs {
+		if j > 1 {
+			cj.Mul(&cj, &c)
+		}
+		w.Square(&r[j])
+		s.Add(&s, &w)
+		var t1, t2 curve.G1Affine
+		t1.ScalarMultiplication(&proofs[j].Ar, w.BigInt(new(big.Int)))
+		t2.ScalarMultiplication(&g1, new(fr.Element).Mul(&cj, &r[j]).BigInt(new(big.Int)))
+		t1.Sub(&t1, &t2)
+		P = append(P, t1)
+		Q = append(Q, proofs[j].Bs)
+		sumC.Add(&sumC, t2.ScalarMultiplication(&proofs[j].Krs, w.BigInt(new(big.Int))))
+		sumH.Add(&sumH, t2.ScalarMultiplication(&hs[j], w.BigInt(new(big.Int))))
+	}
+	var ck, dk fr.Element
+	ck.Set(&c)
+	for k := 1; k < nbProofs; k++ {
+		if k > 1 {
+			ck.Mul(&ck, &c)
+		}
+		var sk curve.G2Affine
+		if k < nbProofs-1 {
+			dk.Mul(&ck, &c)
+			dk.Sub(&ck, &dk)
+			assert.NoError(t, sk.Unmarshal(bFoldingParameters[(k*28+24)*fr.Bytes:(k+1)*28*fr.Bytes]))
+		} else {
+			dk.Set(&ck)
+			sk = foldedProof.Bs
+		}
+		P = append(P, *new(curve.G1Affine).ScalarMultiplication(&g1, dk.BigInt(new(big.Int))))
+		Q = append(Q, sk)
+	}
+	var sAlpha curve.G1Affine
+	var deltaNeg, gammaNeg, betaNeg curve.G2Affine
+	sAlpha.ScalarMultiplication(&vk.G1.Alpha, s.BigInt(new(big.Int)))
+	deltaNeg.Neg(&vk.G2.Delta)
+	gammaNeg.Neg(&vk.G2.Gamma)
+	betaNeg.Neg(&vk.G2.Beta)
+	P = append(P, sumC, sumH, sAlpha)
+	Q = append(Q, deltaNeg, gammaNeg, betaNeg)
+	assert.Len(t, P, 2*nbProofs+2)
+	ok, err := curve.PairingCheck(P, Q)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// a wrong running Bs doesn't pass
+	Q[nbProofs] = proofs[0].Bs
+	ok, err = curve.PairingCheck(P, Q)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// a wrong folded Bs doesn't pass
+	Q[nbProofs] = foldedProof.Bs
+	Q[2*nbProofs-2] = proofs[0].Bs
+	ok, err = curve.PairingCheck(P, Q)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestExportFoldedBatchSolidity(t *testing.T) {
+	_, _, vk := setup(t, &foldedSolidityCircuit{})
+	var buf bytes.Buffer
+	assert.NoError(t, vk.(*groth16_bn254.VerifyingKey).ExportFoldedBatchSolidity(&buf))
+	assert.Contains(t, buf.String(), "function verifyFoldedBatch(")
+
+	// commitments are not supported
+	_, _, vk = setup(t, &singleSecretCommittedCircuit{})
+	assert.Error(t, vk.(*groth16_bn254.VerifyingKey).ExportFoldedBatchSolidity(&buf))
+}
@@ -0,0 +1,78 @@
+// Command evmrunner deploys a contract on a simulated EVM and calls it. It is
+// used by the solccheck tests of the folded Solidity verifier.
+//
+// Usage:
+//
+//	evmrunner <bytecode hex file> <calldata hex file>
+//
+// It exits with status 1 if the deployment fails or the call reverts.
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: evmrunner <bytecode hex file> <calldata hex file>")
+		os.Exit(2)
+	}
+	if err := run(os.Args[1], os.Args[2]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func readHex(path string) ([]byte, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return common.FromHex(strings.TrimSpace(string(b))), nil
+}
+
+func run(bytecodePath, calldataPath string) error {
+	bytecode, err := readHex(bytecodePath)
+	if err != nil {
+		return fmt.Errorf("read bytecode: %w", err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return fmt.Errorf("generate key: %w", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	if err != nil {
+		return fmt.Errorf("new transactor: %w", err)
+	}
+	balance, _ := new(big.Int).SetString("1000000000000000000000", 10)
+	sim := simulated.NewBackend(types.GenesisAlloc{auth.From: {Balance: balance}}, simulated.WithBlockGasLimit(30_000_000))
+	defer sim.Close()
+	client := sim.Client()
+
+	address, _, _, err := bind.DeployContract(auth, abi.ABI{}, bytecode, client)
+	if err != nil {
+		return fmt.Errorf("deploy contract: %w", err)
+	}
+	sim.Commit()
+
+	calldata, err := readHex(calldataPath)
+	if err != nil {
+		return fmt.Errorf("read calldata: %w", err)
+	}
+	if _, err = client.CallContract(context.Background(), ethereum.CallMsg{From: auth.From, To: &address, Data: calldata}, nil); err != nil {
+		return fmt.Errorf("call contract: %w", err)
+	}
+	return nil
+}
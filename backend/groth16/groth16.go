@@ -20,8 +20,9 @@
 package groth16
 
 import (
+	"errors"
+	"fmt"
 	"io"
-	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark/backend"
@@ -53,8 +54,6 @@ import (
 	icicle_bn254 "github.com/consensys/gnark/backend/groth16/bn254/icicle"
 	groth16_bw6633 "github.com/consensys/gnark/backend/groth16/bw6-633"
 	groth16_bw6761 "github.com/consensys/gnark/backend/groth16/bw6-761"
-
-	curve "github.com/consensys/gnark-crypto/ecc/bls12-377"
 )
 
 type groth16Object interface {
@@ -71,10 +70,45 @@ type Proof interface {
 	groth16Object
 }
 
+// FoldedProof represents a Groth16 proof folded from several proofs by
+// groth16.FoldProofs
+//
+// it's underlying implementation is curve specific
+//
+// On BN254, VerifyingKey.ExportFoldedBatchSolidity exports a Solidity verifier
+// batch-verifying the proofs a folded proof was folded from.
 type FoldedProof interface {
+	groth16Object
 }
 
+// FoldingParameters represents the cross term and challenge of a folding step
+//
+// it's underlying implementation is curve specific
 type FoldingParameters interface {
+	groth16Object
+}
+
+// FoldedWitness represents the public part (H, E, μ and the folded commitment)
+// of a folded instance
+//
+// it's underlying implementation is curve specific
+type FoldedWitness interface {
+	groth16Object
+}
+
+// FoldingAccumulator folds a stream of proofs into a single folded instance,
+// see NewFoldingAccumulator
+type FoldingAccumulator interface {
+	// Fold folds proof, with its public witness, into the accumulator
+	Fold(proof Proof, publicWitness witness.Witness) error
+
+	// Snapshot returns a copy of the running folded proof and witness, both
+	// nil if no proof was folded yet. It is the state from which
+	// ResumeFoldingAccumulator resumes folding.
+	Snapshot() (FoldedProof, FoldedWitness)
+
+	// Decide checks all the proofs folded so far
+	Decide() error
 }
 
 // ProvingKey represents a Groth16 ProvingKey
@@ -170,90 +204,351 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ..
 	}
 }
 
-// FIX FOLDING PARAMETERS FOR MORE THAN 2 PROOFS FOLDED
-func VerifyFolded(proof FoldedProof, foldingParameters []FoldingParameters, vk VerifyingKey, publicWitness []witness.Witness, proofs []Proof) error {
-	proofs_bls12377 := make([]groth16_bls12377.Proof, len(proofs))
-	for i, _proof := range proofs {
-		proofs_bls12377[i] = *_proof.(*groth16_bls12377.Proof)
-	}
-	witness_bls12377 := make([]fr_bls12377.Vector, len(publicWitness))
-	for i, w := range publicWitness {
-		witness_bls12377[i] = w.Vector().(fr_bls12377.Vector)
+// BatchVerify verifies proofs with the same VerifyingKey, combining their checks
+// with random scalars into a single multi Miller loop and final
+// exponentiation. If the batch doesn't verify, the returned error identifies
+// the first invalid proof.
+func BatchVerify(proofs []Proof, vk VerifyingKey, publicWitness []witness.Witness, opts ...backend.VerifierOption) error {
+	switch vk.CurveID() {
+	case ecc.BN254:
+		return batchVerify(groth16_bn254.BatchVerify, proofs, vk, publicWitness, opts...)
+	case ecc.BLS12_377:
+		return batchVerify(groth16_bls12377.BatchVerify, proofs, vk, publicWitness, opts...)
+	case ecc.BLS12_381:
+		return batchVerify(groth16_bls12381.BatchVerify, proofs, vk, publicWitness, opts...)
+	case ecc.BW6_761:
+		return batchVerify(groth16_bw6761.BatchVerify, proofs, vk, publicWitness, opts...)
+	case ecc.BLS24_317:
+		return batchVerify(groth16_bls24317.BatchVerify, proofs, vk, publicWitness, opts...)
+	case ecc.BLS24_315:
+		return batchVerify(groth16_bls24315.BatchVerify, proofs, vk, publicWitness, opts...)
+	case ecc.BW6_633:
+		return batchVerify(groth16_bw6633.BatchVerify, proofs, vk, publicWitness, opts...)
+	default:
+		panic("unrecognized R1CS curve type")
 	}
-	folding_pars_bls12377 := make([]groth16_bls12377.FoldingParameters, len(foldingParameters))
-	for i, f := range foldingParameters {
-		folding_pars_bls12377[i] = *f.(*groth16_bls12377.FoldingParameters)
+}
+
+// batchVerify converts the inputs to their curve specific types and verifies
+// them with the curve specific batch verify function.
+func batchVerify[P, VK, V any](verify func([]P, *VK, []V, ...backend.VerifierOption) error, proofs []Proof, vk VerifyingKey, publicWitness []witness.Witness, opts ...backend.VerifierOption) error {
+	_proofs, w, err := curveInputs[P, V](proofs, publicWitness)
+	if err != nil {
+		return err
 	}
-	return groth16_bls12377.VerifyFolded(proof.(*groth16_bls12377.FoldedProof), folding_pars_bls12377, vk.(*groth16_bls12377.VerifyingKey), proofs_bls12377, witness_bls12377)
-}
-
-func FoldProofs(proofs []Proof, vk VerifyingKey, opts ...backend.ProverOption) (FoldedProof, error) {
-	foldedProof := &groth16_bls12377.FoldedProof{}
-	proof0 := proofs[0].(*groth16_bls12377.Proof)
-	foldedProof.Ar = proof0.Ar
-	foldedProof.Bs = proof0.Bs
-	foldedProof.Krs = proof0.Krs
-	for i, _ := range proofs {
-		switch _proof := proofs[i].(type) {
-		case *groth16_bls12377.Proof:
-			if i == 0 {
-				continue
-			}
-			var err error
-			foldedProof, err = groth16_bls12377.FoldProof(foldedProof, _proof, vk.(*groth16_bls12377.VerifyingKey))
-			if err != nil {
-				return nil, err
-			}
-		default:
-			panic("unrecognized R1CS curve type")
-		}
+	return verify(_proofs, any(vk).(*VK), w, opts...)
+}
+
+// VerifyFolded verifies a folded proof against the proofs and public witnesses
+// it was folded from. The folding challenges are derived again from the
+// transcript, using the hash function set by
+// backend.WithVerifierChallengeHashFunction.
+func VerifyFolded(proof FoldedProof, foldingParameters []FoldingParameters, vk VerifyingKey, publicWitness []witness.Witness, proofs []Proof, opts ...backend.VerifierOption) error {
+	switch vk.CurveID() {
+	case ecc.BN254:
+		return verifyFolded(groth16_bn254.VerifyFolded, proof, foldingParameters, vk, publicWitness, proofs, opts...)
+	case ecc.BLS12_377:
+		return verifyFolded(groth16_bls12377.VerifyFolded, proof, foldingParameters, vk, publicWitness, proofs, opts...)
+	case ecc.BLS12_381:
+		return verifyFolded(groth16_bls12381.VerifyFolded, proof, foldingParameters, vk, publicWitness, proofs, opts...)
+	case ecc.BW6_761:
+		return verifyFolded(groth16_bw6761.VerifyFolded, proof, foldingParameters, vk, publicWitness, proofs, opts...)
+	case ecc.BLS24_317:
+		return verifyFolded(groth16_bls24317.VerifyFolded, proof, foldingParameters, vk, publicWitness, proofs, opts...)
+	case ecc.BLS24_315:
+		return verifyFolded(groth16_bls24315.VerifyFolded, proof, foldingParameters, vk, publicWitness, proofs, opts...)
+	case ecc.BW6_633:
+		return verifyFolded(groth16_bw6633.VerifyFolded, proof, foldingParameters, vk, publicWitness, proofs, opts...)
+	default:
+		panic("unrecognized R1CS curve type")
 	}
-	return foldedProof, nil
 }
 
+// FoldProofs folds proofs into a single FoldedProof. The folding challenges are
+// derived with Fiat-Shamir from the proofs, their public witnesses and the
+// cross terms, using the hash function set by
+// backend.WithProverChallengeHashFunction.
+func FoldProofs(proofs []Proof, vk VerifyingKey, publicWitness []witness.Witness, opts ...backend.ProverOption) (FoldedProof, error) {
+	foldedProof, _, err := foldProofs(proofs, vk, publicWitness, opts...)
+	return foldedProof, err
+}
+
+// GetFoldingParameters returns the parameters of each step of folding proofs,
+// as needed by VerifyFolded. It derives the same challenges as FoldProofs.
 func GetFoldingParameters(proofs []Proof, vk VerifyingKey, publicWitness []witness.Witness, opts ...backend.ProverOption) ([]FoldingParameters, error) {
-	w1, _ := publicWitness[0].Vector().(fr_bls12377.Vector)
-	kSumAff, err := groth16_bls12377.GetkSumAff(proofs[0].(*groth16_bls12377.Proof), vk.(*groth16_bls12377.VerifyingKey), w1)
+	_, foldingParameters, err := foldProofs(proofs, vk, publicWitness, opts...)
+	return foldingParameters, err
+}
+
+func foldProofs(proofs []Proof, vk VerifyingKey, publicWitness []witness.Witness, opts ...backend.ProverOption) (FoldedProof, []FoldingParameters, error) {
+	switch vk.CurveID() {
+	case ecc.BN254:
+		return foldWith(groth16_bn254.FoldProofs, proofs, vk, publicWitness, opts...)
+	case ecc.BLS12_377:
+		return foldWith(groth16_bls12377.FoldProofs, proofs, vk, publicWitness, opts...)
+	case ecc.BLS12_381:
+		return foldWith(groth16_bls12381.FoldProofs, proofs, vk, publicWitness, opts...)
+	case ecc.BW6_761:
+		return foldWith(groth16_bw6761.FoldProofs, proofs, vk, publicWitness, opts...)
+	case ecc.BLS24_317:
+		return foldWith(groth16_bls24317.FoldProofs, proofs, vk, publicWitness, opts...)
+	case ecc.BLS24_315:
+		return foldWith(groth16_bls24315.FoldProofs, proofs, vk, publicWitness, opts...)
+	case ecc.BW6_633:
+		return foldWith(groth16_bw6633.FoldProofs, proofs, vk, publicWitness, opts...)
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// foldWith converts the inputs to their curve specific types and folds them
+// with the curve specific fold function.
+func foldWith[P, FP, FPars, VK, V any](fold func([]P, *VK, []V, ...backend.ProverOption) (*FP, []FPars, error), proofs []Proof, vk VerifyingKey, publicWitness []witness.Witness, opts ...backend.ProverOption) (FoldedProof, []FoldingParameters, error) {
+	_proofs, w, err := curveInputs[P, V](proofs, publicWitness)
+	if err != nil {
+		return nil, nil, err
+	}
+	foldedProof, _foldingParameters, err := fold(_proofs, any(vk).(*VK), w, opts...)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	foldingParameters := make([]FoldingParameters, len(_foldingParameters))
+	for i := range _foldingParameters {
+		foldingParameters[i] = any(&_foldingParameters[i]).(FoldingParameters)
+	}
+	return any(foldedProof).(FoldedProof), foldingParameters, nil
+}
 
-	foldedProof := &groth16_bls12377.FoldedProof{}
-	proof0 := proofs[0].(*groth16_bls12377.Proof)
-	foldedProof.Ar = proof0.Ar
-	foldedProof.Bs = proof0.Bs
-	foldedProof.Krs = proof0.Krs
+// verifyFolded converts the inputs to their curve specific types and verifies
+// them with the curve specific verify function.
+func verifyFolded[P, FP, FPars, VK, V any](verify func(*FP, []FPars, *VK, []P, []V, ...backend.VerifierOption) error, proof FoldedProof, foldingParameters []FoldingParameters, vk VerifyingKey, publicWitness []witness.Witness, proofs []Proof, opts ...backend.VerifierOption) error {
+	_proofs, w, err := curveInputs[P, V](proofs, publicWitness)
+	if err != nil {
+		return err
+	}
+	_foldingParameters := make([]FPars, len(foldingParameters))
+	for i := range foldingParameters {
+		fp, ok := any(foldingParameters[i]).(*FPars)
+		if !ok {
+			return fmt.Errorf("invalid folding parameters %d", i)
+		}
+		_foldingParameters[i] = *fp
+	}
+	_proof, ok := any(proof).(*FP)
+	if !ok {
+		return errors.New("invalid folded proof")
+	}
+	return verify(_proof, _foldingParameters, any(vk).(*VK), _proofs, w, opts...)
+}
 
-	foldingParameters := make([]FoldingParameters, len(proofs)-1)
+// curveInputs converts the proofs and public witnesses to their curve specific
+// types.
+func curveInputs[P, V any](proofs []Proof, publicWitness []witness.Witness) ([]P, []V, error) {
+	_proofs := make([]P, len(proofs))
+	for i := range proofs {
+		p, ok := any(proofs[i]).(*P)
+		if !ok {
+			return nil, nil, errors.New("mismatching proof type")
+		}
+		_proofs[i] = *p
+	}
+	w := make([]V, len(publicWitness))
+	for i := range publicWitness {
+		v, ok := publicWitness[i].Vector().(V)
+		if !ok {
+			return nil, nil, witness.ErrInvalidWitness
+		}
+		w[i] = v
+	}
+	return _proofs, w, nil
+}
 
-	foldedWitness := groth16_bls12377.FoldedWitness{}
-	foldedWitness.H = kSumAff
-	foldedWitness.E = *make([]curve.GT, 1)[0].SetOne()
-	foldedWitness.Mu = *big.NewInt(1)
+// NewFoldingAccumulator returns an empty FoldingAccumulator for proofs of vk.
+// It only keeps the running folded instance, so its memory doesn't grow with
+// the number of folded proofs. The folding challenges are derived as in
+// FoldProofs.
+func NewFoldingAccumulator(vk VerifyingKey, opts ...backend.ProverOption) (FoldingAccumulator, error) {
+	switch _vk := vk.(type) {
+	case *groth16_bn254.VerifyingKey:
+		acc, err := groth16_bn254.NewFoldingAccumulator(_vk, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bn254.Proof, fr_bn254.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls12377.VerifyingKey:
+		acc, err := groth16_bls12377.NewFoldingAccumulator(_vk, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls12377.Proof, fr_bls12377.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls12381.VerifyingKey:
+		acc, err := groth16_bls12381.NewFoldingAccumulator(_vk, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls12381.Proof, fr_bls12381.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bw6761.VerifyingKey:
+		acc, err := groth16_bw6761.NewFoldingAccumulator(_vk, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bw6761.Proof, fr_bw6761.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls24317.VerifyingKey:
+		acc, err := groth16_bls24317.NewFoldingAccumulator(_vk, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls24317.Proof, fr_bls24317.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls24315.VerifyingKey:
+		acc, err := groth16_bls24315.NewFoldingAccumulator(_vk, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls24315.Proof, fr_bls24315.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bw6633.VerifyingKey:
+		acc, err := groth16_bw6633.NewFoldingAccumulator(_vk, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bw6633.Proof, fr_bw6633.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
 
-	for i, _ := range proofs {
-		switch _proof := proofs[i].(type) {
-		case *groth16_bls12377.Proof:
-			if i == 0 {
-				continue
-			}
-			w, _ := publicWitness[i].Vector().(fr_bls12377.Vector)
-			var err error
-			foldingParameters[i-1], kSumAff, err = groth16_bls12377.GetFoldingParameters(foldedWitness.H, foldedProof, proofs[i].(*groth16_bls12377.Proof), vk.(*groth16_bls12377.VerifyingKey), foldedWitness, w)
-			if err != nil {
-				return nil, err
-			}
-			witness := groth16_bls12377.PublicWitness{}
-			witness.Public = w
-			witness.SetStartingParameters()
-			foldingPars := *foldingParameters[i-1].(*groth16_bls12377.FoldingParameters)
-			foldedWitness.FoldWitnesses([]groth16_bls12377.PublicWitness{witness}, []groth16_bls12377.FoldingParameters{foldingPars}, *vk.(*groth16_bls12377.VerifyingKey), []groth16_bls12377.Proof{*proofs[i].(*groth16_bls12377.Proof)})
-			foldedProof, _ = groth16_bls12377.FoldProof(foldedProof, _proof, vk.(*groth16_bls12377.VerifyingKey))
-		default:
-			panic("unrecognized R1CS curve type")
+// ResumeFoldingAccumulator returns a FoldingAccumulator for proofs of vk whose
+// running folded instance is (foldedProof, foldedWitness), as returned by
+// FoldingAccumulator.Snapshot. Every folding challenge is derived from the
+// running instance and the incoming proof, so the snapshot is the full
+// transcript state as long as opts set the same hash functions as the ones of
+// the snapshotted accumulator.
+func ResumeFoldingAccumulator(vk VerifyingKey, foldedProof FoldedProof, foldedWitness FoldedWitness, opts ...backend.ProverOption) (FoldingAccumulator, error) {
+	switch _vk := vk.(type) {
+	case *groth16_bn254.VerifyingKey:
+		_foldedProof, _foldedWitness, err := snapshotInputs[groth16_bn254.FoldedProof, groth16_bn254.FoldedWitness](foldedProof, foldedWitness)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := groth16_bn254.ResumeFoldingAccumulator(_vk, _foldedProof, _foldedWitness, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bn254.Proof, fr_bn254.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls12377.VerifyingKey:
+		_foldedProof, _foldedWitness, err := snapshotInputs[groth16_bls12377.FoldedProof, groth16_bls12377.FoldedWitness](foldedProof, foldedWitness)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := groth16_bls12377.ResumeFoldingAccumulator(_vk, _foldedProof, _foldedWitness, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls12377.Proof, fr_bls12377.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls12381.VerifyingKey:
+		_foldedProof, _foldedWitness, err := snapshotInputs[groth16_bls12381.FoldedProof, groth16_bls12381.FoldedWitness](foldedProof, foldedWitness)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := groth16_bls12381.ResumeFoldingAccumulator(_vk, _foldedProof, _foldedWitness, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls12381.Proof, fr_bls12381.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bw6761.VerifyingKey:
+		_foldedProof, _foldedWitness, err := snapshotInputs[groth16_bw6761.FoldedProof, groth16_bw6761.FoldedWitness](foldedProof, foldedWitness)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := groth16_bw6761.ResumeFoldingAccumulator(_vk, _foldedProof, _foldedWitness, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bw6761.Proof, fr_bw6761.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls24317.VerifyingKey:
+		_foldedProof, _foldedWitness, err := snapshotInputs[groth16_bls24317.FoldedProof, groth16_bls24317.FoldedWitness](foldedProof, foldedWitness)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := groth16_bls24317.ResumeFoldingAccumulator(_vk, _foldedProof, _foldedWitness, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls24317.Proof, fr_bls24317.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bls24315.VerifyingKey:
+		_foldedProof, _foldedWitness, err := snapshotInputs[groth16_bls24315.FoldedProof, groth16_bls24315.FoldedWitness](foldedProof, foldedWitness)
+		if err != nil {
+			return nil, err
+		}
+		acc, err := groth16_bls24315.ResumeFoldingAccumulator(_vk, _foldedProof, _foldedWitness, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bls24315.Proof, fr_bls24315.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	case *groth16_bw6633.VerifyingKey:
+		_foldedProof, _foldedWitness, err := snapshotInputs[groth16_bw6633.FoldedProof, groth16_bw6633.FoldedWitness](foldedProof, foldedWitness)
+		if err != nil {
+			return nil, err
 		}
+		acc, err := groth16_bw6633.ResumeFoldingAccumulator(_vk, _foldedProof, _foldedWitness, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return newFoldingAccumulator[groth16_bw6633.Proof, fr_bw6633.Vector](acc.Fold, acc.Snapshot, acc.Decide), nil
+	default:
+		panic("unrecognized R1CS curve type")
+	}
+}
+
+// snapshotInputs converts the folded proof and witness to their curve specific
+// types.
+func snapshotInputs[FP, FW any](foldedProof FoldedProof, foldedWitness FoldedWitness) (*FP, *FW, error) {
+	_foldedProof, ok := any(foldedProof).(*FP)
+	if !ok {
+		return nil, nil, errors.New("mismatching folded proof type")
+	}
+	_foldedWitness, ok := any(foldedWitness).(*FW)
+	if !ok {
+		return nil, nil, errors.New("mismatching folded witness type")
+	}
+	return _foldedProof, _foldedWitness, nil
+}
+
+// foldingAccumulator wraps a curve specific accumulator, converting the inputs
+// to their curve specific types.
+type foldingAccumulator[P, V any] struct {
+	fold     func(*P, V) error
+	snapshot func() (FoldedProof, FoldedWitness)
+	decide   func() error
+}
+
+func newFoldingAccumulator[P, V, FP, FW any](fold func(*P, V) error, snapshot func() (*FP, *FW), decide func() error) *foldingAccumulator[P, V] {
+	return &foldingAccumulator[P, V]{
+		fold: fold,
+		snapshot: func() (FoldedProof, FoldedWitness) {
+			foldedProof, foldedWitness := snapshot()
+			if foldedProof == nil {
+				return nil, nil
+			}
+			return any(foldedProof).(FoldedProof), any(foldedWitness).(FoldedWitness)
+		},
+		decide: decide,
+	}
+}
+
+func (acc *foldingAccumulator[P, V]) Fold(proof Proof, publicWitness witness.Witness) error {
+	_proofs, w, err := curveInputs[P, V]([]Proof{proof}, []witness.Witness{publicWitness})
+	if err != nil {
+		return err
 	}
-	return foldingParameters, nil
+	return acc.fold(&_proofs[0], w[0])
+}
+
+func (acc *foldingAccumulator[P, V]) Snapshot() (FoldedProof, FoldedWitness) {
+	return acc.snapshot()
+}
+
+func (acc *foldingAccumulator[P, V]) Decide() error {
+	return acc.decide()
 }
 
 // Prove runs the groth16.Prove algorithm.
@@ -504,6 +799,84 @@ func NewProof(curveID ecc.ID) Proof {
 	return proof
 }
 
+// NewFoldedProof instantiates a curve-typed FoldedProof and returns an interface
+// This function exists for serialization purposes
+func NewFoldedProof(curveID ecc.ID) FoldedProof {
+	var proof FoldedProof
+	switch curveID {
+	case ecc.BN254:
+		proof = &groth16_bn254.FoldedProof{}
+	case ecc.BLS12_377:
+		proof = &groth16_bls12377.FoldedProof{}
+	case ecc.BLS12_381:
+		proof = &groth16_bls12381.FoldedProof{}
+	case ecc.BW6_761:
+		proof = &groth16_bw6761.FoldedProof{}
+	case ecc.BLS24_317:
+		proof = &groth16_bls24317.FoldedProof{}
+	case ecc.BLS24_315:
+		proof = &groth16_bls24315.FoldedProof{}
+	case ecc.BW6_633:
+		proof = &groth16_bw6633.FoldedProof{}
+	default:
+		panic("not implemented")
+	}
+
+	return proof
+}
+
+// NewFoldedWitness instantiates a curve-typed FoldedWitness and returns an interface
+// This function exists for serialization purposes
+func NewFoldedWitness(curveID ecc.ID) FoldedWitness {
+	var witness FoldedWitness
+	switch curveID {
+	case ecc.BN254:
+		witness = &groth16_bn254.FoldedWitness{}
+	case ecc.BLS12_377:
+		witness = &groth16_bls12377.FoldedWitness{}
+	case ecc.BLS12_381:
+		witness = &groth16_bls12381.FoldedWitness{}
+	case ecc.BW6_761:
+		witness = &groth16_bw6761.FoldedWitness{}
+	case ecc.BLS24_317:
+		witness = &groth16_bls24317.FoldedWitness{}
+	case ecc.BLS24_315:
+		witness = &groth16_bls24315.FoldedWitness{}
+	case ecc.BW6_633:
+		witness = &groth16_bw6633.FoldedWitness{}
+	default:
+		panic("not implemented")
+	}
+
+	return witness
+}
+
+// NewFoldingParameters instantiates curve-typed FoldingParameters and returns an interface
+// This function exists for serialization purposes
+func NewFoldingParameters(curveID ecc.ID) FoldingParameters {
+	var foldingParameters FoldingParameters
+	switch curveID {
+	case ecc.BN254:
+		foldingParameters = &groth16_bn254.FoldingParameters{}
+	case ecc.BLS12_377:
+		foldingParameters = &groth16_bls12377.FoldingParameters{}
+	case ecc.BLS12_381:
+		foldingParameters = &groth16_bls12381.FoldingParameters{}
+	case ecc.BW6_761:
+		foldingParameters = &groth16_bw6761.FoldingParameters{}
+	case ecc.BLS24_317:
+		foldingParameters = &groth16_bls24317.FoldingParameters{}
+	case ecc.BLS24_315:
+		foldingParameters = &groth16_bls24315.FoldingParameters{}
+	case ecc.BW6_633:
+		foldingParameters = &groth16_bw6633.FoldingParameters{}
+	default:
+		panic("not implemented")
+	}
+
+	return foldingParameters
+}
+
 // NewCS instantiate a concrete curved-typed R1CS and return a R1CS interface
 // This method exists for (de)serialization purposes
 func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
@@ -1,14 +1,20 @@
 package groth16_test
 
 import (
+	"bytes"
+	"crypto/sha512"
 	"fmt"
 	"math/big"
+	"sync"
 	"testing"
 
 	"github.com/consensys/gnark"
 	"github.com/consensys/gnark-crypto/ecc"
+	curve_bn254 "github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/groth16"
+	groth16_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
@@ -51,6 +57,325 @@ func TestCustomHashToField(t *testing.T) {
 	}
 }
 
+func TestFoldProofs(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			assert.Run(func(assert *test.Assert) {
+				vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{}, 3)
+				foldedProof, err := groth16.FoldProofs(proofs, vk, publicWitness)
+				assert.NoError(err)
+				foldingParameters, err := groth16.GetFoldingParameters(proofs, vk, publicWitness)
+				assert.NoError(err)
+				err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, proofs)
+				assert.NoError(err)
+			}, "success")
+			assert.Run(func(assert *test.Assert) {
+				vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{}, 3)
+				foldedProof, err := groth16.FoldProofs(proofs, vk, publicWitness)
+				assert.NoError(err)
+				foldingParameters, err := groth16.GetFoldingParameters(proofs, vk, publicWitness)
+				assert.NoError(err)
+				publicWitness[1], publicWitness[2] = publicWitness[2], publicWitness[1]
+				err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, proofs)
+				assert.Error(err)
+			}, "wrong_witness")
+			assert.Run(func(assert *test.Assert) {
+				vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{}, 3)
+				foldedProof, err := groth16.FoldProofs(proofs, vk, publicWitness, backend.WithProverChallengeHashFunction(sha512.New()))
+				assert.NoError(err)
+				foldingParameters, err := groth16.GetFoldingParameters(proofs, vk, publicWitness, backend.WithProverChallengeHashFunction(sha512.New()))
+				assert.NoError(err)
+				err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, proofs, backend.WithVerifierChallengeHashFunction(sha512.New()))
+				assert.NoError(err)
+			}, "custom_challenge_hash")
+			assert.Run(func(assert *test.Assert) {
+				vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{}, 3)
+				foldedProof, err := groth16.FoldProofs(proofs, vk, publicWitness, backend.WithProverChallengeHashFunction(sha512.New()))
+				assert.NoError(err)
+				foldingParameters, err := groth16.GetFoldingParameters(proofs, vk, publicWitness, backend.WithProverChallengeHashFunction(sha512.New()))
+				assert.NoError(err)
+				err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, proofs)
+				assert.Error(err)
+			}, "prover_only_challenge_hash")
+			assert.Run(func(assert *test.Assert) {
+				vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{}, 3)
+				foldedProof, err := groth16.FoldProofs(proofs, vk, publicWitness)
+				assert.NoError(err)
+				foldingParameters, err := groth16.GetFoldingParameters(proofs, vk, publicWitness)
+				assert.NoError(err)
+
+				var buf bytes.Buffer
+				_, err = foldedProof.WriteTo(&buf)
+				assert.NoError(err)
+				foldedProof = groth16.NewFoldedProof(curve)
+				_, err = foldedProof.ReadFrom(&buf)
+				assert.NoError(err)
+				for i := range foldingParameters {
+					buf.Reset()
+					_, err = foldingParameters[i].WriteRawTo(&buf)
+					assert.NoError(err)
+					foldingParameters[i] = groth16.NewFoldingParameters(curve)
+					_, err = foldingParameters[i].ReadFrom(&buf)
+					assert.NoError(err)
+				}
+
+				err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, proofs)
+				assert.NoError(err)
+			}, "serialized")
+		}, curve.String())
+	}
+}
+
+func TestFoldProofsWithCommitments(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		for _, nbCommitments := range []int{1, 2} {
+			assert.Run(func(assert *test.Assert) {
+				vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{nbCommitments: nbCommitments}, 3)
+				foldedProof, err := groth16.FoldProofs(proofs, vk, publicWitness)
+				assert.NoError(err)
+				foldingParameters, err := groth16.GetFoldingParameters(proofs, vk, publicWitness)
+				assert.NoError(err)
+				err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, proofs)
+				assert.NoError(err)
+
+				// a proof of knowledge which doesn't match its commitments must be rejected
+				invalidProofs := make([]groth16.Proof, len(proofs))
+				copy(invalidProofs, proofs)
+				invalidProofs[1] = tamperCommitmentPok(assert, curve, proofs[1], proofs[2])
+				foldedProof, err = groth16.FoldProofs(invalidProofs, vk, publicWitness)
+				assert.NoError(err)
+				foldingParameters, err = groth16.GetFoldingParameters(invalidProofs, vk, publicWitness)
+				assert.NoError(err)
+				err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, invalidProofs)
+				assert.Error(err)
+			}, curve.String(), fmt.Sprintf("nbCommitments=%d", nbCommitments))
+		}
+	}
+}
+
+func TestFoldProofsSubgroupCheck(t *testing.T) {
+	assert := test.NewAssert(t)
+	vk, proofs, publicWitness := foldingInstances(assert, ecc.BN254, &foldingCircuit{}, 3)
+	foldingParameters, err := groth16.GetFoldingParameters(proofs, vk, publicWitness)
+	assert.NoError(err)
+
+	invalidProofs := make([]groth16.Proof, len(proofs))
+	copy(invalidProofs, proofs)
+	invalidProofs[1] = outOfSubgroupProof(assert, proofs[1])
+
+	_, err = groth16.FoldProofs(invalidProofs, vk, publicWitness)
+	assert.Error(err)
+	assert.Contains(err.Error(), "proof 1")
+	foldedProof, err := groth16.FoldProofs(proofs, vk, publicWitness)
+	assert.NoError(err)
+	err = groth16.VerifyFolded(foldedProof, foldingParameters, vk, publicWitness, invalidProofs)
+	assert.Error(err)
+	assert.Contains(err.Error(), "proof 1")
+}
+
+func TestVerifyFoldedSharedKey(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			const nbFolded, foldSize = 4, 3
+			vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{}, nbFolded+foldSize-1)
+			foldedProofs := make([]groth16.FoldedProof, nbFolded)
+			foldingParameters := make([][]groth16.FoldingParameters, nbFolded)
+			for i := range foldedProofs {
+				var err error
+				foldedProofs[i], err = groth16.FoldProofs(proofs[i:i+foldSize], vk, publicWitness[i:i+foldSize])
+				assert.NoError(err)
+				foldingParameters[i], err = groth16.GetFoldingParameters(proofs[i:i+foldSize], vk, publicWitness[i:i+foldSize])
+				assert.NoError(err)
+			}
+
+			// back to back
+			for k := 0; k < 2; k++ {
+				for i := range foldedProofs {
+					err := groth16.VerifyFolded(foldedProofs[i], foldingParameters[i], vk, publicWitness[i:i+foldSize], proofs[i:i+foldSize])
+					assert.NoError(err)
+				}
+			}
+
+			// in parallel
+			var wg sync.WaitGroup
+			errs := make([]error, nbFolded)
+			for i := range foldedProofs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					errs[i] = groth16.VerifyFolded(foldedProofs[i], foldingParameters[i], vk, publicWitness[i:i+foldSize], proofs[i:i+foldSize])
+				}(i)
+			}
+			wg.Wait()
+			for i := range errs {
+				assert.NoError(errs[i])
+			}
+
+			// the key must still verify plain proofs
+			for i := range proofs {
+				assert.NoError(groth16.Verify(proofs[i], vk, publicWitness[i]))
+			}
+		}, curve.String())
+	}
+}
+
+func TestFoldingAccumulator(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{nbCommitments: 1}, 4)
+
+			acc, err := groth16.NewFoldingAccumulator(vk)
+			assert.NoError(err)
+			foldedProof, foldedWitness := acc.Snapshot()
+			assert.Nil(foldedProof)
+			assert.Nil(foldedWitness)
+			assert.Error(acc.Decide())
+
+			for i := range proofs {
+				assert.NoError(acc.Fold(proofs[i], publicWitness[i]))
+				assert.NoError(acc.Decide())
+
+				// the running folded proof is the one of FoldProofs
+				foldedProof, err := groth16.FoldProofs(proofs[:i+1], vk, publicWitness[:i+1])
+				assert.NoError(err)
+				var expected, snapshot bytes.Buffer
+				_, err = foldedProof.WriteTo(&expected)
+				assert.NoError(err)
+				snapshotProof, _ := acc.Snapshot()
+				_, err = snapshotProof.WriteTo(&snapshot)
+				assert.NoError(err)
+				assert.Equal(expected.Bytes(), snapshot.Bytes())
+			}
+
+			// an invalid proof is accepted by Fold, but not by Decide
+			assert.NoError(acc.Fold(tamperCommitmentPok(assert, curve, proofs[1], proofs[2]), publicWitness[1]))
+			assert.Error(acc.Decide())
+
+			acc, err = groth16.NewFoldingAccumulator(vk)
+			assert.NoError(err)
+			assert.NoError(acc.Fold(proofs[0], publicWitness[0]))
+			assert.NoError(acc.Fold(proofs[1], publicWitness[2]))
+			assert.Error(acc.Decide())
+		}, curve.String())
+	}
+}
+
+func TestResumeFoldingAccumulator(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{nbCommitments: 1}, 4)
+			opt := backend.WithProverChallengeHashFunction(sha512.New())
+
+			acc, err := groth16.NewFoldingAccumulator(vk, opt)
+			assert.NoError(err)
+			for i := 0; i < 2; i++ {
+				assert.NoError(acc.Fold(proofs[i], publicWitness[i]))
+			}
+
+			// checkpoint the snapshot
+			foldedProof, foldedWitness := acc.Snapshot()
+			var buf bytes.Buffer
+			_, err = foldedProof.WriteTo(&buf)
+			assert.NoError(err)
+			_, err = foldedWitness.WriteTo(&buf)
+			assert.NoError(err)
+			foldedProof, foldedWitness = groth16.NewFoldedProof(curve), groth16.NewFoldedWitness(curve)
+			_, err = foldedProof.ReadFrom(&buf)
+			assert.NoError(err)
+			_, err = foldedWitness.ReadFrom(&buf)
+			assert.NoError(err)
+
+			resumed, err := groth16.ResumeFoldingAccumulator(vk, foldedProof, foldedWitness, opt)
+			assert.NoError(err)
+			assert.NoError(resumed.Decide())
+			for i := 2; i < len(proofs); i++ {
+				assert.NoError(resumed.Fold(proofs[i], publicWitness[i]))
+			}
+			assert.NoError(resumed.Decide())
+
+			// resuming gives the same folded proof as folding all the proofs
+			expectedProof, err := groth16.FoldProofs(proofs, vk, publicWitness, opt)
+			assert.NoError(err)
+			var expected, actual bytes.Buffer
+			_, err = expectedProof.WriteTo(&expected)
+			assert.NoError(err)
+			resumedProof, _ := resumed.Snapshot()
+			_, err = resumedProof.WriteTo(&actual)
+			assert.NoError(err)
+			assert.Equal(expected.Bytes(), actual.Bytes())
+
+			// the folded witness must be the one of the folded proof
+			mismatched, err := groth16.ResumeFoldingAccumulator(vk, expectedProof, foldedWitness, opt)
+			assert.NoError(err)
+			assert.Error(mismatched.Decide())
+		}, curve.String())
+	}
+}
+
+func TestFoldingAccumulatorSubgroupCheck(t *testing.T) {
+	assert := test.NewAssert(t)
+	vk, proofs, publicWitness := foldingInstances(assert, ecc.BN254, &foldingCircuit{}, 2)
+	acc, err := groth16.NewFoldingAccumulator(vk)
+	assert.NoError(err)
+	assert.Error(acc.Fold(outOfSubgroupProof(assert, proofs[0]), publicWitness[0]))
+	assert.NoError(acc.Fold(proofs[0], publicWitness[0]))
+	assert.Error(acc.Fold(outOfSubgroupProof(assert, proofs[1]), publicWitness[1]))
+	assert.NoError(acc.Decide())
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		assert.Run(func(assert *test.Assert) {
+			vk, proofs, publicWitness := foldingInstances(assert, curve, &foldingCircuit{nbCommitments: 1}, 4)
+			assert.NoError(groth16.BatchVerify(proofs, vk, publicWitness))
+			assert.NoError(groth16.BatchVerify(proofs[:1], vk, publicWitness[:1]))
+			assert.Error(groth16.BatchVerify(nil, vk, nil))
+			assert.Error(groth16.BatchVerify(proofs, vk, publicWitness[:3]))
+
+			// the invalid proof is reported
+			publicWitness[1], publicWitness[2] = publicWitness[2], publicWitness[1]
+			err := groth16.BatchVerify(proofs, vk, publicWitness)
+			assert.Error(err)
+			assert.Contains(err.Error(), "proof 1")
+			publicWitness[1], publicWitness[2] = publicWitness[2], publicWitness[1]
+
+			invalidProofs := make([]groth16.Proof, len(proofs))
+			copy(invalidProofs, proofs)
+			invalidProofs[3] = tamperCommitmentPok(assert, curve, proofs[3], proofs[0])
+			err = groth16.BatchVerify(invalidProofs, vk, publicWitness)
+			assert.Error(err)
+			assert.Contains(err.Error(), "proof 3")
+		}, curve.String())
+	}
+}
+
+// foldingInstances returns nbProofs valid proofs of circuit with different
+// public witnesses.
+func foldingInstances(assert *test.Assert, curve ecc.ID, circuit *foldingCircuit, nbProofs int) (groth16.VerifyingKey, []groth16.Proof, []witness.Witness) {
+	ccs, err := frontend.Compile(curve.ScalarField(), r1cs.NewBuilder, circuit)
+	assert.NoError(err)
+	pk, vk, err := groth16.Setup(ccs)
+	assert.NoError(err)
+	proofs := make([]groth16.Proof, nbProofs)
+	publicWitness := make([]witness.Witness, nbProofs)
+	for i := 0; i < nbProofs; i++ {
+		x := i + 2
+		w, err := frontend.NewWitness(&foldingCircuit{X: x, Y: x * x * x}, curve.ScalarField())
+		assert.NoError(err)
+		proofs[i], err = groth16.Prove(ccs, pk, w)
+		assert.NoError(err)
+		publicWitness[i], err = w.Public()
+		assert.NoError(err)
+	}
+	return vk, proofs, publicWitness
+}
+
 //--------------------//
 //     benches		  //
 //--------------------//
@@ -168,6 +493,54 @@ func (c *commitmentCircuit) Define(api frontend.API) error {
 	return nil
 }
 
+// tamperCommitmentPok returns a copy of proof with the commitment proof of
+// knowledge of other, which ends the raw encoding of the proofs.
+func tamperCommitmentPok(assert *test.Assert, curve ecc.ID, proof, other groth16.Proof) groth16.Proof {
+	sizeOfG1 := 2 * ((curve.BaseField().BitLen() + 63) / 64 * 8)
+	var buf, otherBuf bytes.Buffer
+	_, err := proof.WriteRawTo(&buf)
+	assert.NoError(err)
+	_, err = other.WriteRawTo(&otherBuf)
+	assert.NoError(err)
+	b, otherB := buf.Bytes(), otherBuf.Bytes()
+	copy(b[len(b)-sizeOfG1:], otherB[len(otherB)-sizeOfG1:])
+
+	res := groth16.NewProof(curve)
+	_, err = res.ReadFrom(bytes.NewReader(b))
+	assert.NoError(err)
+	return res
+}
+
+// outOfSubgroupProof returns a copy of the BN254 proof whose Bs is a point of
+// the twist which is not in G2.
+func outOfSubgroupProof(assert *test.Assert, proof groth16.Proof) groth16.Proof {
+	res := *proof.(*groth16_bn254.Proof)
+	var u curve_bn254.E2
+	u.A0.SetUint64(42)
+	res.Bs = curve_bn254.MapToCurve2(&u)
+	assert.True(res.Bs.IsOnCurve())
+	assert.False(res.Bs.IsInSubGroup())
+	return &res
+}
+
+type foldingCircuit struct {
+	nbCommitments int
+	X             frontend.Variable
+	Y             frontend.Variable `gnark:",public"`
+}
+
+func (c *foldingCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X, c.X), c.Y)
+	for i := 0; i < c.nbCommitments; i++ {
+		cmt, err := api.(frontend.Committer).Commit(c.X, c.Y, i)
+		if err != nil {
+			return fmt.Errorf("commit: %w", err)
+		}
+		api.AssertIsDifferent(cmt, c.X)
+	}
+	return nil
+}
+
 type constantHash struct{}
 
 func (h constantHash) Write(p []byte) (n int, err error) { return len(p), nil }
@@ -20,6 +20,7 @@
 package plonk
 
 import (
+	"errors"
 	"io"
 
 	"github.com/consensys/gnark-crypto/ecc"
@@ -216,6 +217,61 @@ func Verify(proof Proof, vk VerifyingKey, publicWitness witness.Witness, opts ..
 	}
 }
 
+// BatchVerify verifies proofs with the same VerifyingKey. The KZG openings of
+// all the proofs are checked with a single pairing equation. If the batch is
+// rejected, the returned error identifies the first invalid proof.
+func BatchVerify(proofs []Proof, vk VerifyingKey, publicWitness []witness.Witness, opts ...backend.VerifierOption) error {
+
+	switch _vk := vk.(type) {
+
+	case *plonk_bn254.VerifyingKey:
+		return batchVerify(plonk_bn254.BatchVerify, proofs, _vk, publicWitness, opts...)
+
+	case *plonk_bls12381.VerifyingKey:
+		return batchVerify(plonk_bls12381.BatchVerify, proofs, _vk, publicWitness, opts...)
+
+	case *plonk_bls12377.VerifyingKey:
+		return batchVerify(plonk_bls12377.BatchVerify, proofs, _vk, publicWitness, opts...)
+
+	case *plonk_bw6761.VerifyingKey:
+		return batchVerify(plonk_bw6761.BatchVerify, proofs, _vk, publicWitness, opts...)
+
+	case *plonk_bw6633.VerifyingKey:
+		return batchVerify(plonk_bw6633.BatchVerify, proofs, _vk, publicWitness, opts...)
+
+	case *plonk_bls24317.VerifyingKey:
+		return batchVerify(plonk_bls24317.BatchVerify, proofs, _vk, publicWitness, opts...)
+
+	case *plonk_bls24315.VerifyingKey:
+		return batchVerify(plonk_bls24315.BatchVerify, proofs, _vk, publicWitness, opts...)
+
+	default:
+		panic("unrecognized verifying key type")
+	}
+}
+
+// batchVerify converts the proofs and public witnesses to their curve specific
+// types and verifies them with the curve specific batch verify function.
+func batchVerify[P, VK, V any](verify func([]P, *VK, []V, ...backend.VerifierOption) error, proofs []Proof, vk *VK, publicWitness []witness.Witness, opts ...backend.VerifierOption) error {
+	_proofs := make([]P, len(proofs))
+	for i := range proofs {
+		p, ok := any(proofs[i]).(*P)
+		if !ok {
+			return errors.New("mismatching proof type")
+		}
+		_proofs[i] = *p
+	}
+	w := make([]V, len(publicWitness))
+	for i := range publicWitness {
+		v, ok := publicWitness[i].Vector().(V)
+		if !ok {
+			return witness.ErrInvalidWitness
+		}
+		w[i] = v
+	}
+	return verify(_proofs, vk, w, opts...)
+}
+
 // NewCS instantiate a concrete curved-typed SparseR1CS and return a ConstraintSystem interface
 // This method exists for (de)serialization purposes
 func NewCS(curveID ecc.ID) constraint.ConstraintSystem {
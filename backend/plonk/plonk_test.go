@@ -11,6 +11,7 @@ import (
 	"github.com/consensys/gnark-crypto/kzg"
 	"github.com/consensys/gnark/backend"
 	"github.com/consensys/gnark/backend/plonk"
+	"github.com/consensys/gnark/backend/witness"
 	"github.com/consensys/gnark/constraint"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/scs"
@@ -19,6 +20,56 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestBatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range getCurves() {
+		curve := curve
+		assert.Run(func(assert *test.Assert) {
+			ccs, err := frontend.Compile(curve.ScalarField(), scs.NewBuilder, &batchCircuit{})
+			assert.NoError(err)
+			srs, srsLagrange, err := unsafekzg.NewSRS(ccs)
+			assert.NoError(err)
+
+			pk, vk, err := plonk.Setup(ccs, srs, srsLagrange)
+			assert.NoError(err)
+
+			const nbProofs = 4
+			proofs := make([]plonk.Proof, nbProofs)
+			publicWitness := make([]witness.Witness, nbProofs)
+			for i := range proofs {
+				x := i + 2
+				w, err := frontend.NewWitness(&batchCircuit{X: x, Y: x * x}, curve.ScalarField())
+				assert.NoError(err)
+				var opts []backend.ProverOption
+				if i == 3 {
+					// the opening of this proof is only valid with the same KZG folding hash
+					opts = append(opts, backend.WithProverKZGFoldingHashFunction(constantHash{}))
+				}
+				proofs[i], err = plonk.Prove(ccs, pk, w, opts...)
+				assert.NoError(err)
+				publicWitness[i], err = w.Public()
+				assert.NoError(err)
+			}
+
+			assert.NoError(plonk.BatchVerify(proofs[:3], vk, publicWitness[:3]))
+			assert.Error(plonk.BatchVerify(nil, vk, nil))
+			assert.Error(plonk.BatchVerify(proofs, vk, publicWitness[:3]))
+
+			// the algebraic relation of the proof doesn't hold
+			publicWitness[0], publicWitness[1] = publicWitness[1], publicWitness[0]
+			err = plonk.BatchVerify(proofs[:3], vk, publicWitness[:3])
+			assert.Error(err)
+			assert.Contains(err.Error(), "proof 0")
+			publicWitness[0], publicWitness[1] = publicWitness[1], publicWitness[0]
+
+			// the opening of the proof is invalid
+			err = plonk.BatchVerify(proofs, vk, publicWitness)
+			assert.Error(err)
+			assert.Contains(err.Error(), "proof 3")
+		}, curve.String())
+	}
+}
+
 //--------------------//
 //     benches		  //
 //--------------------//
@@ -320,6 +371,16 @@ func (c *smallCircuit) Define(api frontend.API) error {
 	return nil
 }
 
+type batchCircuit struct {
+	X frontend.Variable
+	Y frontend.Variable `gnark:",public"`
+}
+
+func (c *batchCircuit) Define(api frontend.API) error {
+	api.AssertIsEqual(api.Mul(c.X, c.X), c.Y)
+	return nil
+}
+
 type constantHash struct{}
 
 func (h constantHash) Write(p []byte) (n int, err error) { return len(p), nil }
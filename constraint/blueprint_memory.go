@@ -0,0 +1,191 @@
+package constraint
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// BlueprintMemoryHint is a blueprint that facilitates read/write memory
+// accesses. It stores the sequence of memory operations only once. An
+// instruction queries a list of addresses at a given operation index and
+// returns, for every address, the value stored by the last operation on the
+// address before this index and the index of this operation.
+type BlueprintMemoryHint struct {
+	// OpsCalldata stores the operations. Every operation is 1 for writes and 0
+	// for reads, followed by the linear expression of the address and, for
+	// writes, the linear expression of the value.
+	OpsCalldata []uint32
+
+	// stores the maxLevel of the operations computed by WireWalker
+	maxLevel         Level
+	maxLevelPosition int
+	maxLevelOffset   int
+
+	// cache the operations replayed by the solver
+	accesses       map[Element][]memoryAccess
+	nbReplayed     int
+	replayedOffset int
+	lock           sync.Mutex
+}
+
+// memoryAccess is the value of an address after the operation op.
+type memoryAccess struct {
+	op    int
+	value Element
+}
+
+// ensures BlueprintMemoryHint implements the BlueprintStateful interface
+var _ BlueprintStateful = (*BlueprintMemoryHint)(nil)
+
+func (b *BlueprintMemoryHint) Solve(s Solver, inst Instruction) error {
+	nbOps := int(inst.Calldata[1])
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if err := b.replay(s, nbOps); err != nil {
+		return err
+	}
+
+	nbInputs := int(inst.Calldata[2])
+	offset, delta := 3, 0
+	for i := 0; i < nbInputs; i++ {
+		var addr Element
+		addr, delta = s.Read(inst.Calldata[offset:])
+		offset += delta
+		acc, ok := b.last(addr, nbOps)
+		if !ok {
+			return fmt.Errorf("memory address %s not initialized", s.String(addr))
+		}
+		s.SetValue(uint32(2*i+int(inst.WireOffset)), acc.value)
+		s.SetValue(uint32(2*i+1+int(inst.WireOffset)), s.FromInterface(acc.op))
+	}
+	return nil
+}
+
+// replay replays the operations up to nbOps.
+func (b *BlueprintMemoryHint) replay(s Solver, nbOps int) error {
+	if b.accesses == nil {
+		b.accesses = make(map[Element][]memoryAccess)
+	}
+	offset, delta := b.replayedOffset, 0
+	for i := b.nbReplayed; i < nbOps; i++ {
+		isWrite := b.OpsCalldata[offset] == 1
+		offset++
+		var addr, value Element
+		addr, delta = s.Read(b.OpsCalldata[offset:])
+		offset += delta
+		if isWrite {
+			value, delta = s.Read(b.OpsCalldata[offset:])
+			offset += delta
+		} else {
+			acc, ok := b.last(addr, i)
+			if !ok {
+				return fmt.Errorf("memory address %s not initialized", s.String(addr))
+			}
+			value = acc.value
+		}
+		b.accesses[addr] = append(b.accesses[addr], memoryAccess{op: i, value: value})
+	}
+	if nbOps > b.nbReplayed {
+		b.nbReplayed = nbOps
+		b.replayedOffset = offset
+	}
+	return nil
+}
+
+// last returns the last access to addr before the operation nbOps.
+func (b *BlueprintMemoryHint) last(addr Element, nbOps int) (memoryAccess, bool) {
+	accesses := b.accesses[addr]
+	i := sort.Search(len(accesses), func(i int) bool { return accesses[i].op >= nbOps })
+	if i == 0 {
+		return memoryAccess{}, false
+	}
+	return accesses[i-1], true
+}
+
+func (b *BlueprintMemoryHint) Reset() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.accesses = nil
+	b.nbReplayed = 0
+	b.replayedOffset = 0
+}
+
+func (b *BlueprintMemoryHint) CalldataSize() int {
+	// variable size
+	return -1
+}
+func (b *BlueprintMemoryHint) NbConstraints() int {
+	return 0
+}
+
+// NbOutputs return the number of output wires this blueprint creates.
+func (b *BlueprintMemoryHint) NbOutputs(inst Instruction) int {
+	return 2 * int(inst.Calldata[2])
+}
+
+func (b *BlueprintMemoryHint) UpdateInstructionTree(inst Instruction, tree InstructionTree) Level {
+	// depend on the operations UP to the operation index of the instruction.
+	nbOps := int(inst.Calldata[1])
+
+	// check if we already cached the max level
+	if b.maxLevelPosition < nbOps {
+
+		j := b.maxLevelOffset // skip the operations we already processed
+		for i := b.maxLevelPosition; i < nbOps; i++ {
+			isWrite := b.OpsCalldata[j] == 1
+			j++
+			nbExpressions := 1
+			if isWrite {
+				nbExpressions = 2
+			}
+			for e := 0; e < nbExpressions; e++ {
+				// first we have the length of the linear expression
+				n := int(b.OpsCalldata[j])
+				j++
+				for k := 0; k < n; k++ {
+					wireID := b.OpsCalldata[j+1]
+					j += 2
+					if !tree.HasWire(wireID) {
+						continue
+					}
+					if level := tree.GetWireLevel(wireID); (level + 1) > b.maxLevel {
+						b.maxLevel = level + 1
+					}
+				}
+			}
+		}
+		b.maxLevelOffset = j
+		b.maxLevelPosition = nbOps
+	}
+
+	maxLevel := b.maxLevel - 1 // offset for default value.
+
+	// update the max level with the queried addresses wires
+	nbInputs := int(inst.Calldata[2])
+	j := 3
+	for i := 0; i < nbInputs; i++ {
+		// first we have the length of the linear expression
+		n := int(inst.Calldata[j])
+		j++
+		for k := 0; k < n; k++ {
+			wireID := inst.Calldata[j+1]
+			j += 2
+			if !tree.HasWire(wireID) {
+				continue
+			}
+			if level := tree.GetWireLevel(wireID); level > maxLevel {
+				maxLevel = level
+			}
+		}
+	}
+
+	// finally we have the outputs
+	maxLevel++
+	for i := 0; i < 2*nbInputs; i++ {
+		tree.InsertWire(uint32(i+int(inst.WireOffset)), maxLevel)
+	}
+
+	return maxLevel
+}
@@ -358,6 +358,7 @@ func getTagSet() cbor.TagSet {
 	addType(reflect.TypeOf(BlueprintLookupHint{}))
 	addType(reflect.TypeOf(Groth16Commitments{}))
 	addType(reflect.TypeOf(PlonkCommitments{}))
+	addType(reflect.TypeOf(BlueprintMemoryHint{}))
 
 	return ts
 }
@@ -0,0 +1,80 @@
+// Package grain implements the Grain LFSR used by the reference
+// implementations of Poseidon and Poseidon2 to derive the round constants and
+// the MDS matrices.
+//
+// See the reference script generate_parameters_grain.sage of
+// https://extgit.iaik.tugraz.at/krypto/hadeshash.
+package grain
+
+import "math/big"
+
+// LFSR is the 80-bit Grain LFSR initialised with the parameters of the
+// permutation over a prime field with the S-box x ↦ xᵅ.
+type LFSR struct {
+	state [80]uint8
+}
+
+// New returns a new LFSR for a permutation of the given width over a prime
+// field of fieldSize bits with the given number of full and partial rounds.
+// The first 160 output bits are discarded as per the reference.
+func New(fieldSize, width, nbFullRounds, nbPartialRounds int) *LFSR {
+	var g LFSR
+	i := 0
+	put := func(v, nbBits int) {
+		for j := nbBits - 1; j >= 0; j-- {
+			g.state[i] = uint8(v>>j) & 1
+			i++
+		}
+	}
+	put(1, 2) // prime field
+	put(0, 4) // S-box x ↦ xᵅ
+	put(fieldSize, 12)
+	put(width, 12)
+	put(nbFullRounds, 10)
+	put(nbPartialRounds, 10)
+	for ; i < len(g.state); i++ {
+		g.state[i] = 1
+	}
+	for j := 0; j < 160; j++ {
+		g.next()
+	}
+	return &g
+}
+
+func (g *LFSR) next() uint8 {
+	b := g.state[62] ^ g.state[51] ^ g.state[38] ^ g.state[23] ^ g.state[13] ^ g.state[0]
+	copy(g.state[:], g.state[1:])
+	g.state[len(g.state)-1] = b
+	return b
+}
+
+// bit returns the next output bit using the self-shrinking rule: bits are
+// taken in pairs and the second bit is output only if the first one is set.
+func (g *LFSR) bit() uint {
+	for g.next() == 0 {
+		g.next()
+	}
+	return uint(g.next())
+}
+
+// Bits returns the integer formed by the next nbBits output bits, most
+// significant bit first.
+func (g *LFSR) Bits(nbBits int) *big.Int {
+	res := new(big.Int)
+	for i := 0; i < nbBits; i++ {
+		res.Lsh(res, 1)
+		res.SetBit(res, 0, g.bit())
+	}
+	return res
+}
+
+// FieldElement samples an element modulo the given modulus by rejection
+// sampling on integers of the bit length of the modulus.
+func (g *LFSR) FieldElement(modulus *big.Int) *big.Int {
+	for {
+		res := g.Bits(modulus.BitLen())
+		if res.Cmp(modulus) < 0 {
+			return res
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package merkle
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// MultiProof is a Merkle proof of several leaves of the same tree. The nodes
+// common to the paths of the leaves are given only once, and the nodes which
+// can be computed from the opened leaves are not given at all.
+//
+// Contrary to [MerkleProof], the indices of the leaves are not part of the
+// witness: the layout of the proof depends on them, so they are constants of
+// the circuit.
+type MultiProof struct {
+
+	// RootHash root of the Merkle tree
+	RootHash frontend.Variable
+
+	// Leaves data of the opened leaves, by increasing index
+	Leaves []frontend.Variable
+
+	// Siblings nodes of the paths which are not computed from the opened
+	// leaves, level by level from the leaves to the root and by increasing
+	// index within a level
+	Siblings []frontend.Variable
+}
+
+// MultiProofSiblings returns the positions of the siblings of a multi-proof
+// of the leaves at indices in a tree of the given depth, in the order of
+// [MultiProof.Siblings]. A position is given as the level of the node,
+// starting from 0 for the leaves, and its index within the level.
+//
+// The indices must be distinct, sorted by increasing order and less than
+// 2^depth.
+func MultiProofSiblings(depth int, indices []int) ([][2]int, error) {
+	if len(indices) == 0 {
+		return nil, errors.New("no leaf to prove")
+	}
+	for i := range indices {
+		if indices[i] < 0 || indices[i] >= 1<<depth {
+			return nil, fmt.Errorf("leaf index %d out of range [0, %d)", indices[i], 1<<depth)
+		}
+		if i > 0 && indices[i] <= indices[i-1] {
+			return nil, fmt.Errorf("leaf indices are not sorted and distinct at %d", i)
+		}
+	}
+	var siblings [][2]int
+	level := indices
+	for d := 0; d < depth; d++ {
+		next := make([]int, 0, len(level))
+		for i := 0; i < len(level); i++ {
+			if i+1 < len(level) && level[i]^1 == level[i+1] {
+				// both children are known
+				i++
+			} else {
+				siblings = append(siblings, [2]int{d, level[i] ^ 1})
+			}
+			next = append(next, level[i]>>1)
+		}
+		level = next
+	}
+	return siblings, nil
+}
+
+// VerifyProof asserts that Leaves are the leaves at indices of a tree of the
+// given depth with root RootHash. The indices are checked as in
+// [MultiProofSiblings] and the number of leaves and siblings must match them.
+func (mp *MultiProof) VerifyProof(api frontend.API, h hash.FieldHasher, depth int, indices []int) error {
+	positions, err := MultiProofSiblings(depth, indices)
+	if err != nil {
+		return err
+	}
+	if len(mp.Leaves) != len(indices) {
+		return fmt.Errorf("got %d leaves for %d indices", len(mp.Leaves), len(indices))
+	}
+	if len(mp.Siblings) != len(positions) {
+		return fmt.Errorf("got %d siblings, expected %d", len(mp.Siblings), len(positions))
+	}
+
+	level := make([]frontend.Variable, len(mp.Leaves))
+	for i := range mp.Leaves {
+		level[i] = leafSum(api, h, mp.Leaves[i])
+	}
+	levelIndices := indices
+	s := 0
+	for d := 0; d < depth; d++ {
+		next := make([]frontend.Variable, 0, len(level))
+		nextIndices := make([]int, 0, len(level))
+		for i := 0; i < len(level); i++ {
+			var d1, d2 frontend.Variable
+			if i+1 < len(level) && levelIndices[i]^1 == levelIndices[i+1] {
+				d1, d2 = level[i], level[i+1]
+				i++
+			} else if levelIndices[i]&1 == 0 {
+				d1, d2 = level[i], mp.Siblings[s]
+				s++
+			} else {
+				d1, d2 = mp.Siblings[s], level[i]
+				s++
+			}
+			next = append(next, nodeSum(api, h, d1, d2))
+			nextIndices = append(nextIndices, levelIndices[i]>>1)
+		}
+		level, levelIndices = next, nextIndices
+	}
+
+	// Compare our calculated Merkle root to the desired Merkle root.
+	api.AssertIsEqual(level[0], mp.RootHash)
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package tree builds Merkle trees out of circuit and generates the
+// assignments of [merkle.MerkleProof] for them.
+//
+// The trees follow the convention of [merkle.MerkleProof.VerifyProof]: the
+// leaves are the digests of the leaf data and the nodes are the digests of the
+// concatenation of their children, without domain separation. As the circuit
+// hashes every leaf as a single variable, the data of every leaf must be
+// exactly one canonical element of the field the hash function operates on,
+// encoded on the block size of the hash function, for example as a 32-byte
+// big-endian value for MiMC on BN254. The number of leaves must be a power of
+// two so that all the proofs have the same length.
+//
+// [Tree.Proof] and [Tree.Proofs] return proofs of single leaves, whose index is
+// part of the witness. [Tree.MultiProof] returns a [merkle.MultiProof] of
+// several leaves, which shares the nodes common to their paths but requires
+// the indices to be fixed in the circuit.
+package tree
+
+import (
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/accumulator/merkle"
+)
+
+// Tree is a Merkle tree over a list of leaves.
+type Tree struct {
+	h      hash.Hash
+	leaves [][]byte
+	// levels[0] are the digests of the leaves and levels[len(levels)-1] the
+	// root.
+	levels [][][]byte
+}
+
+// New builds the Merkle tree of the leaves using the hash function h, for
+// example a MiMC hash function of gnark-crypto. The number of leaves must be a
+// power of two and every leaf must be a single canonical field element of
+// h.BlockSize() bytes.
+func New(h hash.Hash, leaves [][]byte) (*Tree, error) {
+	if len(leaves) == 0 || len(leaves)&(len(leaves)-1) != 0 {
+		return nil, fmt.Errorf("number of leaves %d is not a power of two", len(leaves))
+	}
+	t := &Tree{h: h, leaves: leaves}
+	level := make([][]byte, len(leaves))
+	for i := range leaves {
+		if len(leaves[i]) != h.BlockSize() {
+			return nil, fmt.Errorf("leaf %d: length %d is not the block size %d", i, len(leaves[i]), h.BlockSize())
+		}
+		var err error
+		if level[i], err = t.leafSum(leaves[i]); err != nil {
+			return nil, fmt.Errorf("leaf %d: %w", i, err)
+		}
+	}
+	t.levels = append(t.levels, level)
+	for len(level) > 1 {
+		next := make([][]byte, len(level)/2)
+		for i := range next {
+			var err error
+			if next[i], err = t.nodeSum(level[2*i], level[2*i+1]); err != nil {
+				return nil, err
+			}
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+	return t, nil
+}
+
+// leafSum returns the digest of the leaf data.
+func (t *Tree) leafSum(data []byte) ([]byte, error) {
+	t.h.Reset()
+	if _, err := t.h.Write(data); err != nil {
+		return nil, err
+	}
+	return t.h.Sum(nil), nil
+}
+
+// nodeSum returns the digest of the concatenation of two nodes.
+func (t *Tree) nodeSum(a, b []byte) ([]byte, error) {
+	t.h.Reset()
+	if _, err := t.h.Write(a); err != nil {
+		return nil, err
+	}
+	if _, err := t.h.Write(b); err != nil {
+		return nil, err
+	}
+	return t.h.Sum(nil), nil
+}
+
+// Root returns the root of the tree.
+func (t *Tree) Root() []byte {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Depth returns the depth of the tree, which is the number of siblings in the
+// proofs.
+func (t *Tree) Depth() int {
+	return len(t.levels) - 1
+}
+
+// NbLeaves returns the number of leaves of the tree.
+func (t *Tree) NbLeaves() int {
+	return len(t.leaves)
+}
+
+// Placeholder returns a placeholder proof for compiling a circuit verifying
+// proofs for a tree of the given depth.
+func Placeholder(depth int) merkle.MerkleProof {
+	return merkle.MerkleProof{Path: make([]frontend.Variable, depth+1)}
+}
+
+// Proof returns the assignment of the proof for the leaf at index. The index
+// is the leaf to pass to [merkle.MerkleProof.VerifyProof].
+func (t *Tree) Proof(index int) (merkle.MerkleProof, error) {
+	if index < 0 || index >= len(t.leaves) {
+		return merkle.MerkleProof{}, fmt.Errorf("leaf index %d out of range [0, %d)", index, len(t.leaves))
+	}
+	path := make([]frontend.Variable, t.Depth()+1)
+	path[0] = t.leaves[index]
+	for i := 1; i < len(path); i++ {
+		path[i] = t.levels[i-1][index^1]
+		index >>= 1
+	}
+	return merkle.MerkleProof{RootHash: t.Root(), Path: path}, nil
+}
+
+// Proofs returns the assignments of the independent proofs for the leaves at
+// indices, for verifying several leaves of the same tree in a circuit.
+func (t *Tree) Proofs(indices []int) ([]merkle.MerkleProof, error) {
+	if len(indices) == 0 {
+		return nil, errors.New("no leaf to prove")
+	}
+	res := make([]merkle.MerkleProof, len(indices))
+	for i := range indices {
+		p, err := t.Proof(indices[i])
+		if err != nil {
+			return nil, fmt.Errorf("proof %d: %w", i, err)
+		}
+		res[i] = p
+	}
+	return res, nil
+}
+
+// MultiProofPlaceholder returns a placeholder multi-proof for compiling a
+// circuit verifying a multi-proof of the leaves at indices in a tree of the
+// given depth.
+func MultiProofPlaceholder(depth int, indices []int) (merkle.MultiProof, error) {
+	positions, err := merkle.MultiProofSiblings(depth, indices)
+	if err != nil {
+		return merkle.MultiProof{}, err
+	}
+	return merkle.MultiProof{
+		Leaves:   make([]frontend.Variable, len(indices)),
+		Siblings: make([]frontend.Variable, len(positions)),
+	}, nil
+}
+
+// MultiProof returns the assignment of the multi-proof for the leaves at
+// indices, to pass to [merkle.MultiProof.VerifyProof] with the same indices.
+// The indices must be distinct and sorted by increasing order.
+func (t *Tree) MultiProof(indices []int) (merkle.MultiProof, error) {
+	positions, err := merkle.MultiProofSiblings(t.Depth(), indices)
+	if err != nil {
+		return merkle.MultiProof{}, err
+	}
+	leaves := make([]frontend.Variable, len(indices))
+	for i := range indices {
+		leaves[i] = t.leaves[indices[i]]
+	}
+	siblings := make([]frontend.Variable, len(positions))
+	for i := range positions {
+		siblings[i] = t.levels[positions[i][0]][positions[i][1]]
+	}
+	return merkle.MultiProof{RootHash: t.Root(), Leaves: leaves, Siblings: siblings}, nil
+}
@@ -0,0 +1,181 @@
+package tree
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/accumulator/merkletree"
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/accumulator/merkle"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+type batchCircuit struct {
+	Proofs []merkle.MerkleProof
+	Leaves []frontend.Variable
+}
+
+func (c *batchCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	for i := range c.Proofs {
+		c.Proofs[i].VerifyProof(api, &h, c.Leaves[i])
+	}
+	return nil
+}
+
+type multiProofCircuit struct {
+	depth   int
+	indices []int
+
+	Proof merkle.MultiProof
+}
+
+func (c *multiProofCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	return c.Proof.VerifyProof(api, &h, c.depth, c.indices)
+}
+
+func randomLeaves(assert *test.Assert, n int) [][]byte {
+	mod := ecc.BN254.ScalarField()
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		v, err := rand.Int(rand.Reader, mod)
+		assert.NoError(err)
+		leaves[i] = make([]byte, 32)
+		v.FillBytes(leaves[i])
+	}
+	return leaves
+}
+
+func TestTree(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 4
+	leaves := randomLeaves(assert, 1<<depth)
+	tree, err := New(hash.MIMC_BN254.New(), leaves)
+	assert.NoError(err)
+	assert.Equal(depth, tree.Depth())
+
+	// the root is the same as computed by gnark-crypto
+	root, _, _, err := merkletree.BuildReaderProof(bytes.NewReader(bytes.Join(leaves, nil)), hash.MIMC_BN254.New(), 32, 0)
+	assert.NoError(err)
+	assert.Equal(root, tree.Root())
+
+	indices := []int{0, 5, 10, 15}
+	circuit := batchCircuit{Proofs: make([]merkle.MerkleProof, len(indices)), Leaves: make([]frontend.Variable, len(indices))}
+	for i := range indices {
+		circuit.Proofs[i] = Placeholder(depth)
+	}
+	proofs, err := tree.Proofs(indices)
+	assert.NoError(err)
+	witness := batchCircuit{Proofs: proofs, Leaves: make([]frontend.Variable, len(indices))}
+	for i := range indices {
+		witness.Leaves[i] = indices[i]
+	}
+	assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+}
+
+func TestTreeAllLeaves(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 3
+	leaves := randomLeaves(assert, 1<<depth)
+	tree, err := New(hash.MIMC_BN254.New(), leaves)
+	assert.NoError(err)
+	circuit := batchCircuit{Proofs: []merkle.MerkleProof{Placeholder(depth)}, Leaves: make([]frontend.Variable, 1)}
+	for i := 0; i < tree.NbLeaves(); i++ {
+		proof, err := tree.Proof(i)
+		assert.NoError(err)
+		witness := batchCircuit{Proofs: []merkle.MerkleProof{proof}, Leaves: []frontend.Variable{i}}
+		assert.NoError(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+		// the proof does not hold for another leaf index
+		witness.Leaves[0] = i ^ 1
+		assert.Error(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+	}
+}
+
+func TestTreeErrors(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, err := New(hash.MIMC_BN254.New(), randomLeaves(assert, 6))
+	assert.Error(err)
+	tree, err := New(hash.MIMC_BN254.New(), randomLeaves(assert, 4))
+	assert.NoError(err)
+	_, err = tree.Proof(4)
+	assert.Error(err)
+	_, err = tree.Proofs([]int{1, -1})
+	assert.Error(err)
+}
+
+func TestTreeInvalidLeaves(t *testing.T) {
+	assert := test.NewAssert(t)
+	// a leaf which is not a canonical field element
+	leaves := randomLeaves(assert, 4)
+	ecc.BN254.ScalarField().FillBytes(leaves[1])
+	_, err := New(hash.MIMC_BN254.New(), leaves)
+	assert.Error(err)
+	// a leaf of several field elements
+	leaves = randomLeaves(assert, 4)
+	leaves[2] = append(leaves[2], leaves[3]...)
+	_, err = New(hash.MIMC_BN254.New(), leaves)
+	assert.Error(err)
+	// a leaf shorter than a field element
+	leaves = randomLeaves(assert, 4)
+	leaves[0] = leaves[0][1:]
+	_, err = New(hash.MIMC_BN254.New(), leaves)
+	assert.Error(err)
+}
+
+func TestTreeMultiProof(t *testing.T) {
+	assert := test.NewAssert(t)
+	const depth = 4
+	leaves := randomLeaves(assert, 1<<depth)
+	tree, err := New(hash.MIMC_BN254.New(), leaves)
+	assert.NoError(err)
+
+	for _, indices := range [][]int{{5}, {0, 1}, {0, 5, 10, 15}, {2, 3, 4, 5, 6, 7}, {0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}} {
+		proof, err := tree.MultiProof(indices)
+		assert.NoError(err)
+		// the common nodes are not repeated
+		assert.LessOrEqual(len(proof.Siblings), len(indices)*depth)
+		placeholder, err := MultiProofPlaceholder(depth, indices)
+		assert.NoError(err)
+		circuit := multiProofCircuit{depth: depth, indices: indices, Proof: placeholder}
+		witness := multiProofCircuit{Proof: proof}
+		assert.NoError(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+
+		// the proof does not hold for another leaf
+		proof.Leaves[len(indices)-1] = leaves[indices[len(indices)-1]^1]
+		assert.Error(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+	}
+
+	// the paths of the leaves 0 and 5 merge at the third level, so that the
+	// multi-proof has 5 siblings instead of 8.
+	proof, err := tree.MultiProof([]int{0, 5})
+	assert.NoError(err)
+	assert.Len(proof.Siblings, 5)
+
+	indices := []int{0, 5, 10, 15}
+	placeholder, err := MultiProofPlaceholder(depth, indices)
+	assert.NoError(err)
+	proof, err = tree.MultiProof(indices)
+	assert.NoError(err)
+	assert.CheckCircuit(&multiProofCircuit{depth: depth, indices: indices, Proof: placeholder}, test.WithValidAssignment(&multiProofCircuit{Proof: proof}), test.WithCurves(ecc.BN254))
+}
+
+func TestTreeMultiProofErrors(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree, err := New(hash.MIMC_BN254.New(), randomLeaves(assert, 4))
+	assert.NoError(err)
+	for _, indices := range [][]int{nil, {4}, {-1, 2}, {2, 1}, {1, 1}} {
+		_, err = tree.MultiProof(indices)
+		assert.Error(err)
+	}
+}
@@ -43,6 +43,9 @@ limitations under the License.
 */
 
 // Package merkle provides a ZKP-circuit function to verify merkle proofs.
+//
+// The assignments of the proofs can be generated with the
+// [github.com/consensys/gnark/std/accumulator/merkle/tree] package.
 package merkle
 
 import (
@@ -0,0 +1,257 @@
+// Package mpt provides a ZKP-circuit function to verify Ethereum
+// Merkle-Patricia trie proofs.
+//
+// A proof is the list of the RLP-encoded trie nodes on the path from the root
+// to the leaf storing the value, as returned for example by the eth_getProof
+// RPC method. Every node is referenced by the hash of its encoding, which is
+// computed with a [hash.BinaryFixedLengthHasher] of 32 bytes digest, usually
+// [sha3.NewLegacyKeccak256]. Nodes shorter than 32 bytes are embedded in their
+// parent in Ethereum and are not supported. This is never the case for the
+// state and storage tries, where the keys are the 32-byte Keccak-256 digests
+// of the addresses and slots.
+//
+// [sha3.NewLegacyKeccak256]: https://pkg.go.dev/github.com/consensys/gnark/std/hash/sha3#NewLegacyKeccak256
+package mpt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/selector"
+)
+
+// MaxNodeSize is the length of the longest node of a trie with 32-byte keys:
+// a branch node with 16 child references and no value.
+const MaxNodeSize = 532
+
+// Proof stores the nodes of a Merkle-Patricia trie proof.
+type Proof struct {
+
+	// Nodes are the RLP-encoded nodes starting from the root, right-padded
+	// with zeros to the same length. The nodes after Depth are ignored.
+	Nodes [][]uints.U8
+
+	// Lengths are the actual lengths of the nodes.
+	Lengths []frontend.Variable
+
+	// Depth is the number of nodes in the proof.
+	Depth frontend.Variable
+}
+
+// PlaceholderProof returns a placeholder proof for compiling a circuit
+// verifying proofs of at most maxDepth nodes of at most nodeSize bytes.
+func PlaceholderProof(maxDepth, nodeSize int) Proof {
+	p := Proof{
+		Nodes:   make([][]uints.U8, maxDepth),
+		Lengths: make([]frontend.Variable, maxDepth),
+	}
+	for i := range p.Nodes {
+		p.Nodes[i] = make([]uints.U8, nodeSize)
+	}
+	return p
+}
+
+// ValueOfProof returns the assignment of a proof consisting of the given
+// nodes, padded to maxDepth nodes of nodeSize bytes.
+func ValueOfProof(nodes [][]byte, maxDepth, nodeSize int) (Proof, error) {
+	if len(nodes) == 0 || len(nodes) > maxDepth {
+		return Proof{}, fmt.Errorf("proof has %d nodes, expected between 1 and %d", len(nodes), maxDepth)
+	}
+	p := Proof{
+		Nodes:   make([][]uints.U8, maxDepth),
+		Lengths: make([]frontend.Variable, maxDepth),
+		Depth:   len(nodes),
+	}
+	for i := range p.Nodes {
+		padded := make([]byte, nodeSize)
+		p.Lengths[i] = 0
+		if i < len(nodes) {
+			if len(nodes[i]) > nodeSize {
+				return Proof{}, fmt.Errorf("node %d has %d bytes, expected at most %d", i, len(nodes[i]), nodeSize)
+			}
+			copy(padded, nodes[i])
+			p.Lengths[i] = len(nodes[i])
+		}
+		p.Nodes[i] = uints.NewU8Array(padded)
+	}
+	return p, nil
+}
+
+// VerifyInclusion asserts that the trie with the given root stores the value
+// at the key. The first valueLength bytes of value are the value, that is the
+// data of the RLP string in the leaf node. For the state and storage tries,
+// the key is the Keccak-256 digest of the address or the slot.
+//
+// The nodes are hashed using hashers returned by newHasher, for example
+// [sha3.NewLegacyKeccak256] for Ethereum.
+//
+// [sha3.NewLegacyKeccak256]: https://pkg.go.dev/github.com/consensys/gnark/std/hash/sha3#NewLegacyKeccak256
+func (p *Proof) VerifyInclusion(api frontend.API, newHasher func(frontend.API) (hash.BinaryFixedLengthHasher, error), root, key, value []uints.U8, valueLength frontend.Variable) error {
+	if len(p.Nodes) == 0 || len(p.Nodes) != len(p.Lengths) {
+		return errors.New("mismatching number of nodes and lengths")
+	}
+	if len(key) == 0 {
+		return errors.New("empty key")
+	}
+	for i := range p.Nodes {
+		// the header, the prefix and the compact encoding of the longest path
+		if len(p.Nodes[i]) < len(key)+5 {
+			return fmt.Errorf("node %d has %d bytes, expected at least %d", i, len(p.Nodes[i]), len(key)+5)
+		}
+	}
+
+	nbNibbles := 2 * len(key)
+	keyNibbles := logderivlookup.New(api)
+	for i := range key {
+		b := api.ToBinary(key[i].Val, 8)
+		keyNibbles.Insert(api.FromBinary(b[4:]...))
+		keyNibbles.Insert(api.FromBinary(b[:4]...))
+	}
+
+	// active[i] = 1 if the node i is part of the proof
+	active := mask(api, 1, p.Depth, len(p.Nodes))
+	api.AssertIsEqual(active[0], 1)
+	valueMask := mask(api, 1, valueLength, len(value))
+
+	// reference to the current node and the number of key nibbles consumed so
+	// far.
+	ref := make([]frontend.Variable, len(root))
+	for i := range root {
+		ref[i] = root[i].Val
+	}
+	var pos frontend.Variable = 0
+
+	for i := range p.Nodes {
+		a := active[i]
+		isLast := a
+		if i+1 < len(p.Nodes) {
+			isLast = api.Sub(a, active[i+1])
+		}
+
+		// the node hashes to the reference
+		h, err := newHasher(api)
+		if err != nil {
+			return fmt.Errorf("new hasher: %w", err)
+		}
+		if h.Size() != len(ref) {
+			return fmt.Errorf("hasher digest is %d bytes, expected %d", h.Size(), len(ref))
+		}
+		h.Write(p.Nodes[i])
+		digest := h.FixedLengthSum(p.Lengths[i])
+		for j := range digest {
+			api.AssertIsEqual(api.Mul(a, api.Sub(digest[j].Val, ref[j])), 0)
+		}
+
+		n := newNode(api, p.Nodes[i])
+		offset, length := n.listHeader(a)
+		api.AssertIsEqual(api.Mul(a, api.Sub(api.Add(offset, length), p.Lengths[i])), 0)
+
+		// branch nodes have 17 items and extension and leaf nodes 2 items. As
+		// all the items are strings, we decode the first two items and check
+		// if they end the node.
+		pathOffset, pathLength := n.stringItem(offset, a)
+		valueOffset, valueLen := n.stringItem(api.Add(pathOffset, pathLength), a)
+		isPair := api.IsZero(api.Sub(api.Add(valueOffset, valueLen), p.Lengths[i]))
+		isBranch := api.Sub(1, isPair)
+
+		// branch node. Every child is either empty or a 32-byte hash and we
+		// do not support values in branch nodes as the keys have the same
+		// length.
+		gb := api.Mul(a, isBranch)
+		childOffsets := make([]frontend.Variable, 16)
+		isHash := make([]frontend.Variable, 16)
+		o := offset
+		for j := 0; j < 17; j++ {
+			prefix := n.at(o)[0]
+			if j < 16 {
+				childOffsets[j] = o
+				isHash[j] = api.IsZero(api.Sub(prefix, 0xa0))
+				api.AssertIsEqual(api.Mul(gb, api.Sub(prefix, 0x80), api.Sub(1, isHash[j])), 0)
+				o = api.Add(o, api.Mul(isBranch, api.Add(1, api.Mul(32, isHash[j]))))
+			} else {
+				api.AssertIsEqual(api.Mul(gb, api.Sub(prefix, 0x80)), 0)
+				o = api.Add(o, isBranch)
+			}
+		}
+		api.AssertIsEqual(api.Mul(gb, api.Sub(o, p.Lengths[i])), 0)
+		nibble := keyNibbles.Lookup(api.Mul(gb, pos))[0]
+		childOffset := selector.Mux(api, nibble, childOffsets...)
+		api.AssertIsEqual(api.Mul(gb, api.Sub(1, selector.Mux(api, nibble, isHash...))), 0)
+
+		// extension and leaf nodes. The path is in compact encoding: the high
+		// nibble of the first byte is 2 for leaves, plus 1 if the path has an
+		// odd number of nibbles, in which case the first nibble is the low
+		// nibble of the first byte.
+		gp := api.Mul(a, isPair)
+		pathOffsets := make([]frontend.Variable, len(key)+1)
+		for j := range pathOffsets {
+			pathOffsets[j] = api.Add(pathOffset, j)
+		}
+		pathBytes := n.at(pathOffsets...)
+		pathNibbles := make([]frontend.Variable, 0, 2*len(pathBytes))
+		for j := range pathBytes {
+			b := api.ToBinary(pathBytes[j], 8)
+			if j == 0 {
+				api.AssertIsEqual(api.Mul(gp, api.Add(b[7], b[6])), 0)
+				pathNibbles = append(pathNibbles, 0, api.FromBinary(b[:4]...))
+				continue
+			}
+			pathNibbles = append(pathNibbles, api.FromBinary(b[4:]...), api.FromBinary(b[:4]...))
+		}
+		first := api.ToBinary(pathBytes[0], 8)
+		isOdd := first[4]
+		isLeaf := api.Mul(isPair, first[5])
+		isExtension := api.Sub(isPair, isLeaf)
+		nbPathNibbles := api.Add(api.Mul(2, api.Sub(pathLength, 1)), isOdd)
+		pathMask := mask(api, gp, nbPathNibbles, nbNibbles)
+		nibbleMask := make([]frontend.Variable, nbNibbles)
+		nibbleOffsets := make([]frontend.Variable, nbNibbles)
+		for k := range nibbleMask {
+			nibbleMask[k] = api.Mul(gp, pathMask[k])
+			nibbleOffsets[k] = api.Mul(nibbleMask[k], api.Add(pos, k))
+		}
+		nibbles := keyNibbles.Lookup(nibbleOffsets...)
+		for k := range nibbles {
+			pathNibble := api.Select(isOdd, pathNibbles[k+1], pathNibbles[k+2])
+			api.AssertIsEqual(api.Mul(nibbleMask[k], api.Sub(pathNibble, nibbles[k])), 0)
+		}
+
+		// the leaf is the last node, it consumes the rest of the key and
+		// stores the value.
+		gl := api.Mul(a, isLeaf)
+		api.AssertIsEqual(api.Mul(a, api.Sub(isLast, isLeaf)), 0)
+		api.AssertIsEqual(api.Mul(gl, api.Sub(api.Add(pos, nbPathNibbles), nbNibbles)), 0)
+		api.AssertIsEqual(api.Mul(gl, api.Sub(valueLen, valueLength)), 0)
+		valueMasks := make([]frontend.Variable, len(value))
+		valueOffsets := make([]frontend.Variable, len(value))
+		for j := range value {
+			valueMasks[j] = api.Mul(gl, valueMask[j])
+			valueOffsets[j] = api.Mul(valueMasks[j], api.Add(valueOffset, j))
+		}
+		if len(value) > 0 {
+			values := n.at(valueOffsets...)
+			for j := range values {
+				api.AssertIsEqual(api.Mul(valueMasks[j], api.Sub(value[j].Val, values[j])), 0)
+			}
+		}
+
+		// the extension references the next node by hash
+		api.AssertIsEqual(api.Mul(a, isExtension, api.Sub(valueLen, 32)), 0)
+
+		// the reference to the next node follows the prefix of the selected
+		// child for branch nodes and is the second item for extension nodes.
+		refOffset := api.Select(isBranch, api.Add(childOffset, 1), valueOffset)
+		gr := api.Sub(a, gl)
+		refOffsets := make([]frontend.Variable, len(ref))
+		for j := range refOffsets {
+			refOffsets[j] = api.Mul(gr, api.Add(refOffset, j))
+		}
+		ref = n.at(refOffsets...)
+		pos = api.Add(pos, isBranch, api.Mul(isPair, nbPathNibbles))
+	}
+	return nil
+}
@@ -0,0 +1,396 @@
+package mpt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha3"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	nativesha3 "golang.org/x/crypto/sha3"
+)
+
+// native Merkle-Patricia trie for generating test witnesses
+
+func keccak(in []byte) []byte {
+	h := nativesha3.NewLegacyKeccak256()
+	h.Write(in)
+	return h.Sum(nil)
+}
+
+func rlpLength(prefix byte, n int) []byte {
+	if n <= 55 {
+		return []byte{prefix + byte(n)}
+	}
+	var l []byte
+	for ; n > 0; n >>= 8 {
+		l = append([]byte{byte(n)}, l...)
+	}
+	return append([]byte{prefix + 55 + byte(len(l))}, l...)
+}
+
+func rlpString(s []byte) []byte {
+	if len(s) == 1 && s[0] < 0x80 {
+		return []byte{s[0]}
+	}
+	return append(rlpLength(0x80, len(s)), s...)
+}
+
+func rlpList(items ...[]byte) []byte {
+	payload := bytes.Join(items, nil)
+	return append(rlpLength(0xc0, len(payload)), payload...)
+}
+
+func toNibbles(key []byte) []byte {
+	res := make([]byte, 0, 2*len(key))
+	for _, b := range key {
+		res = append(res, b>>4, b&0xf)
+	}
+	return res
+}
+
+func compact(nibbles []byte, isLeaf bool) []byte {
+	var flag byte
+	if isLeaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		flag++
+		nibbles = append([]byte{flag}, nibbles...)
+	} else {
+		nibbles = append([]byte{flag, 0}, nibbles...)
+	}
+	res := make([]byte, len(nibbles)/2)
+	for i := range res {
+		res[i] = nibbles[2*i]<<4 | nibbles[2*i+1]
+	}
+	return res
+}
+
+func commonPrefix(keys [][]byte, depth int) int {
+	n := 0
+	for {
+		if depth+n >= len(keys[0]) {
+			return n
+		}
+		for _, k := range keys[1:] {
+			if k[depth+n] != keys[0][depth+n] {
+				return n
+			}
+		}
+		n++
+	}
+}
+
+// buildTrie returns the encoding of the node storing the values at the
+// nibble keys from depth on and the nodes on the path to target.
+func buildTrie(keys, values [][]byte, depth int, target []byte) (enc []byte, proof [][]byte) {
+	var child [][]byte
+	if len(keys) == 1 {
+		enc = rlpList(rlpString(compact(keys[0][depth:], true)), rlpString(values[0]))
+	} else if n := commonPrefix(keys, depth); n > 0 {
+		next, childProof := buildTrie(keys, values, depth+n, target)
+		child = childProof
+		enc = rlpList(rlpString(compact(keys[0][depth:depth+n], false)), rlpString(keccak(next)))
+	} else {
+		items := make([][]byte, 17)
+		for nibble := byte(0); nibble < 16; nibble++ {
+			var ks, vs [][]byte
+			for i := range keys {
+				if keys[i][depth] == nibble {
+					ks = append(ks, keys[i])
+					vs = append(vs, values[i])
+				}
+			}
+			items[nibble] = rlpString(nil)
+			if len(ks) > 0 {
+				next, childProof := buildTrie(ks, vs, depth+1, target)
+				if target != nil && target[depth] == nibble {
+					child = childProof
+				}
+				items[nibble] = rlpString(keccak(next))
+			}
+		}
+		items[16] = rlpString(nil)
+		enc = rlpList(items...)
+	}
+	if len(enc) < 32 {
+		panic("embedded nodes not supported")
+	}
+	for _, k := range keys {
+		if bytes.Equal(k, target) {
+			return enc, append([][]byte{enc}, child...)
+		}
+	}
+	return enc, nil
+}
+
+type testTrie struct {
+	keys, values [][]byte
+}
+
+func (t *testTrie) proof(key []byte) (root []byte, proof [][]byte) {
+	nibbles := make([][]byte, len(t.keys))
+	for i := range t.keys {
+		nibbles[i] = toNibbles(t.keys[i])
+	}
+	enc, proof := buildTrie(nibbles, t.values, 0, toNibbles(key))
+	return keccak(enc), proof
+}
+
+func randomBytes(assert *test.Assert, n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	assert.NoError(err)
+	return b
+}
+
+const (
+	testMaxDepth  = 5
+	testValueSize = 128
+)
+
+type inclusionCircuit struct {
+	Proof       Proof
+	Root        [32]uints.U8
+	Key         [32]uints.U8
+	Value       [testValueSize]uints.U8
+	ValueLength frontend.Variable
+}
+
+func (c *inclusionCircuit) Define(api frontend.API) error {
+	return c.Proof.VerifyInclusion(api, sha3.NewLegacyKeccak256, c.Root[:], c.Key[:], c.Value[:], c.ValueLength)
+}
+
+func inclusionWitness(assert *test.Assert, root, key, value []byte, nodes [][]byte) *inclusionCircuit {
+	proof, err := ValueOfProof(nodes, testMaxDepth, MaxNodeSize)
+	assert.NoError(err)
+	w := &inclusionCircuit{Proof: proof, ValueLength: len(value)}
+	copy(w.Root[:], uints.NewU8Array(root))
+	copy(w.Key[:], uints.NewU8Array(key))
+	padded := make([]byte, testValueSize)
+	copy(padded, value)
+	copy(w.Value[:], uints.NewU8Array(padded))
+	return w
+}
+
+func TestVerifyInclusion(t *testing.T) {
+	assert := test.NewAssert(t)
+	trie := &testTrie{}
+	for i := 0; i < 6; i++ {
+		key := randomBytes(assert, 32)
+		// distinct first nibbles so that the root is a branch node
+		// referencing the leaves directly.
+		key[0] = byte(i<<4) | key[0]&0x0f
+		trie.keys = append(trie.keys, key)
+		trie.values = append(trie.values, randomBytes(assert, 32+15*i))
+	}
+	// keys sharing a prefix, stored below an extension node.
+	extKey := bytes.Clone(trie.keys[0])
+	extKey[20] ^= 0x01
+	trie.keys = append(trie.keys, extKey)
+	trie.values = append(trie.values, randomBytes(assert, 33))
+
+	circuit := inclusionCircuit{Proof: PlaceholderProof(testMaxDepth, MaxNodeSize)}
+	// leaf below an extension node, leaf with long value and leaf below the
+	// root.
+	for _, i := range []int{6, 5, 1} {
+		assert.Run(func(assert *test.Assert) {
+			root, nodes := trie.proof(trie.keys[i])
+			witness := inclusionWitness(assert, root, trie.keys[i], trie.values[i], nodes)
+			err := test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+		}, fmt.Sprintf("key=%d", i))
+	}
+	root, nodes := trie.proof(trie.keys[0])
+	assert.Equal(4, len(nodes), "expected branch, extension, branch and leaf nodes")
+	assert.Run(func(assert *test.Assert) {
+		witness := inclusionWitness(assert, root, trie.keys[0], trie.values[1], nodes)
+		err := test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_value")
+	assert.Run(func(assert *test.Assert) {
+		witness := inclusionWitness(assert, root, trie.keys[0], trie.values[0], nodes)
+		witness.ValueLength = len(trie.values[0]) - 1
+		err := test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_value_length")
+	assert.Run(func(assert *test.Assert) {
+		witness := inclusionWitness(assert, root, trie.keys[1], trie.values[0], nodes)
+		err := test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_key")
+	assert.Run(func(assert *test.Assert) {
+		witness := inclusionWitness(assert, randomBytes(assert, 32), trie.keys[0], trie.values[0], nodes)
+		err := test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_root")
+	assert.Run(func(assert *test.Assert) {
+		witness := inclusionWitness(assert, root, trie.keys[0], trie.values[0], nodes[:len(nodes)-1])
+		err := test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "truncated")
+	assert.Run(func(assert *test.Assert) {
+		_, otherNodes := trie.proof(trie.keys[1])
+		witness := inclusionWitness(assert, root, trie.keys[0], trie.values[0], otherNodes)
+		err := test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "other_proof")
+}
+
+// ethProof is the result of the eth_getProof RPC method together with the
+// state root of the block it was requested for.
+type ethProof struct {
+	StateRoot hexBytes `json:"stateRoot"`
+	Result    struct {
+		Address      hexBytes          `json:"address"`
+		AccountProof []hexBytes        `json:"accountProof"`
+		Balance      hexBytes          `json:"balance"`
+		CodeHash     hexBytes          `json:"codeHash"`
+		Nonce        hexBytes          `json:"nonce"`
+		StorageHash  hexBytes          `json:"storageHash"`
+		StorageProof []ethStorageProof `json:"storageProof"`
+	} `json:"result"`
+}
+
+type ethStorageProof struct {
+	Key   hexBytes   `json:"key"`
+	Value hexBytes   `json:"value"`
+	Proof []hexBytes `json:"proof"`
+}
+
+// hexBytes is a 0x-prefixed hex string. Quantities may have an odd number of
+// digits.
+type hexBytes []byte
+
+func (h *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	s = strings.TrimPrefix(s, "0x")
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+	b, err := hex.DecodeString(s)
+	*h = b
+	return err
+}
+
+func nodesOf(h []hexBytes) [][]byte {
+	res := make([][]byte, len(h))
+	for i := range h {
+		res[i] = h[i]
+	}
+	return res
+}
+
+// quantity returns the minimal big-endian encoding of the quantity q as
+// stored in the tries.
+func quantity(q []byte) []byte {
+	return bytes.TrimLeft(q, "\x00")
+}
+
+// verifyEthProof checks the account proof against the state root and the
+// storage proofs of non-empty slots against the storage root of the account.
+func verifyEthProof(assert *test.Assert, p *ethProof) {
+	r := &p.Result
+	account := rlpList(rlpString(quantity(r.Nonce)), rlpString(quantity(r.Balance)), rlpString(r.StorageHash), rlpString(r.CodeHash))
+	check := func(assert *test.Assert, root, key, value []byte, nodes [][]byte) {
+		proof, err := ValueOfProof(nodes, len(nodes), MaxNodeSize)
+		assert.NoError(err)
+		witness := &inclusionCircuit{Proof: proof, ValueLength: len(value)}
+		copy(witness.Root[:], uints.NewU8Array(root))
+		copy(witness.Key[:], uints.NewU8Array(key))
+		padded := make([]byte, testValueSize)
+		copy(padded, value)
+		copy(witness.Value[:], uints.NewU8Array(padded))
+		circuit := inclusionCircuit{Proof: PlaceholderProof(len(nodes), MaxNodeSize)}
+		err = test.IsSolved(&circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+	assert.Run(func(assert *test.Assert) {
+		check(assert, p.StateRoot, keccak(r.Address), account, nodesOf(r.AccountProof))
+	}, "account")
+	for i, sp := range r.StorageProof {
+		if len(quantity(sp.Value)) == 0 {
+			// the proofs of empty slots are exclusion proofs
+			continue
+		}
+		slot := make([]byte, 32)
+		copy(slot[32-len(sp.Key):], sp.Key)
+		assert.Run(func(assert *test.Assert) {
+			check(assert, r.StorageHash, keccak(slot), rlpString(quantity(sp.Value)), nodesOf(sp.Proof))
+		}, fmt.Sprintf("storage=%d", i))
+	}
+}
+
+func TestVerifyEthProof(t *testing.T) {
+	// account and storage proofs laid out as returned by eth_getProof: the
+	// account leaf stores the root of the storage trie.
+	assert := test.NewAssert(t)
+	var p ethProof
+	r := &p.Result
+	r.Address = randomBytes(assert, 20)
+	r.Nonce = []byte{0x07}
+	r.Balance = append([]byte{0x01}, randomBytes(assert, 11)...)
+	r.CodeHash = keccak(randomBytes(assert, 64))
+
+	storage := &testTrie{}
+	var slotValues [][]byte
+	for i := 0; i < 20; i++ {
+		slot := make([]byte, 32)
+		slot[31] = byte(i)
+		v := append([]byte{byte(i + 1)}, randomBytes(assert, i)...)
+		storage.keys = append(storage.keys, keccak(slot))
+		storage.values = append(storage.values, rlpString(v))
+		slotValues = append(slotValues, v)
+	}
+	for _, i := range []int{0, 19} {
+		root, nodes := storage.proof(storage.keys[i])
+		r.StorageHash = root
+		sp := ethStorageProof{Key: []byte{byte(i)}, Value: slotValues[i]}
+		for _, n := range nodes {
+			sp.Proof = append(sp.Proof, n)
+		}
+		r.StorageProof = append(r.StorageProof, sp)
+	}
+	account := rlpList(rlpString(r.Nonce), rlpString(r.Balance), rlpString(r.StorageHash), rlpString(r.CodeHash))
+	state := &testTrie{keys: [][]byte{keccak(r.Address)}, values: [][]byte{account}}
+	for i := 0; i < 30; i++ {
+		state.keys = append(state.keys, keccak(randomBytes(assert, 20)))
+		state.values = append(state.values, randomBytes(assert, 70))
+	}
+	root, nodes := state.proof(state.keys[0])
+	p.StateRoot = root
+	for _, n := range nodes {
+		r.AccountProof = append(r.AccountProof, n)
+	}
+	verifyEthProof(assert, &p)
+
+	// responses of eth_getProof together with the state root, saved as
+	// testdata/eth_getproof_*.json. They were generated with the state trie of
+	// go-ethereum v1.14.12 for 300 accounts, the first one being a contract
+	// with 200 storage slots, in the format of its eth_getProof handler.
+	files, err := filepath.Glob(filepath.Join("testdata", "eth_getproof_*.json"))
+	assert.NoError(err)
+	assert.NotEmpty(files)
+	for _, f := range files {
+		assert.Run(func(assert *test.Assert) {
+			data, err := os.ReadFile(f)
+			assert.NoError(err)
+			var p ethProof
+			assert.NoError(json.Unmarshal(data, &p))
+			verifyEthProof(assert, &p)
+		}, filepath.Base(f))
+	}
+}
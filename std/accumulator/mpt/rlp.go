@@ -0,0 +1,103 @@
+package mpt
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/lookup/logderivlookup"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// node allows to read the bytes of an RLP-encoded trie node at variable
+// offsets.
+type node struct {
+	api   frontend.API
+	bytes []uints.U8
+	tbl   *logderivlookup.Table
+}
+
+func newNode(api frontend.API, in []uints.U8) *node {
+	tbl := logderivlookup.New(api)
+	for i := range in {
+		tbl.Insert(in[i].Val)
+	}
+	// we read the prefix of an item and the byte following it at once, pad so
+	// that reading past the end of the node does not fail.
+	tbl.Insert(0)
+	tbl.Insert(0)
+	return &node{api: api, bytes: in, tbl: tbl}
+}
+
+// at returns the bytes at the given offsets.
+func (n *node) at(offsets ...frontend.Variable) []frontend.Variable {
+	return n.tbl.Lookup(offsets...)
+}
+
+// listHeader decodes the header of the RLP list encoding the node and returns
+// the offset and the length of its payload. Lists whose length is encoded on
+// more than two bytes are not supported as they do not occur in the trie. The
+// checks are enforced only if active is 1.
+func (n *node) listHeader(active frontend.Variable) (offset, length frontend.Variable) {
+	api := n.api
+	b := api.ToBinary(n.bytes[0].Val, 8)
+	// lists have prefix 0xc0-0xff
+	api.AssertIsEqual(api.Mul(active, api.Sub(1, api.Mul(b[7], b[6]))), 0)
+	// long lists have prefix 0xf8-0xff, the length of the length is the
+	// prefix minus 0xf7.
+	isLong := api.Mul(b[7], b[6], b[5], b[4], b[3])
+	isLongSupported := api.Mul(isLong, api.Sub(1, b[2]), api.Sub(1, b[1]))
+	api.AssertIsEqual(api.Mul(active, api.Sub(isLong, isLongSupported)), 0)
+	isF9 := api.Mul(isLongSupported, b[0])
+	isF8 := api.Sub(isLongSupported, isF9)
+
+	offset = api.Add(1, isF8, api.Mul(2, isF9))
+	length = api.Add(
+		api.Mul(api.Sub(1, isLong), api.Sub(n.bytes[0].Val, 0xc0)),
+		api.Mul(isF8, n.bytes[1].Val),
+		api.Mul(isF9, api.Add(api.Mul(256, n.bytes[1].Val), n.bytes[2].Val)),
+	)
+	return offset, length
+}
+
+// stringItem decodes the RLP string item starting at offset and returns the
+// offset and the length of its data. Strings whose length is encoded on more
+// than one byte are not supported as they do not occur in the trie. The checks
+// are enforced only if active is 1.
+func (n *node) stringItem(offset, active frontend.Variable) (dataOffset, dataLength frontend.Variable) {
+	api := n.api
+	v := n.at(offset, api.Add(offset, 1))
+	b := api.ToBinary(v[0], 8)
+	// strings have prefix 0x00-0xbf
+	api.AssertIsEqual(api.Mul(active, b[7], b[6]), 0)
+	// long strings have prefix 0xb8-0xbf, the length of the length is the
+	// prefix minus 0xb7.
+	isLong := api.Mul(b[7], b[5], b[4], b[3])
+	isB8 := api.Mul(isLong, api.Sub(1, b[2]), api.Sub(1, b[1]), api.Sub(1, b[0]))
+	api.AssertIsEqual(api.Mul(active, api.Sub(isLong, isB8)), 0)
+	// short strings have prefix 0x80-0xb7 and single bytes below 0x80 are
+	// their own encoding.
+	isShort := api.Sub(b[7], isLong)
+	isSingle := api.Sub(1, b[7])
+
+	dataOffset = api.Add(offset, isShort, api.Mul(2, isB8))
+	dataLength = api.Add(
+		isSingle,
+		api.Mul(isShort, api.Sub(v[0], 0x80)),
+		api.Mul(isB8, v[1]),
+	)
+	return dataOffset, dataLength
+}
+
+// mask returns a slice m of n boolean variables where m[i] = 1 if i < length
+// and m[i] = 0 otherwise. It asserts that 0 ≤ length ≤ n if active is 1.
+func mask(api frontend.API, active, length frontend.Variable, n int) []frontend.Variable {
+	res := make([]frontend.Variable, n)
+	// acc = 1 if i ≥ length and 0 otherwise
+	var acc frontend.Variable = 0
+	for i := 0; i <= n; i++ {
+		acc = api.Add(acc, api.IsZero(api.Sub(length, i)))
+		if i < n {
+			res[i] = api.Sub(1, acc)
+		}
+	}
+	api.AssertIsEqual(api.Mul(active, api.Sub(acc, 1)), 0)
+	return res
+}
@@ -0,0 +1,99 @@
+// Package smt provides ZKP-circuit functions to verify sparse Merkle tree
+// proofs.
+//
+// The tree has a fixed depth defined by the number of siblings in the proof and
+// stores a value at every key in [0, 2^depth). The value zero denotes an absent
+// key, whose leaf node is zero. A present key is stored as the leaf node
+// H(key, value). Internal nodes are H(left, right), so the empty subtrees are
+// the successive hashes of the empty leaf.
+//
+// The key bits select the path from the leaf to the root in little-endian
+// order: the bit i of the key is 1 if the node at level i of the path is a
+// right child. As the key is part of the leaf, a proof for a key cannot be
+// reused for another key sharing the same path.
+//
+// The nodes are native field elements hashed with a [hash.FieldHasher], such
+// as MiMC or Poseidon. Trees hashed with a binary hasher, for example the
+// Keccak-256 sparse Merkle trees used outside of circuits, are not supported.
+package smt
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+)
+
+// Proof stores the sibling nodes of a sparse Merkle tree path.
+type Proof struct {
+
+	// Siblings of the path, starting from the sibling of the leaf.
+	Siblings []frontend.Variable
+}
+
+// leafSum returns the leaf node for the key and value. It is zero if the value
+// is zero and H(key, value) otherwise.
+func leafSum(api frontend.API, h hash.FieldHasher, key, value frontend.Variable) frontend.Variable {
+
+	h.Reset()
+	h.Write(key, value)
+	res := h.Sum()
+
+	return api.Select(api.IsZero(value), 0, res)
+}
+
+// nodeSum returns the hash of two sibling nodes.
+func nodeSum(api frontend.API, h hash.FieldHasher, a, b frontend.Variable) frontend.Variable {
+
+	h.Reset()
+	h.Write(a, b)
+	res := h.Sum()
+
+	return res
+}
+
+// computeRoot returns the root obtained from the leaf and the siblings on the
+// path given by the little-endian key bits.
+func (p *Proof) computeRoot(api frontend.API, h hash.FieldHasher, bits []frontend.Variable, leaf frontend.Variable) frontend.Variable {
+
+	sum := leaf
+	for i := range p.Siblings {
+		d1 := api.Select(bits[i], p.Siblings[i], sum)
+		d2 := api.Select(bits[i], sum, p.Siblings[i])
+		sum = nodeSum(api, h, d1, d2)
+	}
+
+	return sum
+}
+
+// VerifyMembership asserts that the key is stored in the tree with the given
+// root and that its value is value. The value must be non-zero and the key must
+// be less than 2^len(p.Siblings).
+func (p *Proof) VerifyMembership(api frontend.API, h hash.FieldHasher, root, key, value frontend.Variable) {
+
+	api.AssertIsDifferent(value, 0)
+	bits := api.ToBinary(key, len(p.Siblings))
+	sum := p.computeRoot(api, h, bits, leafSum(api, h, key, value))
+	api.AssertIsEqual(sum, root)
+}
+
+// VerifyNonMembership asserts that the key is not stored in the tree with the
+// given root. The key must be less than 2^len(p.Siblings).
+func (p *Proof) VerifyNonMembership(api frontend.API, h hash.FieldHasher, root, key frontend.Variable) {
+
+	bits := api.ToBinary(key, len(p.Siblings))
+	sum := p.computeRoot(api, h, bits, 0)
+	api.AssertIsEqual(sum, root)
+}
+
+// VerifyUpdate asserts that setting the value of the key from oldValue to
+// newValue changes the root of the tree from oldRoot to newRoot. The siblings
+// are the same before and after the update. A zero oldValue corresponds to an
+// insertion and a zero newValue to a deletion. The key must be less than
+// 2^len(p.Siblings).
+func (p *Proof) VerifyUpdate(api frontend.API, h hash.FieldHasher, oldRoot, newRoot, key, oldValue, newValue frontend.Variable) {
+
+	bits := api.ToBinary(key, len(p.Siblings))
+	oldSum := p.computeRoot(api, h, bits, leafSum(api, h, key, oldValue))
+	api.AssertIsEqual(oldSum, oldRoot)
+	newSum := p.computeRoot(api, h, bits, leafSum(api, h, key, newValue))
+	api.AssertIsEqual(newSum, newRoot)
+}
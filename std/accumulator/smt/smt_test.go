@@ -0,0 +1,221 @@
+package smt
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark-crypto/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/test"
+)
+
+const testDepth = 8
+
+// nativeTree is a sparse Merkle tree over MiMC on BN254 for generating test
+// witnesses.
+type nativeTree struct {
+	values map[uint64]*big.Int
+}
+
+func newNativeTree() *nativeTree {
+	return &nativeTree{values: make(map[uint64]*big.Int)}
+}
+
+func nativeHash(a, b *big.Int) *big.Int {
+	var ea, eb fr.Element
+	ea.SetBigInt(a)
+	eb.SetBigInt(b)
+	h := hash.MIMC_BN254.New()
+	ba, bb := ea.Bytes(), eb.Bytes()
+	h.Write(ba[:])
+	h.Write(bb[:])
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+func (t *nativeTree) levels() [][]*big.Int {
+	leaves := make([]*big.Int, 1<<testDepth)
+	for i := range leaves {
+		if v, ok := t.values[uint64(i)]; ok && v.Sign() != 0 {
+			leaves[i] = nativeHash(big.NewInt(int64(i)), v)
+		} else {
+			leaves[i] = new(big.Int)
+		}
+	}
+	res := [][]*big.Int{leaves}
+	for len(leaves) > 1 {
+		next := make([]*big.Int, len(leaves)/2)
+		for i := range next {
+			next[i] = nativeHash(leaves[2*i], leaves[2*i+1])
+		}
+		res = append(res, next)
+		leaves = next
+	}
+	return res
+}
+
+func (t *nativeTree) root() *big.Int {
+	l := t.levels()
+	return l[len(l)-1][0]
+}
+
+func (t *nativeTree) proof(key uint64) []frontend.Variable {
+	l := t.levels()
+	siblings := make([]frontend.Variable, testDepth)
+	for i := range siblings {
+		siblings[i] = l[i][(key>>i)^1]
+	}
+	return siblings
+}
+
+func randomValue(assert *test.Assert) *big.Int {
+	v, err := rand.Int(rand.Reader, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	return v
+}
+
+type membershipCircuit struct {
+	P     Proof
+	Root  frontend.Variable
+	Key   frontend.Variable
+	Value frontend.Variable
+}
+
+func (c *membershipCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.P.VerifyMembership(api, &h, c.Root, c.Key, c.Value)
+	return nil
+}
+
+type nonMembershipCircuit struct {
+	P    Proof
+	Root frontend.Variable
+	Key  frontend.Variable
+}
+
+func (c *nonMembershipCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.P.VerifyNonMembership(api, &h, c.Root, c.Key)
+	return nil
+}
+
+type updateCircuit struct {
+	P                  Proof
+	OldRoot, NewRoot   frontend.Variable
+	Key                frontend.Variable
+	OldValue, NewValue frontend.Variable
+}
+
+func (c *updateCircuit) Define(api frontend.API) error {
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return err
+	}
+	c.P.VerifyUpdate(api, &h, c.OldRoot, c.NewRoot, c.Key, c.OldValue, c.NewValue)
+	return nil
+}
+
+func TestMembership(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newNativeTree()
+	for _, k := range []uint64{3, 17, 200, 201} {
+		tree.values[k] = randomValue(assert)
+	}
+	circuit := membershipCircuit{P: Proof{Siblings: make([]frontend.Variable, testDepth)}}
+	assert.Run(func(assert *test.Assert) {
+		witness := membershipCircuit{P: Proof{Siblings: tree.proof(200)}, Root: tree.root(), Key: 200, Value: tree.values[200]}
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+	}, "valid")
+	assert.Run(func(assert *test.Assert) {
+		witness := membershipCircuit{P: Proof{Siblings: tree.proof(200)}, Root: tree.root(), Key: 200, Value: tree.values[201]}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_value")
+	assert.Run(func(assert *test.Assert) {
+		// the leaf contains the key, so the proof for 200 does not apply to 201
+		// even though the values would match.
+		witness := membershipCircuit{P: Proof{Siblings: tree.proof(200)}, Root: tree.root(), Key: 201, Value: tree.values[200]}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_key")
+	assert.Run(func(assert *test.Assert) {
+		witness := membershipCircuit{P: Proof{Siblings: tree.proof(5)}, Root: tree.root(), Key: 5, Value: 0}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "absent")
+}
+
+func TestNonMembership(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newNativeTree()
+	for _, k := range []uint64{3, 17, 200} {
+		tree.values[k] = randomValue(assert)
+	}
+	circuit := nonMembershipCircuit{P: Proof{Siblings: make([]frontend.Variable, testDepth)}}
+	assert.Run(func(assert *test.Assert) {
+		witness := nonMembershipCircuit{P: Proof{Siblings: tree.proof(16)}, Root: tree.root(), Key: 16}
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+	}, "valid")
+	assert.Run(func(assert *test.Assert) {
+		witness := nonMembershipCircuit{P: Proof{Siblings: tree.proof(17)}, Root: tree.root(), Key: 17}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "present")
+	assert.Run(func(assert *test.Assert) {
+		witness := nonMembershipCircuit{P: Proof{Siblings: tree.proof(16)}, Root: tree.root(), Key: 16 + (1 << testDepth)}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "key_overflow")
+}
+
+func TestUpdate(t *testing.T) {
+	assert := test.NewAssert(t)
+	tree := newNativeTree()
+	for _, k := range []uint64{3, 17, 200} {
+		tree.values[k] = randomValue(assert)
+	}
+	circuit := updateCircuit{P: Proof{Siblings: make([]frontend.Variable, testDepth)}}
+	update := func(key uint64, newValue *big.Int) updateCircuit {
+		oldValue, ok := tree.values[key]
+		if !ok {
+			oldValue = new(big.Int)
+		}
+		witness := updateCircuit{P: Proof{Siblings: tree.proof(key)}, OldRoot: tree.root(), Key: key, OldValue: oldValue, NewValue: newValue}
+		tree.values[key] = newValue
+		witness.NewRoot = tree.root()
+		return witness
+	}
+	assert.Run(func(assert *test.Assert) {
+		witness := update(17, randomValue(assert))
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+	}, "modify")
+	assert.Run(func(assert *test.Assert) {
+		witness := update(42, randomValue(assert))
+		assert.NoError(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+	}, "insert")
+	assert.Run(func(assert *test.Assert) {
+		witness := update(3, new(big.Int))
+		assert.NoError(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+	}, "delete")
+	assert.Run(func(assert *test.Assert) {
+		witness := update(200, randomValue(assert))
+		witness.OldValue = 1
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_old_value")
+	assert.Run(func(assert *test.Assert) {
+		witness := update(200, randomValue(assert))
+		witness.NewValue = 1
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "wrong_new_value")
+}
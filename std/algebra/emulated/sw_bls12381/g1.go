@@ -27,7 +27,10 @@ func NewG1Affine(v bls12381.G1Affine) G1Affine {
 	}
 }
 
+type baseEl = emulated.Element[BaseField]
+
 type G1 struct {
+	api    frontend.API
 	curveF *emulated.Field[BaseField]
 	w      *emulated.Element[BaseField]
 }
@@ -39,6 +42,7 @@ func NewG1(api frontend.API) (*G1, error) {
 	}
 	w := emulated.ValueOf[BaseField]("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939436")
 	return &G1{
+		api:    api,
 		curveF: ba,
 		w:      &w,
 	}, nil
@@ -142,6 +146,75 @@ func (g1 G1) doubleAndAdd(p, q *G1Affine) *G1Affine {
 	}
 }
 
+// addUnified adds p and q and returns it. p can be equal to q, and either or
+// both can be (0,0). See [G2.AddUnified].
+func (g1 *G1) addUnified(p, q *G1Affine) *G1Affine {
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := g1.api.And(g1.curveF.IsZero(&p.X), g1.curveF.IsZero(&p.Y))
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := g1.api.And(g1.curveF.IsZero(&q.X), g1.curveF.IsZero(&q.Y))
+
+	// λ = ((p.x+q.x)² - p.x*q.x)/(p.y + q.y)
+	pxqx := g1.curveF.Mul(&p.X, &q.X)
+	pxplusqx := g1.curveF.Add(&p.X, &q.X)
+	num := g1.curveF.Mul(pxplusqx, pxplusqx)
+	num = g1.curveF.Sub(num, pxqx)
+	denum := g1.curveF.Add(&p.Y, &q.Y)
+	// if p.y + q.y = 0, use λ = (q.y - p.y)/(q.x - p.x) instead
+	selector3 := g1.curveF.IsZero(denum)
+	num = g1.curveF.Select(selector3, g1.curveF.Sub(&q.Y, &p.Y), num)
+	denum = g1.curveF.Select(selector3, g1.curveF.Sub(&q.X, &p.X), denum)
+	// selector4 = 1 when p = -q and 0 otherwise. Then both denominators are
+	// zero, so we assign dummy 1 to denum and continue
+	selector4 := g1.api.And(selector3, g1.curveF.IsZero(denum))
+	denum = g1.curveF.Select(selector4, g1.curveF.One(), denum)
+	λ := g1.curveF.Div(num, denum)
+
+	// x = λ^2 - p.x - q.x
+	xr := g1.curveF.Mul(λ, λ)
+	xr = g1.curveF.Sub(xr, pxplusqx)
+
+	// y = λ(p.x - xr) - p.y
+	yr := g1.curveF.Sub(&p.X, xr)
+	yr = g1.curveF.Mul(yr, λ)
+	yr = g1.curveF.Sub(yr, &p.Y)
+	result := &G1Affine{X: *xr, Y: *yr}
+
+	zero := g1.curveF.Zero()
+	// if p=(0,0) return q
+	result = &G1Affine{X: *g1.curveF.Select(selector1, &q.X, &result.X), Y: *g1.curveF.Select(selector1, &q.Y, &result.Y)}
+	// if q=(0,0) return p
+	result = &G1Affine{X: *g1.curveF.Select(selector2, &p.X, &result.X), Y: *g1.curveF.Select(selector2, &p.Y, &result.Y)}
+	// if p = -q, return (0, 0)
+	result = &G1Affine{X: *g1.curveF.Select(selector4, zero, &result.X), Y: *g1.curveF.Select(selector4, zero, &result.Y)}
+
+	return result
+}
+
+func (g1 G1) neg(p *G1Affine) *G1Affine {
+	return &G1Affine{
+		X: p.X,
+		Y: *g1.curveF.Neg(&p.Y),
+	}
+}
+
+// scalarMulBySeed computes [x₀]q where x₀ is the (negative) curve seed.
+func (g1 *G1) scalarMulBySeed(q *G1Affine) *G1Affine {
+	z := g1.double(q)
+	z = g1.add(q, z)
+	z = g1.double(z)
+	z = g1.doubleAndAdd(z, q)
+	z = g1.doubleN(z, 2)
+	z = g1.doubleAndAdd(z, q)
+	z = g1.doubleN(z, 8)
+	z = g1.doubleAndAdd(z, q)
+	z = g1.doubleN(z, 31)
+	z = g1.doubleAndAdd(z, q)
+	z = g1.doubleN(z, 16)
+
+	return g1.neg(z)
+}
+
 func (g1 *G1) scalarMulBySeedSquare(q *G1Affine) *G1Affine {
 	z := g1.double(q)
 	z = g1.add(q, z)
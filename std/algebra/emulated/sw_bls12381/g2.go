@@ -10,6 +10,8 @@ import (
 )
 
 type G2 struct {
+	api frontend.API
+	fp  *emulated.Field[BaseField]
 	*fields_bls12381.Ext2
 	u1, w *emulated.Element[BaseField]
 	v     *fields_bls12381.E2
@@ -39,6 +41,10 @@ func newG2AffP(v bls12381.G2Affine) g2AffP {
 }
 
 func NewG2(api frontend.API) *G2 {
+	fp, err := emulated.NewField[BaseField](api)
+	if err != nil {
+		panic(err)
+	}
 	w := emulated.ValueOf[BaseField]("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939436")
 	u1 := emulated.ValueOf[BaseField]("4002409555221667392624310435006688643935503118305586438271171395842971157480381377015405980053539358417135540939437")
 	v := fields_bls12381.E2{
@@ -46,6 +52,8 @@ func NewG2(api frontend.API) *G2 {
 		A1: emulated.ValueOf[BaseField]("1028732146235106349975324479215795277384839936929757896155643118032610843298655225875571310552543014690878354869257"),
 	}
 	return &G2{
+		api:  api,
+		fp:   fp,
 		Ext2: fields_bls12381.NewExt2(api),
 		w:    &w,
 		u1:   &u1,
@@ -265,3 +273,111 @@ func (g2 *G2) AssertIsEqual(p, q *G2Affine) {
 	g2.Ext2.AssertIsEqual(&p.P.X, &q.P.X)
 	g2.Ext2.AssertIsEqual(&p.P.Y, &q.P.Y)
 }
+
+// AssertIsInRange asserts that the coordinates of p are less than the base
+// field modulus. The coordinates must have zero overflow.
+func (g2 *G2) AssertIsInRange(p *G2Affine) {
+	g2.fp.AssertIsInRange(&p.P.X.A0)
+	g2.fp.AssertIsInRange(&p.P.X.A1)
+	g2.fp.AssertIsInRange(&p.P.Y.A0)
+	g2.fp.AssertIsInRange(&p.P.Y.A1)
+}
+
+// Neg returns the negation of p.
+func (g2 *G2) Neg(p *G2Affine) *G2Affine {
+	return g2.neg(p)
+}
+
+// Select returns p if b=1 and q if b=0.
+func (g2 *G2) Select(b frontend.Variable, p, q *G2Affine) *G2Affine {
+	return &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.Select(b, &p.P.X, &q.P.X),
+			Y: *g2.Ext2.Select(b, &p.P.Y, &q.P.Y),
+		},
+	}
+}
+
+// IsInfinity returns 1 if p is the point at infinity, represented as (0,0),
+// and 0 otherwise.
+func (g2 *G2) IsInfinity(p *G2Affine) frontend.Variable {
+	return g2.api.And(g2.Ext2.IsZero(&p.P.X), g2.Ext2.IsZero(&p.P.Y))
+}
+
+// AddUnified adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p can be equal to q, and either or both can be (0,0).
+//
+// It uses the unified formulas of Brier and Joye ([[BriJoy02]] (Corollary 1)).
+// The formula is undefined when p.y = -q.y. Apart from p = -q, this happens
+// for p.x ≠ q.x (e.g. q = -φ(p) = (ω⋅p.x, -p.y)), in which case we use the
+// chord slope instead.
+//
+// [BriJoy02]: https://link.springer.com/content/pdf/10.1007/3-540-45664-3_24.pdf
+func (g2 *G2) AddUnified(p, q *G2Affine) *G2Affine {
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := g2.IsInfinity(p)
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := g2.IsInfinity(q)
+
+	// λ = ((p.x+q.x)² - p.x*q.x)/(p.y + q.y)
+	pxqx := g2.Ext2.Mul(&p.P.X, &q.P.X)
+	pxplusqx := g2.Ext2.Add(&p.P.X, &q.P.X)
+	num := g2.Ext2.Square(pxplusqx)
+	num = g2.Ext2.Sub(num, pxqx)
+	denum := g2.Ext2.Add(&p.P.Y, &q.P.Y)
+	// if p.y + q.y = 0, use λ = (q.y - p.y)/(q.x - p.x) instead
+	selector3 := g2.Ext2.IsZero(denum)
+	num = g2.Ext2.Select(selector3, g2.Ext2.Sub(&q.P.Y, &p.P.Y), num)
+	denum = g2.Ext2.Select(selector3, g2.Ext2.Sub(&q.P.X, &p.P.X), denum)
+	// selector4 = 1 when p = -q and 0 otherwise. Then both denominators are
+	// zero, so we assign dummy 1 to denum and continue
+	selector4 := g2.api.And(selector3, g2.Ext2.IsZero(denum))
+	denum = g2.Ext2.Select(selector4, g2.Ext2.One(), denum)
+	λ := g2.Ext2.DivUnchecked(num, denum)
+
+	// x = λ^2 - p.x - q.x
+	xr := g2.Ext2.Square(λ)
+	xr = g2.Ext2.Sub(xr, pxplusqx)
+
+	// y = λ(p.x - xr) - p.y
+	yr := g2.Ext2.Sub(&p.P.X, xr)
+	yr = g2.Ext2.Mul(yr, λ)
+	yr = g2.Ext2.Sub(yr, &p.P.Y)
+	result := &G2Affine{
+		P: g2AffP{X: *xr, Y: *yr},
+	}
+
+	infinity := &G2Affine{
+		P: g2AffP{X: *g2.Ext2.Zero(), Y: *g2.Ext2.Zero()},
+	}
+	// if p=(0,0) return q
+	result = g2.Select(selector1, q, result)
+	// if q=(0,0) return p
+	result = g2.Select(selector2, p, result)
+	// if p = -q, return (0, 0)
+	result = g2.Select(selector4, infinity, result)
+
+	return result
+}
+
+// ScalarMul computes [s]p using a double-and-add algorithm with complete
+// formulas. p can be (0,0) and s can be zero.
+//
+// ⚠️  The scalar is reduced modulo r, so the result is correct only when p is
+// in G2. See [Pairing.AssertIsOnG2].
+func (g2 *G2) ScalarMul(p *G2Affine, s *Scalar) *G2Affine {
+	fr, err := emulated.NewField[ScalarField](g2.api)
+	if err != nil {
+		panic(err)
+	}
+	sBits := fr.ToBits(fr.Reduce(s))
+	res := &G2Affine{
+		P: g2AffP{X: *g2.Ext2.Zero(), Y: *g2.Ext2.Zero()},
+	}
+	for i := len(sBits) - 1; i >= 0; i-- {
+		res = g2.AddUnified(res, res)
+		res = g2.Select(sBits[i], g2.AddUnified(res, p), res)
+	}
+	return res
+}
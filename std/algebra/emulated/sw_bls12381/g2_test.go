@@ -6,6 +6,8 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	fp_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	fr_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/test"
 )
@@ -118,3 +120,78 @@ func TestScalarMulG2BySeedTestSolve(t *testing.T) {
 	err := test.IsSolved(&scalarMulG2BySeedCircuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type addUnifiedG2Circuit struct {
+	In1, In2 G2Affine
+	Res      G2Affine
+}
+
+func (c *addUnifiedG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.AddUnified(&c.In1, &c.In2)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestAddUnifiedG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in1 := randomG1G2Affines()
+	_, in2 := randomG1G2Affines()
+	var res, neg, zero bls12381.G2Affine
+	res.Add(&in1, &in2)
+	neg.Neg(&in1)
+	// p + q
+	err := test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(in2), Res: NewG2Affine(res)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// p + p
+	res.Double(&in1)
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(in1), Res: NewG2Affine(res)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// p + (-p)
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(neg), Res: NewG2Affine(zero)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// 0 + q
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(zero), In2: NewG2Affine(in2), Res: NewG2Affine(in2)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// p + (-φ(p)), where p.y + q.y = 0 but p ≠ -q
+	var omega fp_bls12381.Element
+	omega.SetUint64(2)
+	omega.Exp(omega, new(big.Int).Div(new(big.Int).Sub(fp_bls12381.Modulus(), big.NewInt(1)), big.NewInt(3)))
+	assert.False(omega.IsOne())
+	var negPhi bls12381.G2Affine
+	negPhi.X.MulByElement(&in1.X, &omega)
+	negPhi.Y.Neg(&in1.Y)
+	assert.True(negPhi.IsOnCurve())
+	res.Add(&in1, &negPhi)
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(negPhi), Res: NewG2Affine(res)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type scalarMulG2Circuit struct {
+	In  G2Affine
+	S   Scalar
+	Res G2Affine
+}
+
+func (c *scalarMulG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.ScalarMul(&c.In, &c.S)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestScalarMulG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in := randomG1G2Affines()
+	var s fr_bls12381.Element
+	s.SetRandom()
+	var res bls12381.G2Affine
+	res.ScalarMultiplication(&in, s.BigInt(new(big.Int)))
+	witness := scalarMulG2Circuit{
+		In:  NewG2Affine(in),
+		S:   NewScalar(s),
+		Res: NewG2Affine(res),
+	}
+	err := test.IsSolved(&scalarMulG2Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,47 @@
+package sw_bls12381
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/hash/tofield"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// HashToG1 hashes the message msg with the domain separation tag dst to G1
+// using the suite BLS12381G1_XMD:SHA-256_SSWU_RO_ of [RFC 9380] section 8.8.1.
+// The result corresponds to [bls12381.HashToG1].
+//
+// The two mapped points are added with complete formulas, as they can be equal,
+// opposite or the point at infinity.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g1 *G1) HashToG1(msg []uints.U8, dst []byte) (*G1Affine, error) {
+	u, err := tofield.HashToField[BaseField](g1.api, msg, dst, 2)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0 := g1.isogeny(g1.MapToCurve1(u[0]))
+	q1 := g1.isogeny(g1.MapToCurve1(u[1]))
+	return g1.ClearCofactor(g1.addUnified(q0, q1)), nil
+}
+
+// HashToG2 hashes the message msg with the domain separation tag dst to G2
+// using the suite BLS12381G2_XMD:SHA-256_SSWU_RO_ of [RFC 9380] section 8.8.2.
+// The result corresponds to [bls12381.HashToG2].
+//
+// The two mapped points are added with complete formulas, as they can be equal,
+// opposite or the point at infinity.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) HashToG2(msg []uints.U8, dst []byte) (*G2Affine, error) {
+	u, err := tofield.HashToField[BaseField](g2.api, msg, dst, 4)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	u0 := &fields_bls12381.E2{A0: *u[0], A1: *u[1]}
+	u1 := &fields_bls12381.E2{A0: *u[2], A1: *u[3]}
+	q0 := g2.isogeny(g2.MapToCurve2(u0))
+	q1 := g2.isogeny(g2.MapToCurve2(u1))
+	return g2.ClearCofactor(g2.AddUnified(q0, q1)), nil
+}
@@ -0,0 +1,66 @@
+package sw_bls12381
+
+import (
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all the hints used in this package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{sqrtRatioG1Hint, sqrtRatioG2Hint}
+}
+
+// sqrtRatioG1Hint returns a square root of x if it is a quadratic residue and
+// a square root of Z⋅x otherwise, where Z is the SSWU constant for G1.
+func sqrtRatioG1Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(mod *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 {
+			return fmt.Errorf("expecting one input")
+		}
+		if len(outputs) != 1 {
+			return fmt.Errorf("expecting one output")
+		}
+		var x fp.Element
+		x.SetBigInt(inputs[0])
+		if x.Legendre() == -1 {
+			x.Mul(&x, &sswuZG1)
+		}
+		if x.Sqrt(&x) == nil {
+			return fmt.Errorf("no square root")
+		}
+		x.BigInt(outputs[0])
+		return nil
+	})
+}
+
+// sqrtRatioG2Hint returns a square root of x if it is a quadratic residue and
+// a square root of Z⋅x otherwise, where Z is the SSWU constant for G2.
+func sqrtRatioG2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(mod *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 2 {
+			return fmt.Errorf("expecting two inputs")
+		}
+		if len(outputs) != 2 {
+			return fmt.Errorf("expecting two outputs")
+		}
+		var x bls12381.E2
+		x.A0.SetBigInt(inputs[0])
+		x.A1.SetBigInt(inputs[1])
+		if x.Legendre() == -1 {
+			x.Mul(&x, &sswuZG2)
+		}
+		x.Sqrt(&x)
+		x.A0.BigInt(outputs[0])
+		x.A1.BigInt(outputs[1])
+		return nil
+	})
+}
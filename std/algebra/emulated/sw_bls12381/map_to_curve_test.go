@@ -0,0 +1,219 @@
+package sw_bls12381
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToG1Circuit struct {
+	U   emulated.Element[BaseField]
+	Res G1Affine
+}
+
+func (c *mapToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return err
+	}
+	res := g1.MapToG1(&c.U)
+	g1.curveF.AssertIsEqual(&res.X, &c.Res.X)
+	g1.curveF.AssertIsEqual(&res.Y, &c.Res.Y)
+	return nil
+}
+
+func TestMapToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for i := 0; i < 4; i++ {
+		var u fp.Element
+		u.SetRandom()
+		res := bls12381.MapToG1(u)
+		witness := mapToG1Circuit{
+			U:   emulated.ValueOf[BaseField](u),
+			Res: NewG1Affine(res),
+		}
+		err := test.IsSolved(&mapToG1Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type mapToG2Circuit struct {
+	U   fields_bls12381.E2
+	Res G2Affine
+}
+
+func (c *mapToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.MapToG2(&c.U)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMapToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for i := 0; i < 4; i++ {
+		var u bls12381.E2
+		u.SetRandom()
+		res := bls12381.MapToG2(u)
+		witness := mapToG2Circuit{
+			U:   fields_bls12381.FromE2(&u),
+			Res: NewG2Affine(res),
+		}
+		err := test.IsSolved(&mapToG2Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type hashToG1Circuit struct {
+	Msg []uints.U8
+	Res G1Affine
+
+	dst []byte
+}
+
+func (c *hashToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return err
+	}
+	res, err := g1.HashToG1(c.Msg, c.dst)
+	if err != nil {
+		return err
+	}
+	g1.curveF.AssertIsEqual(&res.X, &c.Res.X)
+	g1.curveF.AssertIsEqual(&res.Y, &c.Res.Y)
+	return nil
+}
+
+func TestHashToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	for _, msg := range []string{"", "abc"} {
+		res, err := bls12381.HashToG1([]byte(msg), dst)
+		assert.NoError(err)
+		witness := hashToG1Circuit{
+			Msg: uints.NewU8Array([]byte(msg)),
+			Res: NewG1Affine(res),
+		}
+		err = test.IsSolved(&hashToG1Circuit{Msg: make([]uints.U8, len(msg)), dst: dst}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type hashToG2Circuit struct {
+	Msg []uints.U8
+	Res G2Affine
+
+	dst []byte
+}
+
+func (c *hashToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res, err := g2.HashToG2(c.Msg, c.dst)
+	if err != nil {
+		return err
+	}
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestHashToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	for _, msg := range []string{"", "abc"} {
+		res, err := bls12381.HashToG2([]byte(msg), dst)
+		assert.NoError(err)
+		witness := hashToG2Circuit{
+			Msg: uints.NewU8Array([]byte(msg)),
+			Res: NewG2Affine(res),
+		}
+		err = test.IsSolved(&hashToG2Circuit{Msg: make([]uints.U8, len(msg)), dst: dst}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type clearCofactorG1Circuit struct {
+	In, Res G1Affine
+}
+
+func (c *clearCofactorG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return err
+	}
+	res := g1.ClearCofactor(&c.In)
+	g1.curveF.AssertIsEqual(&res.X, &c.Res.X)
+	g1.curveF.AssertIsEqual(&res.Y, &c.Res.Y)
+	return nil
+}
+
+func TestClearCofactorG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	// a random point of the curve, not in G1
+	var p bls12381.G1Affine
+	for {
+		p.X.SetRandom()
+		var y2 fp.Element
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, new(fp.Element).SetUint64(4))
+		if p.Y.Sqrt(&y2) != nil {
+			break
+		}
+	}
+	// the points (0,±2) of order 3 and the point at infinity are mapped to the
+	// point at infinity.
+	var order3 bls12381.G1Affine
+	order3.Y.SetUint64(2)
+	for _, in := range []bls12381.G1Affine{p, order3, {}} {
+		var res bls12381.G1Affine
+		res.ClearCofactor(&in)
+		witness := clearCofactorG1Circuit{In: NewG1Affine(in), Res: NewG1Affine(res)}
+		err := test.IsSolved(&clearCofactorG1Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type clearCofactorG2Circuit struct {
+	In, Res G2Affine
+}
+
+func (c *clearCofactorG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.ClearCofactor(&c.In)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestClearCofactorG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	// a random point of the twist, not in G2
+	var p bls12381.G2Affine
+	_, _, _, g2 := bls12381.Generators()
+	b := new(bls12381.E2)
+	b.Square(&g2.Y)
+	var x3 bls12381.E2
+	x3.Square(&g2.X).Mul(&x3, &g2.X)
+	b.Sub(b, &x3)
+	for {
+		p.X.SetRandom()
+		var y2 bls12381.E2
+		y2.Square(&p.X).Mul(&y2, &p.X).Add(&y2, b)
+		if y2.Legendre() == 1 {
+			p.Y.Sqrt(&y2)
+			break
+		}
+	}
+	for _, in := range []bls12381.G2Affine{p, {}} {
+		var res bls12381.G2Affine
+		res.ClearCofactor(&in)
+		witness := clearCofactorG2Circuit{In: NewG2Affine(in), Res: NewG2Affine(res)}
+		err := test.IsSolved(&clearCofactorG2Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
@@ -0,0 +1,215 @@
+package sw_bls12381
+
+import (
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+)
+
+// Constants for the simplified SWU map onto the curve E': y² = x³ + A'x + B'
+// which is 3-isogenous to the BLS12-381 twist, see [RFC 9380] section 8.8.2.
+// Here A' = 240⋅u, B' = 1012⋅(1+u) and Z = -(2+u).
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+var (
+	sswuA2 = [2]string{"0", "240"}
+	sswuB2 = [2]string{"1012", "1012"}
+)
+
+// sswuZG2 is the non-square Z used in the simplified SWU map for G2.
+var sswuZG2 = func() (z bls12381.E2) {
+	z.A0.SetInt64(-2)
+	z.A1.SetInt64(-1)
+	return
+}()
+
+// Coefficients of the 3-isogeny map from E' to the twist, see [RFC 9380]
+// appendix E.3. The coefficients are given in increasing degree and the leading
+// coefficient of the denominators is 1 and omitted.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+var isoG2XNum = [][2]string{
+	{"889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235542"},
+	{"0", "2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706522"},
+	{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706526", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853261"},
+	{"3557697382419259905260257622876359250272784728834673675850718343221361467102966990615722337003569479144794908942033", "0"},
+}
+
+var isoG2XDen = [][2]string{
+	{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559715"},
+	{"12", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559775"},
+}
+
+var isoG2YNum = [][2]string{
+	{"3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558", "3261222600550988246488569487636662646083386001431784202863158481286248011511053074731078808919938689216061999863558"},
+	{"0", "889424345604814976315064405719089812568196182208668418962679585805340366775741747653930584250892369786198727235518"},
+	{"2668273036814444928945193217157269437704588546626005256888038757416021100327225242961791752752677109358596181706524", "1334136518407222464472596608578634718852294273313002628444019378708010550163612621480895876376338554679298090853263"},
+	{"2816510427748580758331037284777117739799287910327449993381818688383577828123182200904113516794492504322962636245776", "0"},
+}
+
+var isoG2YDen = [][2]string{
+	{"4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559355"},
+	{"0", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559571"},
+	{"18", "4002409555221667393417789825735904156556882819939007885332058136124031650490837864442687629129015664037894272559769"},
+}
+
+func (g2 *G2) constant(c [2]string) *fields_bls12381.E2 {
+	return &fields_bls12381.E2{
+		A0: *g2.fp.NewElement(c[0]),
+		A1: *g2.fp.NewElement(c[1]),
+	}
+}
+
+// evalPolynomial evaluates the polynomial with the given constant coefficients
+// at x using Horner's rule. If monic is set, then the polynomial has an
+// implicit leading coefficient 1.
+func (g2 *G2) evalPolynomial(monic bool, coefficients [][2]string, x *fields_bls12381.E2) *fields_bls12381.E2 {
+	dst := g2.constant(coefficients[len(coefficients)-1])
+	if monic {
+		dst = g2.Ext2.Add(dst, x)
+	}
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		dst = g2.Ext2.Mul(dst, x)
+		dst = g2.Ext2.Add(dst, g2.constant(coefficients[i]))
+	}
+	return dst
+}
+
+// sgn0 returns the sign of x as defined in [RFC 9380] section 4.1, i.e.
+// sgn0(x) = sgn0(x.A0) OR (x.A0 == 0 AND sgn0(x.A1)).
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) sgn0(x *fields_bls12381.E2) frontend.Variable {
+	a0 := g2.fp.Reduce(&x.A0)
+	g2.fp.AssertIsInRange(a0)
+	a1 := g2.fp.Reduce(&x.A1)
+	g2.fp.AssertIsInRange(a1)
+	sign0 := g2.fp.ToBits(a0)[0]
+	sign1 := g2.fp.ToBits(a1)[0]
+	zero0 := g2.fp.IsZero(a0)
+	return g2.api.Or(sign0, g2.api.And(zero0, sign1))
+}
+
+// MapToCurve2 implements the simplified SWU map of [RFC 9380] section 6.6.2
+// onto the curve E' which is 3-isogenous to the BLS12-381 twist. The returned
+// point is on E' and not on the twist.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) MapToCurve2(u *fields_bls12381.E2) *G2Affine {
+	a := g2.constant(sswuA2)
+	b := g2.constant(sswuB2)
+	z := &fields_bls12381.E2{
+		A0: *g2.fp.NewElement(sswuZG2.A0.String()),
+		A1: *g2.fp.NewElement(sswuZG2.A1.String()),
+	}
+
+	// tv1 = Z⋅u²
+	tv1 := g2.Ext2.Square(u)
+	tv1 = g2.Ext2.Mul(tv1, z)
+	// tv2 = tv1² + tv1
+	tv2 := g2.Ext2.Square(tv1)
+	tv2 = g2.Ext2.Add(tv2, tv1)
+	// x1 = B⋅(tv2 + 1) / (A⋅CMOV(Z, -tv2, tv2 != 0))
+	tv3 := g2.Ext2.Add(tv2, g2.Ext2.One())
+	tv3 = g2.Ext2.Mul(tv3, b)
+	tv4 := g2.Ext2.Select(g2.Ext2.IsZero(tv2), z, g2.Ext2.Neg(tv2))
+	tv4 = g2.Ext2.Mul(tv4, a)
+	x1 := g2.Ext2.DivUnchecked(tv3, tv4)
+	// gx1 = x1³ + A⋅x1 + B
+	gx1 := g2.Ext2.Square(x1)
+	gx1 = g2.Ext2.Add(gx1, a)
+	gx1 = g2.Ext2.Mul(gx1, x1)
+	gx1 = g2.Ext2.Add(gx1, b)
+
+	// the hint returns either √gx1 or √(Z⋅gx1). As Z is a non-square, then
+	// only one of them exists (except when gx1 = 0).
+	res, err := g2.fp.NewHint(sqrtRatioG2Hint, 2, &gx1.A0, &gx1.A1)
+	if err != nil {
+		panic(err)
+	}
+	y1 := &fields_bls12381.E2{A0: *res[0], A1: *res[1]}
+	y1y1 := g2.Ext2.Square(y1)
+	isSquare := g2.Ext2.IsZero(g2.Ext2.Sub(y1y1, gx1))
+	g2.Ext2.AssertIsEqual(y1y1, g2.Ext2.Select(isSquare, gx1, g2.Ext2.Mul(z, gx1)))
+
+	// if gx1 is not a square, then x2 = tv1⋅x1 and y2 = tv1⋅u⋅y1, as
+	// g(x2) = (Z⋅u²)³⋅g(x1)
+	x := g2.Ext2.Select(isSquare, x1, g2.Ext2.Mul(tv1, x1))
+	y := g2.Ext2.Select(isSquare, y1, g2.Ext2.Mul(g2.Ext2.Mul(tv1, u), y1))
+
+	// fix the sign of y so that sgn0(u) == sgn0(y)
+	e1 := g2.api.Xor(g2.sgn0(u), g2.sgn0(y))
+	y = g2.Ext2.Select(e1, g2.Ext2.Neg(y), y)
+
+	return &G2Affine{P: g2AffP{X: *x, Y: *y}}
+}
+
+// isogeny maps a point on E' to the twist using the 3-isogeny map.
+func (g2 *G2) isogeny(p *G2Affine) *G2Affine {
+	xn := g2.evalPolynomial(false, isoG2XNum, &p.P.X)
+	xd := g2.evalPolynomial(true, isoG2XDen, &p.P.X)
+	yn := g2.evalPolynomial(false, isoG2YNum, &p.P.X)
+	yd := g2.evalPolynomial(true, isoG2YDen, &p.P.X)
+	// the denominators vanish on the kernel of the isogeny, which is mapped
+	// to the point at infinity (0,0).
+	isKernel := g2.Ext2.IsZero(xd)
+	xd = g2.Ext2.Select(isKernel, g2.Ext2.One(), xd)
+	yd = g2.Ext2.Select(isKernel, g2.Ext2.One(), yd)
+	x := g2.Ext2.DivUnchecked(xn, xd)
+	y := g2.Ext2.Mul(&p.P.Y, g2.Ext2.DivUnchecked(yn, yd))
+	x = g2.Ext2.Select(isKernel, g2.Ext2.Zero(), x)
+	y = g2.Ext2.Select(isKernel, g2.Ext2.Zero(), y)
+	return &G2Affine{P: g2AffP{X: *x, Y: *y}}
+}
+
+// ClearCofactor maps a point on the twist to G2 by multiplying it by the
+// effective cofactor h_eff using the endomorphism ψ as in [RFC 9380] appendix
+// G.3:
+//
+//	h_eff⋅P = [x₀²-x₀-1]P + [x₀-1]ψ(P) + ψ²(2P)
+//
+// The point can be of any order, so the method uses complete formulas. p can
+// be (0,0).
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) ClearCofactor(p *G2Affine) *G2Affine {
+	t1 := g2.scalarMulBySeedUnified(p)
+	t2 := g2.psi(p)
+	t3 := g2.AddUnified(p, p)
+	t3 = g2.psi(g2.psi(t3))
+	t3 = g2.AddUnified(t3, g2.neg(t2))
+	t2 = g2.AddUnified(t1, t2)
+	t2 = g2.scalarMulBySeedUnified(t2)
+	t3 = g2.AddUnified(t3, t2)
+	t3 = g2.AddUnified(t3, g2.neg(t1))
+	return g2.AddUnified(t3, g2.neg(p))
+}
+
+// seedAbs is the absolute value of the (negative) curve seed x₀.
+var seedAbs, _ = new(big.Int).SetString("d201000000010000", 16)
+
+// scalarMulBySeedUnified computes [x₀]q with complete formulas. q can be of any
+// order and can be (0,0).
+func (g2 *G2) scalarMulBySeedUnified(q *G2Affine) *G2Affine {
+	res := q
+	for i := seedAbs.BitLen() - 2; i >= 0; i-- {
+		res = g2.AddUnified(res, res)
+		if seedAbs.Bit(i) == 1 {
+			res = g2.AddUnified(res, q)
+		}
+	}
+	return g2.neg(res)
+}
+
+// MapToG2 maps the element u of the quadratic extension to G2 as defined in
+// [RFC 9380] section 6.6.3, i.e. it applies the simplified SWU map, the
+// 3-isogeny and clears the cofactor.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) MapToG2(u *fields_bls12381.E2) *G2Affine {
+	p := g2.MapToCurve2(u)
+	p = g2.isogeny(p)
+	return g2.ClearCofactor(p)
+}
@@ -10,6 +10,8 @@ import (
 )
 
 type G2 struct {
+	api frontend.API
+	fp  *emulated.Field[BaseField]
 	*fields_bn254.Ext2
 	w    *emulated.Element[BaseField]
 	u, v *fields_bn254.E2
@@ -39,6 +41,10 @@ func newG2AffP(v bn254.G2Affine) g2AffP {
 }
 
 func NewG2(api frontend.API) *G2 {
+	fp, err := emulated.NewField[BaseField](api)
+	if err != nil {
+		panic(err)
+	}
 	w := emulated.ValueOf[BaseField]("21888242871839275220042445260109153167277707414472061641714758635765020556616")
 	u := fields_bn254.E2{
 		A0: emulated.ValueOf[BaseField]("21575463638280843010398324269430826099269044274347216827212613867836435027261"),
@@ -49,6 +55,8 @@ func NewG2(api frontend.API) *G2 {
 		A1: emulated.ValueOf[BaseField]("3505843767911556378687030309984248845540243509899259641013678093033130930403"),
 	}
 	return &G2{
+		api:  api,
+		fp:   fp,
 		Ext2: fields_bn254.NewExt2(api),
 		w:    &w,
 		u:    &u,
@@ -262,3 +270,81 @@ func (g2 *G2) AssertIsEqual(p, q *G2Affine) {
 	g2.Ext2.AssertIsEqual(&p.P.X, &q.P.X)
 	g2.Ext2.AssertIsEqual(&p.P.Y, &q.P.Y)
 }
+
+// Neg returns the negation of p.
+func (g2 *G2) Neg(p *G2Affine) *G2Affine {
+	return g2.neg(p)
+}
+
+// Select returns p if b=1 and q if b=0.
+func (g2 *G2) Select(b frontend.Variable, p, q *G2Affine) *G2Affine {
+	return &G2Affine{
+		P: g2AffP{
+			X: *g2.Ext2.Select(b, &p.P.X, &q.P.X),
+			Y: *g2.Ext2.Select(b, &p.P.Y, &q.P.Y),
+		},
+	}
+}
+
+// IsInfinity returns 1 if p is the point at infinity, represented as (0,0),
+// and 0 otherwise.
+func (g2 *G2) IsInfinity(p *G2Affine) frontend.Variable {
+	return g2.api.And(g2.Ext2.IsZero(&p.P.X), g2.Ext2.IsZero(&p.P.Y))
+}
+
+// AddUnified adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p can be equal to q, and either or both can be (0,0).
+//
+// It uses the unified formulas of Brier and Joye ([[BriJoy02]] (Corollary 1)).
+// The formula is undefined when p.y = -q.y. Apart from p = -q, this happens
+// for p.x ≠ q.x (e.g. q = -φ(p) = (ω⋅p.x, -p.y)), in which case we use the
+// chord slope instead.
+//
+// [BriJoy02]: https://link.springer.com/content/pdf/10.1007/3-540-45664-3_24.pdf
+func (g2 *G2) AddUnified(p, q *G2Affine) *G2Affine {
+	// selector1 = 1 when p is (0,0) and 0 otherwise
+	selector1 := g2.IsInfinity(p)
+	// selector2 = 1 when q is (0,0) and 0 otherwise
+	selector2 := g2.IsInfinity(q)
+
+	// λ = ((p.x+q.x)² - p.x*q.x)/(p.y + q.y)
+	pxqx := g2.Ext2.Mul(&p.P.X, &q.P.X)
+	pxplusqx := g2.Ext2.Add(&p.P.X, &q.P.X)
+	num := g2.Ext2.Square(pxplusqx)
+	num = g2.Ext2.Sub(num, pxqx)
+	denum := g2.Ext2.Add(&p.P.Y, &q.P.Y)
+	// if p.y + q.y = 0, use λ = (q.y - p.y)/(q.x - p.x) instead
+	selector3 := g2.Ext2.IsZero(denum)
+	num = g2.Ext2.Select(selector3, g2.Ext2.Sub(&q.P.Y, &p.P.Y), num)
+	denum = g2.Ext2.Select(selector3, g2.Ext2.Sub(&q.P.X, &p.P.X), denum)
+	// selector4 = 1 when p = -q and 0 otherwise. Then both denominators are
+	// zero, so we assign dummy 1 to denum and continue
+	selector4 := g2.api.And(selector3, g2.Ext2.IsZero(denum))
+	denum = g2.Ext2.Select(selector4, g2.Ext2.One(), denum)
+	λ := g2.Ext2.DivUnchecked(num, denum)
+
+	// x = λ^2 - p.x - q.x
+	xr := g2.Ext2.Square(λ)
+	xr = g2.Ext2.Sub(xr, pxplusqx)
+
+	// y = λ(p.x - xr) - p.y
+	yr := g2.Ext2.Sub(&p.P.X, xr)
+	yr = g2.Ext2.Mul(yr, λ)
+	yr = g2.Ext2.Sub(yr, &p.P.Y)
+	result := &G2Affine{
+		P: g2AffP{X: *xr, Y: *yr},
+	}
+
+	infinity := &G2Affine{
+		P: g2AffP{X: *g2.Ext2.Zero(), Y: *g2.Ext2.Zero()},
+	}
+	// if p=(0,0) return q
+	result = g2.Select(selector1, q, result)
+	// if q=(0,0) return p
+	result = g2.Select(selector2, p, result)
+	// if p = -q, return (0, 0)
+	result = g2.Select(selector4, infinity, result)
+
+	return result
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/consensys/gnark-crypto/ecc"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	fp_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/test"
 )
@@ -142,3 +143,51 @@ func TestEndomorphismG2TestSolve(t *testing.T) {
 	err := test.IsSolved(&endomorphismG2Circuit{}, &witness, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type addUnifiedG2Circuit struct {
+	In1, In2 G2Affine
+	Res      G2Affine
+}
+
+func (c *addUnifiedG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.AddUnified(&c.In1, &c.In2)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestAddUnifiedG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, in1 := randomG1G2Affines()
+	_, in2 := randomG1G2Affines()
+	var res, neg, zero bn254.G2Affine
+	res.Add(&in1, &in2)
+	neg.Neg(&in1)
+	// p + q
+	err := test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(in2), Res: NewG2Affine(res)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// p + p
+	res.Double(&in1)
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(in1), Res: NewG2Affine(res)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// p + (-p)
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(neg), Res: NewG2Affine(zero)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// 0 + q
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(zero), In2: NewG2Affine(in2), Res: NewG2Affine(in2)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	// p + (-φ(p)), where p.y + q.y = 0 but p ≠ -q
+	// ω = g^((p-1)/3) is a primitive cube root of unity for a non-cube g
+	var omega fp_bn254.Element
+	for g := uint64(2); omega.IsZero() || omega.IsOne(); g++ {
+		omega.SetUint64(g)
+		omega.Exp(omega, new(big.Int).Div(new(big.Int).Sub(fp_bn254.Modulus(), big.NewInt(1)), big.NewInt(3)))
+	}
+	var negPhi bn254.G2Affine
+	negPhi.X.MulByElement(&in1.X, &omega)
+	negPhi.Y.Neg(&in1.Y)
+	assert.True(negPhi.IsOnCurve())
+	res.Add(&in1, &negPhi)
+	err = test.IsSolved(&addUnifiedG2Circuit{}, &addUnifiedG2Circuit{In1: NewG2Affine(in1), In2: NewG2Affine(negPhi), Res: NewG2Affine(res)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,154 @@
+package sw_bn254
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/tofield"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+type baseEl = emulated.Element[BaseField]
+
+// constants of the Shallue-van de Woestijne map for BN254 as defined in
+// [RFC 9380] section 6.6.1 with Z = 1:
+//
+//	c1 = g(Z)
+//	c2 = -Z / 2
+//	c3 = sqrt(-g(Z) * 3 * Z²), sgn0(c3) = 0
+//	c4 = -4 * g(Z) / (3 * Z²)
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+const (
+	svdwZ  = "1"
+	svdwC1 = "4"
+	svdwC2 = "10944121435919637611123202872628637544348155578648911831344518947322613104291"
+	svdwC3 = "8815841940592487685674414971303048083897117035520822607866"
+	svdwC4 = "7296080957279758407415468581752425029565437052432607887563012631548408736189"
+)
+
+// G1 implements the hash-to-curve methods in G1. The group operations are
+// performed using [sw_emulated.Curve].
+type G1 struct {
+	api    frontend.API
+	curveF *emulated.Field[BaseField]
+	curve  *sw_emulated.Curve[BaseField, ScalarField]
+}
+
+// NewG1 returns a new [G1] instance.
+func NewG1(api frontend.API) (*G1, error) {
+	ba, err := emulated.NewField[BaseField](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	curve, err := sw_emulated.New[BaseField, ScalarField](api, sw_emulated.GetBN254Params())
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	return &G1{
+		api:    api,
+		curveF: ba,
+		curve:  curve,
+	}, nil
+}
+
+// sgn0 returns the parity of the canonical representation of x as defined in
+// [RFC 9380] section 4.1.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g1 *G1) sgn0(x *baseEl) frontend.Variable {
+	r := g1.curveF.Reduce(x)
+	g1.curveF.AssertIsInRange(r)
+	bits := g1.curveF.ToBits(r)
+	return bits[0]
+}
+
+// g returns x³ + 3.
+func (g1 *G1) g(x *baseEl) *baseEl {
+	gx := g1.curveF.Mul(x, x)
+	gx = g1.curveF.Mul(gx, x)
+	return g1.curveF.Add(gx, g1.curveF.NewElement(3))
+}
+
+// isSquare returns 1 if x is a quadratic residue (or zero) and 0 otherwise.
+func (g1 *G1) isSquare(x *baseEl) frontend.Variable {
+	res, err := g1.curveF.NewHint(sqrtOrNegSqrtHint, 1, x)
+	if err != nil {
+		panic(err)
+	}
+	rr := g1.curveF.Mul(res[0], res[0])
+	// r² = x or r² = -x
+	g1.curveF.AssertIsEqual(
+		g1.curveF.Mul(g1.curveF.Sub(rr, x), g1.curveF.Add(rr, x)),
+		g1.curveF.Zero(),
+	)
+	return g1.curveF.IsZero(g1.curveF.Sub(rr, x))
+}
+
+// MapToCurve1 implements the Shallue-van de Woestijne map of [RFC 9380]
+// section 6.6.1 onto BN254. The result corresponds to [bn254.MapToCurve1].
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g1 *G1) MapToCurve1(u *baseEl) *G1Affine {
+	one := g1.curveF.One()
+	c2 := g1.curveF.NewElement(svdwC2)
+	c3 := g1.curveF.NewElement(svdwC3)
+
+	// tv1 = u²⋅c1
+	tv1 := g1.curveF.Mul(u, u)
+	tv1 = g1.curveF.Mul(tv1, g1.curveF.NewElement(svdwC1))
+	// tv2 = 1 + tv1
+	tv2 := g1.curveF.Add(one, tv1)
+	// tv1 = 1 - tv1
+	tv1 = g1.curveF.Sub(one, tv1)
+	// tv3 = inv0(tv1⋅tv2)
+	tv3 := g1.curveF.Mul(tv1, tv2)
+	isZero := g1.curveF.IsZero(tv3)
+	tv3 = g1.curveF.Inverse(g1.curveF.Select(isZero, one, tv3))
+	tv3 = g1.curveF.Select(isZero, g1.curveF.Zero(), tv3)
+	// tv4 = u⋅tv1⋅tv3⋅c3
+	tv4 := g1.curveF.Mul(u, tv1)
+	tv4 = g1.curveF.Mul(tv4, tv3)
+	tv4 = g1.curveF.Mul(tv4, c3)
+	// x1 = c2 - tv4, x2 = c2 + tv4
+	x1 := g1.curveF.Sub(c2, tv4)
+	x2 := g1.curveF.Add(c2, tv4)
+	// x3 = (tv2²⋅tv3)²⋅c4 + Z
+	x3 := g1.curveF.Mul(tv2, tv2)
+	x3 = g1.curveF.Mul(x3, tv3)
+	x3 = g1.curveF.Mul(x3, x3)
+	x3 = g1.curveF.Mul(x3, g1.curveF.NewElement(svdwC4))
+	x3 = g1.curveF.Add(x3, g1.curveF.NewElement(svdwZ))
+
+	// e1 = is_square(g(x1)), e2 = is_square(g(x2)) AND NOT e1
+	e1 := g1.isSquare(g1.g(x1))
+	e2 := g1.api.And(g1.isSquare(g1.g(x2)), g1.api.Sub(1, e1))
+	x := g1.curveF.Select(e1, x1, g1.curveF.Select(e2, x2, x3))
+
+	// y = sqrt(g(x)), g(x) is a square by construction
+	y := g1.curveF.Sqrt(g1.g(x))
+
+	// fix the sign of y so that sgn0(u) == sgn0(y)
+	e3 := g1.api.Xor(g1.sgn0(u), g1.sgn0(y))
+	y = g1.curveF.Select(e3, g1.curveF.Neg(y), y)
+
+	return &G1Affine{X: *x, Y: *y}
+}
+
+// HashToG1 hashes the message msg with the domain separation tag dst to G1
+// using the suite BN254G1_XMD:SHA-256_SVDW_RO_ of [RFC 9380]. The result
+// corresponds to [bn254.HashToG1].
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g1 *G1) HashToG1(msg []uints.U8, dst []byte) (*G1Affine, error) {
+	u, err := tofield.HashToField[BaseField](g1.api, msg, dst, 2)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0 := g1.MapToCurve1(u[0])
+	q1 := g1.MapToCurve1(u[1])
+	// BN254 has cofactor 1, so no cofactor clearing is needed.
+	return g1.curve.AddUnified(q0, q1), nil
+}
@@ -0,0 +1,80 @@
+package sw_bn254
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToCurve1Circuit struct {
+	U   emulated.Element[BaseField]
+	Res G1Affine
+}
+
+func (c *mapToCurve1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return err
+	}
+	res := g1.MapToCurve1(&c.U)
+	g1.curve.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMapToCurve1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for i := 0; i < 4; i++ {
+		var u fp.Element
+		if i > 0 {
+			u.SetRandom()
+		}
+		res := bn254.MapToCurve1(&u)
+		witness := mapToCurve1Circuit{
+			U:   emulated.ValueOf[BaseField](u),
+			Res: NewG1Affine(res),
+		}
+		err := test.IsSolved(&mapToCurve1Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type hashToG1Circuit struct {
+	Msg []uints.U8
+	Res G1Affine
+
+	dst []byte
+}
+
+func (c *hashToG1Circuit) Define(api frontend.API) error {
+	g1, err := NewG1(api)
+	if err != nil {
+		return err
+	}
+	res, err := g1.HashToG1(c.Msg, c.dst)
+	if err != nil {
+		return err
+	}
+	g1.curve.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestHashToG1TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BN254G1_XMD:SHA-256_SVDW_RO_")
+	for _, msg := range []string{"", "abc"} {
+		res, err := bn254.HashToG1([]byte(msg), dst)
+		assert.NoError(err)
+		witness := hashToG1Circuit{
+			Msg: uints.NewU8Array([]byte(msg)),
+			Res: NewG1Affine(res),
+		}
+		err = test.IsSolved(&hashToG1Circuit{Msg: make([]uints.U8, len(msg)), dst: dst}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
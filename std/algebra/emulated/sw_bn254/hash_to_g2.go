@@ -0,0 +1,160 @@
+package sw_bn254
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
+	"github.com/consensys/gnark/std/hash/tofield"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// constants of the Shallue-van de Woestijne map for the BN254 twist
+// y² = x³ + b' with b' = 3/(9+u) as defined in [RFC 9380] section 6.6.1 with
+// Z = 1:
+//
+//	c1 = g(Z)
+//	c2 = -Z / 2
+//	c3 = sqrt(-g(Z) * 3 * Z²), sgn0(c3) = 0
+//	c4 = -4 * g(Z) / (3 * Z²)
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+var (
+	svdwBTwist = [2]string{"19485874751759354771024239261021720505790618469301721065564631296452457478373", "266929791119991161246907387137283842545076965332900288569378510910307636690"}
+	svdwG2C1   = [2]string{"19485874751759354771024239261021720505790618469301721065564631296452457478374", "266929791119991161246907387137283842545076965332900288569378510910307636690"}
+	svdwG2C3   = [2]string{"18992192239972082890849143911285057164064277369389217330423471574879236301292", "21819008332247140148575583693947636719449476128975323941588917397607662637108"}
+	svdwG2C4   = [2]string{"10499238450719652342378357227399831140106360636427411350395554762472100376473", "6940174569119770192419592065569379906172001098655407502803841283667998553941"}
+)
+
+// constant returns the constant c = c[0] + c[1]⋅u of 𝔽p².
+func (g2 *G2) constant(c [2]string) *fields_bn254.E2 {
+	return &fields_bn254.E2{
+		A0: *g2.fp.NewElement(c[0]),
+		A1: *g2.fp.NewElement(c[1]),
+	}
+}
+
+// sgn0 returns the sign of x as defined in [RFC 9380] section 4.1, i.e.
+// sgn0(x) = sgn0(x.A0) OR (x.A0 == 0 AND sgn0(x.A1)).
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) sgn0(x *fields_bn254.E2) frontend.Variable {
+	a0 := g2.fp.Reduce(&x.A0)
+	g2.fp.AssertIsInRange(a0)
+	a1 := g2.fp.Reduce(&x.A1)
+	g2.fp.AssertIsInRange(a1)
+	sign0 := g2.fp.ToBits(a0)[0]
+	sign1 := g2.fp.ToBits(a1)[0]
+	zero0 := g2.fp.IsZero(a0)
+	return g2.api.Or(sign0, g2.api.And(zero0, sign1))
+}
+
+// g returns x³ + b'.
+func (g2 *G2) g(x *fields_bn254.E2) *fields_bn254.E2 {
+	gx := g2.Ext2.Square(x)
+	gx = g2.Ext2.Mul(gx, x)
+	return g2.Ext2.Add(gx, g2.constant(svdwBTwist))
+}
+
+// sqrt returns a square root of x and 1 if x is a quadratic residue (or
+// zero), and a square root of (9+u)⋅x and 0 otherwise.
+func (g2 *G2) sqrt(x *fields_bn254.E2) (*fields_bn254.E2, frontend.Variable) {
+	res, err := g2.fp.NewHint(sqrtOrNonResidueSqrtG2Hint, 2, &x.A0, &x.A1)
+	if err != nil {
+		panic(err)
+	}
+	r := &fields_bn254.E2{A0: *res[0], A1: *res[1]}
+	rr := g2.Ext2.Square(r)
+	isSquare := g2.Ext2.IsZero(g2.Ext2.Sub(rr, x))
+	g2.Ext2.AssertIsEqual(rr, g2.Ext2.Select(isSquare, x, g2.Ext2.MulByNonResidue(x)))
+	return r, isSquare
+}
+
+// MapToCurve2 implements the Shallue-van de Woestijne map of [RFC 9380]
+// section 6.6.1 onto the BN254 twist. The result corresponds to
+// [bn254.MapToCurve2] and is not in G2.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) MapToCurve2(u *fields_bn254.E2) *G2Affine {
+	one := g2.Ext2.One()
+	c2 := &fields_bn254.E2{A0: *g2.fp.NewElement(svdwC2), A1: *g2.fp.Zero()}
+
+	// tv1 = u²⋅c1
+	tv1 := g2.Ext2.Square(u)
+	tv1 = g2.Ext2.Mul(tv1, g2.constant(svdwG2C1))
+	// tv2 = 1 + tv1
+	tv2 := g2.Ext2.Add(one, tv1)
+	// tv1 = 1 - tv1
+	tv1 = g2.Ext2.Sub(one, tv1)
+	// tv3 = inv0(tv1⋅tv2)
+	tv3 := g2.Ext2.Mul(tv1, tv2)
+	isZero := g2.Ext2.IsZero(tv3)
+	tv3 = g2.Ext2.Inverse(g2.Ext2.Select(isZero, one, tv3))
+	tv3 = g2.Ext2.Select(isZero, g2.Ext2.Zero(), tv3)
+	// tv4 = u⋅tv1⋅tv3⋅c3
+	tv4 := g2.Ext2.Mul(u, tv1)
+	tv4 = g2.Ext2.Mul(tv4, tv3)
+	tv4 = g2.Ext2.Mul(tv4, g2.constant(svdwG2C3))
+	// x1 = c2 - tv4, x2 = c2 + tv4
+	x1 := g2.Ext2.Sub(c2, tv4)
+	x2 := g2.Ext2.Add(c2, tv4)
+	// x3 = (tv2²⋅tv3)²⋅c4 + Z
+	x3 := g2.Ext2.Square(tv2)
+	x3 = g2.Ext2.Mul(x3, tv3)
+	x3 = g2.Ext2.Square(x3)
+	x3 = g2.Ext2.Mul(x3, g2.constant(svdwG2C4))
+	x3 = g2.Ext2.Add(x3, one)
+
+	// e1 = is_square(g(x1)), e2 = is_square(g(x2)) AND NOT e1
+	_, e1 := g2.sqrt(g2.g(x1))
+	_, e2 := g2.sqrt(g2.g(x2))
+	e2 = g2.api.And(e2, g2.api.Sub(1, e1))
+	x := g2.Ext2.Select(e1, x1, g2.Ext2.Select(e2, x2, x3))
+
+	// y = sqrt(g(x)), g(x) is a square by construction
+	y, isSquare := g2.sqrt(g2.g(x))
+	g2.api.AssertIsEqual(isSquare, 1)
+
+	// fix the sign of y so that sgn0(u) == sgn0(y)
+	e3 := g2.api.Xor(g2.sgn0(u), g2.sgn0(y))
+	y = g2.Ext2.Select(e3, g2.Ext2.Neg(y), y)
+
+	return &G2Affine{P: g2AffP{X: *x, Y: *y}}
+}
+
+// ClearCofactor maps a point on the BN254 twist to G2 as
+//
+//	[x₀]Q + ψ([3x₀]Q) + ψ²([x₀]Q) + ψ³(Q)
+//
+// following [FKR11] section 6.1. The result corresponds to
+// [bn254.G2Affine.ClearCofactor].
+//
+// The multiplication by the seed uses incomplete formulas, which fail only when
+// Q has small order. As the cofactor of the twist is 10069 times a large prime,
+// this happens with negligible probability for the points of [G2.MapToCurve2].
+// The terms are added with complete formulas.
+//
+// [FKR11]: http://cacr.uwaterloo.ca/techreports/2011/cacr2011-26.pdf
+func (g2 *G2) ClearCofactor(q *G2Affine) *G2Affine {
+	xQ := g2.scalarMulBySeed(q)
+	t := g2.add(g2.double(xQ), xQ)
+	t = g2.psi(t)
+	res := g2.AddUnified(xQ, t)
+	res = g2.AddUnified(res, g2.psi(g2.psi(xQ)))
+	return g2.AddUnified(res, g2.psi(g2.psi(g2.psi(q))))
+}
+
+// HashToG2 hashes the message msg with the domain separation tag dst to G2
+// using the suite BN254G2_XMD:SHA-256_SVDW_RO_ of [RFC 9380]. The result
+// corresponds to [bn254.HashToG2].
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func (g2 *G2) HashToG2(msg []uints.U8, dst []byte) (*G2Affine, error) {
+	u, err := tofield.HashToField[BaseField](g2.api, msg, dst, 4)
+	if err != nil {
+		return nil, fmt.Errorf("hash to field: %w", err)
+	}
+	q0 := g2.MapToCurve2(&fields_bn254.E2{A0: *u[0], A1: *u[1]})
+	q1 := g2.MapToCurve2(&fields_bn254.E2{A0: *u[2], A1: *u[3]})
+	return g2.ClearCofactor(g2.AddUnified(q0, q1)), nil
+}
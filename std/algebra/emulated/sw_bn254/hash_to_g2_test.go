@@ -0,0 +1,99 @@
+package sw_bn254
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type mapToCurve2Circuit struct {
+	U   fields_bn254.E2
+	Res G2Affine
+}
+
+func (c *mapToCurve2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.MapToCurve2(&c.U)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestMapToCurve2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	for i := 0; i < 4; i++ {
+		var u bn254.E2
+		if i > 0 {
+			u.SetRandom()
+		}
+		res := bn254.MapToCurve2(&u)
+		witness := mapToCurve2Circuit{
+			U:   fields_bn254.FromE2(&u),
+			Res: NewG2Affine(res),
+		}
+		err := test.IsSolved(&mapToCurve2Circuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type clearCofactorG2Circuit struct {
+	In, Res G2Affine
+}
+
+func (c *clearCofactorG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res := g2.ClearCofactor(&c.In)
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestClearCofactorG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u bn254.E2
+	u.SetRandom()
+	in := bn254.MapToCurve2(&u)
+	var res bn254.G2Affine
+	res.ClearCofactor(&in)
+	witness := clearCofactorG2Circuit{
+		In:  NewG2Affine(in),
+		Res: NewG2Affine(res),
+	}
+	err := test.IsSolved(&clearCofactorG2Circuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type hashToG2Circuit struct {
+	Msg []uints.U8
+	Res G2Affine
+
+	dst []byte
+}
+
+func (c *hashToG2Circuit) Define(api frontend.API) error {
+	g2 := NewG2(api)
+	res, err := g2.HashToG2(c.Msg, c.dst)
+	if err != nil {
+		return err
+	}
+	g2.AssertIsEqual(res, &c.Res)
+	return nil
+}
+
+func TestHashToG2TestSolve(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BN254G2_XMD:SHA-256_SVDW_RO_")
+	for _, msg := range []string{"", "abc"} {
+		res, err := bn254.HashToG2([]byte(msg), dst)
+		assert.NoError(err)
+		witness := hashToG2Circuit{
+			Msg: uints.NewU8Array([]byte(msg)),
+			Res: NewG2Affine(res),
+		}
+		err = test.IsSolved(&hashToG2Circuit{Msg: make([]uints.U8, len(msg)), dst: dst}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
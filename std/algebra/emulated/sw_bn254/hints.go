@@ -0,0 +1,70 @@
+package sw_bn254
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all the hints used in this package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{sqrtOrNegSqrtHint, sqrtOrNonResidueSqrtG2Hint}
+}
+
+// sqrtOrNegSqrtHint returns a square root of x if it is a quadratic residue
+// and a square root of -x otherwise. As p ≡ 3 mod 4, then -1 is a non-square
+// and exactly one of them exists (except when x = 0).
+func sqrtOrNegSqrtHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(mod *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 {
+			return fmt.Errorf("expecting one input")
+		}
+		if len(outputs) != 1 {
+			return fmt.Errorf("expecting one output")
+		}
+		var x fp.Element
+		x.SetBigInt(inputs[0])
+		if x.Legendre() == -1 {
+			x.Neg(&x)
+		}
+		if x.Sqrt(&x) == nil {
+			return fmt.Errorf("no square root")
+		}
+		x.BigInt(outputs[0])
+		return nil
+	})
+}
+
+// sqrtOrNonResidueSqrtG2Hint returns a square root of x ∈ 𝔽p² if it is a
+// quadratic residue and a square root of (9+u)⋅x otherwise. As 9+u is a
+// non-square, then exactly one of them exists (except when x = 0).
+func sqrtOrNonResidueSqrtG2Hint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(mod *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 2 {
+			return fmt.Errorf("expecting two inputs")
+		}
+		if len(outputs) != 2 {
+			return fmt.Errorf("expecting two outputs")
+		}
+		var x bn254.E2
+		x.A0.SetBigInt(inputs[0])
+		x.A1.SetBigInt(inputs[1])
+		if x.Legendre() == -1 {
+			x.MulByNonResidue(&x)
+		}
+		if x.Sqrt(&x) == nil {
+			return fmt.Errorf("no square root")
+		}
+		x.A0.BigInt(outputs[0])
+		x.A1.BigInt(outputs[1])
+		return nil
+	})
+}
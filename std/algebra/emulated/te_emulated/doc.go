@@ -0,0 +1,29 @@
+/*
+Package te_emulated implements elliptic curve group operations in twisted
+Edwards form.
+
+The elliptic curve is the set of points (X,Y) satisfying the equation:
+
+	aX² + Y² = 1 + dX²Y²
+
+over some base field 𝐅p for some constants a, d ∈ 𝐅p. Additionally, for every
+curve we also define its generator (base point) G and the cofactor h of the
+prime order subgroup generated by G. All these parameters are stored in the
+variable of type [CurveParams].
+
+When a is a square and d is a non-square in 𝐅p, the addition formulas are
+complete and the point (0,1) is the neutral element. This package assumes that
+the curve parameters satisfy these conditions and thus exposes a single [Curve.Add]
+method which can be used for point additions, doublings or in case of the
+neutral element.
+
+The package provides the parameters for Edwards25519, see function
+[GetEd25519Params].
+
+Similarly to [github.com/consensys/gnark/std/algebra/emulated/sw_emulated], this
+package uses field emulation for the operations, which allows to use any curve
+over any native (SNARK) field at the cost of many constraints. For the twisted
+Edwards curves defined over the native field, use the package
+[github.com/consensys/gnark/std/algebra/native/twistededwards] instead.
+*/
+package te_emulated
@@ -0,0 +1,265 @@
+package te_emulated
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// New returns a new [Curve] instance over the base field Base and scalar field
+// Scalars defined by the curve parameters params. It returns an error if
+// initialising the field emulation fails (for example, when the native field is
+// too small) or when the curve parameters are incompatible with the fields.
+func New[Base, Scalars emulated.FieldParams](api frontend.API, params CurveParams) (*Curve[Base, Scalars], error) {
+	ba, err := emulated.NewField[Base](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	sa, err := emulated.NewField[Scalars](api)
+	if err != nil {
+		return nil, fmt.Errorf("new scalar api: %w", err)
+	}
+	if params.Cofactor == nil || params.Cofactor.Sign() != 1 {
+		return nil, fmt.Errorf("invalid cofactor")
+	}
+	var fp Base
+	return &Curve[Base, Scalars]{
+		params:    params,
+		api:       api,
+		baseApi:   ba,
+		scalarApi: sa,
+		g: AffinePoint[Base]{
+			X: emulated.ValueOf[Base](params.Gx),
+			Y: emulated.ValueOf[Base](params.Gy),
+		},
+		a:           emulated.ValueOf[Base](params.A),
+		d:           emulated.ValueOf[Base](params.D),
+		aIsMinusOne: new(big.Int).Sub(fp.Modulus(), params.A).Cmp(big.NewInt(1)) == 0,
+	}, nil
+}
+
+// Curve is an initialised curve which allows performing group operations.
+type Curve[Base, Scalars emulated.FieldParams] struct {
+	// params is the parameters of the curve
+	params CurveParams
+	// api is the native api, we construct it ourselves to be sure
+	api frontend.API
+	// baseApi is the api for point operations
+	baseApi *emulated.Field[Base]
+	// scalarApi is the api for scalar operations
+	scalarApi *emulated.Field[Scalars]
+
+	// g is the generator (base point) of the curve.
+	g AffinePoint[Base]
+
+	a           emulated.Element[Base]
+	d           emulated.Element[Base]
+	aIsMinusOne bool
+}
+
+// AffinePoint represents a point on the elliptic curve. We do not check that
+// the point is actually on the curve.
+//
+// Point (0,1) is the neutral element of the group.
+type AffinePoint[Base emulated.FieldParams] struct {
+	X, Y emulated.Element[Base]
+}
+
+// Generator returns the base point of the curve. The method does not copy and
+// modifying the returned element leads to undefined behaviour!
+func (c *Curve[B, S]) Generator() *AffinePoint[B] {
+	return &c.g
+}
+
+// Neutral returns the neutral element (0,1) of the group.
+func (c *Curve[B, S]) Neutral() *AffinePoint[B] {
+	return &AffinePoint[B]{
+		X: *c.baseApi.Zero(),
+		Y: *c.baseApi.One(),
+	}
+}
+
+// Neg returns an inverse of p. It doesn't modify p.
+func (c *Curve[B, S]) Neg(p *AffinePoint[B]) *AffinePoint[B] {
+	return &AffinePoint[B]{
+		X: *c.baseApi.Neg(&p.X),
+		Y: p.Y,
+	}
+}
+
+// AssertIsEqual asserts that p and q are the same point.
+func (c *Curve[B, S]) AssertIsEqual(p, q *AffinePoint[B]) {
+	c.baseApi.AssertIsEqual(&p.X, &q.X)
+	c.baseApi.AssertIsEqual(&p.Y, &q.Y)
+}
+
+// mulByA returns a⋅x.
+func (c *Curve[B, S]) mulByA(x *emulated.Element[B]) *emulated.Element[B] {
+	if c.aIsMinusOne {
+		return c.baseApi.Neg(x)
+	}
+	return c.baseApi.Mul(&c.a, x)
+}
+
+// AssertIsOnCurve asserts if p belongs to the curve. It doesn't modify p.
+func (c *Curve[B, S]) AssertIsOnCurve(p *AffinePoint[B]) {
+	// (X,Y) ∈ {aX² + Y² = 1 + dX²Y²}
+	xx := c.baseApi.Mul(&p.X, &p.X)
+	yy := c.baseApi.Mul(&p.Y, &p.Y)
+	lhs := c.baseApi.Add(c.mulByA(xx), yy)
+	rhs := c.baseApi.Mul(&c.d, c.baseApi.Mul(xx, yy))
+	rhs = c.baseApi.Add(rhs, c.baseApi.One())
+	c.baseApi.AssertIsEqual(lhs, rhs)
+}
+
+// Add adds p and q and returns it. It doesn't modify p nor q.
+//
+// ✅ p can be equal to q, and either or both can be (0,1).
+//
+// It uses the complete addition formulas in affine coordinates
+//
+//	X3 = (X1Y2 + Y1X2) / (1 + dX1X2Y1Y2)
+//	Y3 = (Y1Y2 - aX1X2) / (1 - dX1X2Y1Y2)
+func (c *Curve[B, S]) Add(p, q *AffinePoint[B]) *AffinePoint[B] {
+	x1y2 := c.baseApi.Mul(&p.X, &q.Y)
+	y1x2 := c.baseApi.Mul(&p.Y, &q.X)
+	x1x2 := c.baseApi.Mul(&p.X, &q.X)
+	y1y2 := c.baseApi.Mul(&p.Y, &q.Y)
+	t := c.baseApi.Mul(&c.d, c.baseApi.Mul(x1x2, y1y2))
+	x := c.baseApi.Div(
+		c.baseApi.Add(x1y2, y1x2),
+		c.baseApi.Add(c.baseApi.One(), t),
+	)
+	y := c.baseApi.Div(
+		c.baseApi.Sub(y1y2, c.mulByA(x1x2)),
+		c.baseApi.Sub(c.baseApi.One(), t),
+	)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// Double doubles p and return it. It doesn't modify p.
+//
+// ⚠️  p must be on the curve.
+//
+// It uses the dedicated doubling formulas in affine coordinates, obtained from
+// the addition formulas by substituting the curve equation
+//
+//	X3 = 2X1Y1 / (aX1² + Y1²)
+//	Y3 = (Y1² - aX1²) / (2 - aX1² - Y1²)
+func (c *Curve[B, S]) Double(p *AffinePoint[B]) *AffinePoint[B] {
+	xy := c.baseApi.Mul(&p.X, &p.Y)
+	axx := c.mulByA(c.baseApi.Mul(&p.X, &p.X))
+	yy := c.baseApi.Mul(&p.Y, &p.Y)
+	x := c.baseApi.Div(
+		c.baseApi.Add(xy, xy),
+		c.baseApi.Add(axx, yy),
+	)
+	y := c.baseApi.Div(
+		c.baseApi.Sub(yy, axx),
+		c.baseApi.Sub(c.baseApi.NewElement(2), c.baseApi.Add(axx, yy)),
+	)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// Select selects between p and q given the selector b. If b == 1, then returns
+// p and q otherwise.
+func (c *Curve[B, S]) Select(b frontend.Variable, p, q *AffinePoint[B]) *AffinePoint[B] {
+	x := c.baseApi.Select(b, &p.X, &q.X)
+	y := c.baseApi.Select(b, &p.Y, &q.Y)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// Lookup2 performs a 2-bit lookup between i0, i1, i2, i3 based on bits b0
+// and b1. Returns:
+//   - i0 if b0=0 and b1=0,
+//   - i1 if b0=1 and b1=0,
+//   - i2 if b0=0 and b1=1,
+//   - i3 if b0=1 and b1=1.
+func (c *Curve[B, S]) Lookup2(b0, b1 frontend.Variable, i0, i1, i2, i3 *AffinePoint[B]) *AffinePoint[B] {
+	x := c.baseApi.Lookup2(b0, b1, &i0.X, &i1.X, &i2.X, &i3.X)
+	y := c.baseApi.Lookup2(b0, b1, &i0.Y, &i1.Y, &i2.Y, &i3.Y)
+	return &AffinePoint[B]{
+		X: *x,
+		Y: *y,
+	}
+}
+
+// scalarBits returns the bits of the reduced scalar s, up to the bit length of
+// the scalar field modulus.
+func (c *Curve[B, S]) scalarBits(s *emulated.Element[S]) []frontend.Variable {
+	var st S
+	sr := c.scalarApi.Reduce(s)
+	sBits := c.scalarApi.ToBits(sr)
+	return sBits[:st.Modulus().BitLen()]
+}
+
+// ScalarMul computes [s]p and returns it. It doesn't modify p nor s.
+//
+// ✅ p can be (0,1) and s can be 0.
+//
+// The scalar is only reduced to its bit length and not necessarily to the
+// canonical value, so the result is correct when p is in the prime order
+// subgroup. It uses the left-to-right double-and-add algorithm with complete
+// additions.
+func (c *Curve[B, S]) ScalarMul(p *AffinePoint[B], s *emulated.Element[S]) *AffinePoint[B] {
+	sBits := c.scalarBits(s)
+	res := c.Neutral()
+	for i := len(sBits) - 1; i >= 0; i-- {
+		res = c.Double(res)
+		res = c.Select(sBits[i], c.Add(res, p), res)
+	}
+	return res
+}
+
+// ScalarMulBase computes [s]g and returns it, where g is the fixed generator.
+// It doesn't modify s.
+func (c *Curve[B, S]) ScalarMulBase(s *emulated.Element[S]) *AffinePoint[B] {
+	return c.ScalarMul(c.Generator(), s)
+}
+
+// JointScalarMulBase computes [s1]g + [s2]p and returns it, where g is the
+// fixed generator. It doesn't modify p, s1 nor s2.
+//
+// ✅ p can be (0,1) and s1 and s2 can be 0.
+//
+// It uses the Straus-Shamir trick, doubling once and adding one of (0,1), g, p
+// or g+p per bit. Same as for [Curve.ScalarMul], the result is correct when p
+// is in the prime order subgroup.
+func (c *Curve[B, S]) JointScalarMulBase(p *AffinePoint[B], s2, s1 *emulated.Element[S]) *AffinePoint[B] {
+	s1Bits := c.scalarBits(s1)
+	s2Bits := c.scalarBits(s2)
+	g := c.Generator()
+	gp := c.Add(g, p)
+	neutral := c.Neutral()
+	res := c.Neutral()
+	for i := len(s1Bits) - 1; i >= 0; i-- {
+		res = c.Double(res)
+		res = c.Add(res, c.Lookup2(s1Bits[i], s2Bits[i], neutral, g, p, gp))
+	}
+	return res
+}
+
+// MulByCofactor computes [h]p and returns it, where h is the cofactor of the
+// curve. It doesn't modify p.
+func (c *Curve[B, S]) MulByCofactor(p *AffinePoint[B]) *AffinePoint[B] {
+	h := c.params.Cofactor
+	res := p
+	for i := h.BitLen() - 2; i >= 0; i-- {
+		res = c.Double(res)
+		if h.Bit(i) == 1 {
+			res = c.Add(res, p)
+		}
+	}
+	return res
+}
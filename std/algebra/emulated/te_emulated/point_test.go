@@ -0,0 +1,216 @@
+package te_emulated
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+var testCurve = ecc.BN254
+
+// nativePoint is a reference implementation of the twisted Edwards group law
+// over big integers.
+type nativePoint struct {
+	X, Y *big.Int
+}
+
+func nativeAdd(params CurveParams, p, q nativePoint) nativePoint {
+	mod := emulated.Curve25519Fp{}.Modulus()
+	x1y2 := new(big.Int).Mul(p.X, q.Y)
+	y1x2 := new(big.Int).Mul(p.Y, q.X)
+	x1x2 := new(big.Int).Mul(p.X, q.X)
+	y1y2 := new(big.Int).Mul(p.Y, q.Y)
+	t := new(big.Int).Mul(params.D, new(big.Int).Mul(x1x2, y1y2))
+	t.Mod(t, mod)
+	xn := new(big.Int).Add(x1y2, y1x2)
+	xd := new(big.Int).Add(big.NewInt(1), t)
+	yn := new(big.Int).Sub(y1y2, new(big.Int).Mul(params.A, x1x2))
+	yd := new(big.Int).Sub(big.NewInt(1), t)
+	xd.ModInverse(xd.Mod(xd, mod), mod)
+	yd.ModInverse(yd.Mod(yd, mod), mod)
+	xn.Mul(xn, xd).Mod(xn, mod)
+	yn.Mul(yn, yd).Mod(yn, mod)
+	return nativePoint{xn, yn}
+}
+
+func nativeScalarMul(params CurveParams, p nativePoint, s *big.Int) nativePoint {
+	res := nativePoint{big.NewInt(0), big.NewInt(1)}
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		res = nativeAdd(params, res, res)
+		if s.Bit(i) == 1 {
+			res = nativeAdd(params, res, p)
+		}
+	}
+	return res
+}
+
+func randomScalar(t *testing.T) *big.Int {
+	s, err := rand.Int(rand.Reader, emulated.Curve25519Fr{}.Modulus())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func valueOf(p nativePoint) AffinePoint[emulated.Curve25519Fp] {
+	return AffinePoint[emulated.Curve25519Fp]{
+		X: emulated.ValueOf[emulated.Curve25519Fp](p.X),
+		Y: emulated.ValueOf[emulated.Curve25519Fp](p.Y),
+	}
+}
+
+type AddTest[T, S emulated.FieldParams] struct {
+	P, Q, R AffinePoint[T]
+}
+
+func (c *AddTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	cr.AssertIsOnCurve(&c.P)
+	cr.AssertIsOnCurve(&c.Q)
+	res := cr.Add(&c.P, &c.Q)
+	cr.AssertIsEqual(res, &c.R)
+	return nil
+}
+
+func TestAdd(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := nativePoint{params.Gx, params.Gy}
+	p := nativeScalarMul(params, g, randomScalar(t))
+	q := nativeScalarMul(params, g, randomScalar(t))
+	neutral := nativePoint{big.NewInt(0), big.NewInt(1)}
+	for _, tc := range [][2]nativePoint{{p, q}, {p, p}, {p, neutral}, {neutral, neutral}} {
+		circuit := AddTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{}
+		witness := AddTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{
+			P: valueOf(tc[0]),
+			Q: valueOf(tc[1]),
+			R: valueOf(nativeAdd(params, tc[0], tc[1])),
+		}
+		err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type DoubleTest[T, S emulated.FieldParams] struct {
+	P, Q AffinePoint[T]
+}
+
+func (c *DoubleTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.Double(&c.P)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestDouble(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	p := nativeScalarMul(params, nativePoint{params.Gx, params.Gy}, randomScalar(t))
+	circuit := DoubleTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{}
+	witness := DoubleTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{
+		P: valueOf(p),
+		Q: valueOf(nativeAdd(params, p, p)),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type ScalarMulTest[T, S emulated.FieldParams] struct {
+	P, Q AffinePoint[T]
+	S    emulated.Element[S]
+}
+
+func (c *ScalarMulTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.ScalarMul(&c.P, &c.S)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestScalarMul(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	p := nativeScalarMul(params, nativePoint{params.Gx, params.Gy}, randomScalar(t))
+	s := randomScalar(t)
+	circuit := ScalarMulTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{}
+	witness := ScalarMulTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{
+		P: valueOf(p),
+		Q: valueOf(nativeScalarMul(params, p, s)),
+		S: emulated.ValueOf[emulated.Curve25519Fr](s),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type JointScalarMulBaseTest[T, S emulated.FieldParams] struct {
+	P, Q   AffinePoint[T]
+	S1, S2 emulated.Element[S]
+}
+
+func (c *JointScalarMulBaseTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.JointScalarMulBase(&c.P, &c.S2, &c.S1)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestJointScalarMulBase(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	g := nativePoint{params.Gx, params.Gy}
+	p := nativeScalarMul(params, g, randomScalar(t))
+	s1, s2 := randomScalar(t), randomScalar(t)
+	circuit := JointScalarMulBaseTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{}
+	witness := JointScalarMulBaseTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{
+		P:  valueOf(p),
+		Q:  valueOf(nativeAdd(params, nativeScalarMul(params, g, s1), nativeScalarMul(params, p, s2))),
+		S1: emulated.ValueOf[emulated.Curve25519Fr](s1),
+		S2: emulated.ValueOf[emulated.Curve25519Fr](s2),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
+
+type MulByCofactorTest[T, S emulated.FieldParams] struct {
+	P, Q AffinePoint[T]
+}
+
+func (c *MulByCofactorTest[T, S]) Define(api frontend.API) error {
+	cr, err := New[T, S](api, GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	res := cr.MulByCofactor(&c.P)
+	cr.AssertIsEqual(res, &c.Q)
+	return nil
+}
+
+func TestMulByCofactor(t *testing.T) {
+	assert := test.NewAssert(t)
+	params := GetEd25519Params()
+	p := nativeScalarMul(params, nativePoint{params.Gx, params.Gy}, randomScalar(t))
+	circuit := MulByCofactorTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{}
+	witness := MulByCofactorTest[emulated.Curve25519Fp, emulated.Curve25519Fr]{
+		P: valueOf(p),
+		Q: valueOf(nativeScalarMul(params, p, params.Cofactor)),
+	}
+	err := test.IsSolved(&circuit, &witness, testCurve.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,40 @@
+package evmprecompiles
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // go-ethereum uses the same implementation
+)
+
+type ripemd160Circuit struct {
+	In       []uints.U8
+	Expected [32]uints.U8
+}
+
+func (c *ripemd160Circuit) Define(api frontend.API) error {
+	res := RIPEMD160(api, c.In)
+	for i := range res {
+		api.AssertIsEqual(res[i].Val, c.Expected[i].Val)
+	}
+	return nil
+}
+
+func TestRIPEMD160(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq")
+	h := ripemd160.New()
+	h.Write(msg)
+	// go-ethereum left-pads the digest to 32 bytes
+	var expected [32]byte
+	copy(expected[12:], h.Sum(nil))
+	witness := ripemd160Circuit{
+		In:       uints.NewU8Array(msg),
+		Expected: [32]uints.U8(uints.NewU8Array(expected[:])),
+	}
+	err := test.IsSolved(&ripemd160Circuit{In: make([]uints.U8, len(msg))}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,30 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2b"
+)
+
+// BLAKE2F implements [BLAKE2F] precompile contract at address 0x09.
+//
+// The EVM encodes the state vector h, the message block m and the offset
+// counters t as little-endian 64-bit words, which corresponds directly to the
+// byte order of [uints.U64]. The final block indicator must be boolean. The
+// number of rounds is a variable as in the EVM, but the circuit computes
+// maxRounds rounds, so rounds must be at most maxRounds, otherwise the proof
+// cannot be generated.
+//
+// [BLAKE2F]: https://eips.ethereum.org/EIPS/eip-152
+func BLAKE2F(api frontend.API, rounds frontend.Variable, maxRounds int, h [8]uints.U64, m [16]uints.U64, t [2]uints.U64, final frontend.Variable) [8]uints.U64 {
+	if maxRounds < 0 {
+		panic("negative maximum number of rounds")
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		panic(fmt.Sprintf("new uints: %v", err))
+	}
+	return blake2b.CompressVarRounds(uapi, api, rounds, maxRounds, h, m, t, final)
+}
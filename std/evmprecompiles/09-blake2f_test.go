@@ -0,0 +1,88 @@
+package evmprecompiles
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2b"
+	"github.com/consensys/gnark/test"
+)
+
+type blake2fCircuit struct {
+	MaxRounds int
+	Rounds    frontend.Variable
+	H         [8]uints.U64
+	M         [16]uints.U64
+	T         [2]uints.U64
+	F         frontend.Variable
+	Expected  [8]uints.U64
+}
+
+func (c *blake2fCircuit) Define(api frontend.API) error {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	res := BLAKE2F(api, c.Rounds, c.MaxRounds, c.H, c.M, c.T, c.F)
+	for i := range res {
+		uapi.AssertEq(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+// blake2fTestVectors are the test vectors 4-7 of EIP-152, also used by
+// go-ethereum. The input is the "abc" message block with the parameter block
+// of BLAKE2b-512.
+var blake2fTestVectors = []struct {
+	rounds   int
+	final    bool
+	expected string
+}{
+	{0, true, "08c9bcf367e6096a3ba7ca8485ae67bb2bf894fe72f36e3cf1361d5f3af54fa5d282e6ad7f520e511f6c3e2b8c68059b9442be0454267ce079217e1319cde05b"},
+	{12, true, "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+	{12, false, "75ab69d3190a562c51aef8d88f1c2775876944407270c42c9844252c26d2875298743e7f6d5ea2f2d3e8d226039cd31b4e426ac4f2d3d666a610c2116fde4735"},
+	{1, true, "b63a380cb2897d521994a85234ee2c181b5f844d2c624c002677e9703449d2fba551b3a8333bcdf5f2f7e08993d53923de3d64fcc68c034e717b9293fed7a421"},
+}
+
+func TestBLAKE2F(t *testing.T) {
+	assert := test.NewAssert(t)
+	h := blake2b.IV
+	h[0] ^= 0x01010040
+	var block [128]byte
+	copy(block[:], "abc")
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(block[8*i:])
+	}
+	for _, tv := range blake2fTestVectors {
+		assert.Run(func(assert *test.Assert) {
+			expected, err := hex.DecodeString(tv.expected)
+			assert.NoError(err)
+			witness := blake2fCircuit{
+				Rounds: tv.rounds,
+				H:      [8]uints.U64(uints.NewU64Array(h[:])),
+				M:      [16]uints.U64(uints.NewU64Array(m[:])),
+				T:      [2]uints.U64{uints.NewU64(3), uints.NewU64(0)},
+				F:      0,
+			}
+			if tv.final {
+				witness.F = 1
+			}
+			for i := range witness.Expected {
+				witness.Expected[i] = uints.NewU64(binary.LittleEndian.Uint64(expected[8*i:]))
+			}
+			err = test.IsSolved(&blake2fCircuit{MaxRounds: 12}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err)
+			if tv.rounds > 0 {
+				// the number of rounds is bounded by the maximum
+				err = test.IsSolved(&blake2fCircuit{MaxRounds: tv.rounds - 1}, &witness, ecc.BN254.ScalarField())
+				assert.Error(err)
+			}
+		}, fmt.Sprintf("rounds=%d/final=%t", tv.rounds, tv.final))
+	}
+}
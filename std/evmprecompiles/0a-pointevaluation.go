@@ -0,0 +1,192 @@
+package evmprecompiles
+
+import (
+	"fmt"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// blobCommitmentVersionKZG is the version byte of the versioned hash of a KZG
+// commitment.
+const blobCommitmentVersionKZG = 0x01
+
+// KZGPointEvaluation implements [POINT_EVALUATION] precompile contract at
+// address 0x0a.
+//
+// It asserts that versionedHash is the versioned hash of the compressed
+// commitment and that proof is a valid KZG opening proof of the evaluation
+// p(z) = y of the polynomial committed in commitment. The commitment and the
+// proof are given in the compressed serialization of BLS12-381 G1 points and
+// are decompressed and checked to be in G1 in-circuit. The evaluation point z
+// and the claimed value y must be given with zero overflow and are asserted to
+// be less than the BLS12-381 scalar field modulus.
+//
+// The verification key tauG2 is [τ]₂ of the trusted setup and is embedded into
+// the circuit as a constant. For Ethereum, it is the corresponding point of the
+// KZG ceremony.
+//
+// The commitment and the proof can be the point at infinity (the compressed
+// encoding 0xc0 followed by zeros), for example the commitment of the all-zero
+// blob.
+//
+// The precompile output (FIELD_ELEMENTS_PER_BLOB and BLS_MODULUS) is constant
+// and is not returned.
+//
+// [POINT_EVALUATION]: https://eips.ethereum.org/EIPS/eip-4844#point-evaluation-precompile
+func KZGPointEvaluation(api frontend.API, versionedHash [32]uints.U8, z, y *sw_bls12381.Scalar, commitmentBytes, proofBytes [48]uints.U8, tauG2 bls12381.G2Affine) {
+	frField, err := emulated.NewField[sw_bls12381.ScalarField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new scalar field: %v", err))
+	}
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+
+	// 1- check that the versioned hash corresponds to the commitment
+	h, err := sha2.New(api, false)
+	if err != nil {
+		panic(fmt.Sprintf("new sha2: %v", err))
+	}
+	h.Write(commitmentBytes[:])
+	dgst := h.Sum()
+	api.AssertIsEqual(versionedHash[0].Val, blobCommitmentVersionKZG)
+	for i := 1; i < len(versionedHash); i++ {
+		api.AssertIsEqual(versionedHash[i].Val, dgst[i].Val)
+	}
+
+	// 2- check that z and y are canonical scalars
+	frField.AssertIsInRange(z)
+	frField.AssertIsInRange(y)
+
+	// 3- decompress the commitment and the proof and check that they are in G1.
+	// Neither the subgroup checks nor the Miller loop handle the point at
+	// infinity, so we get the generator instead and discard its terms.
+	commitment, isInfC := decompressG1BLS(api, commitmentBytes)
+	proof, isInfPi := decompressG1BLS(api, proofBytes)
+	pairing.AssertIsOnG1(commitment)
+	pairing.AssertIsOnG1(proof)
+	infinity := &sw_bls12381.G1Affine{X: *fpField.Zero(), Y: *fpField.Zero()}
+	commitment = curve.Select(isInfC, infinity, commitment)
+
+	// 4- check the opening. The KZG equation
+	//   e(C - [y]G₁, G₂) == e(π, [τ]₂ - [z]G₂)
+	// is equivalent to
+	//   e(C - [y]G₁ + [z]π, -G₂) ⋅ e(π, [τ]₂) == 1
+	// which avoids the scalar multiplication in G2.
+	yG := curve.ScalarMulBase(y, algopts.WithCompleteArithmetic())
+	zPi := curve.ScalarMul(proof, z, algopts.WithCompleteArithmetic())
+	zPi = curve.Select(isInfPi, infinity, zPi)
+	lhs := curve.AddUnified(commitment, curve.Neg(yG))
+	lhs = curve.AddUnified(lhs, zPi)
+	// lhs is at infinity for the opening of a constant polynomial.
+	isInfLhs := api.And(fpField.IsZero(&lhs.X), fpField.IsZero(&lhs.Y))
+	lhs = curve.Select(isInfLhs, curve.Generator(), lhs)
+
+	_, _, _, g2 := bls12381.Generators()
+	g2.Neg(&g2)
+	negG2 := sw_bls12381.NewG2AffineFixed(g2)
+	tau := sw_bls12381.NewG2AffineFixed(tauG2)
+	mlLhs, err := pairing.MillerLoop([]*sw_bls12381.G1Affine{lhs}, []*sw_bls12381.G2Affine{&negG2})
+	if err != nil {
+		panic(fmt.Sprintf("miller loop: %v", err))
+	}
+	mlPi, err := pairing.MillerLoop([]*sw_bls12381.G1Affine{proof}, []*sw_bls12381.G2Affine{&tau})
+	if err != nil {
+		panic(fmt.Sprintf("miller loop: %v", err))
+	}
+	mlLhs = pairing.Select(isInfLhs, pairing.One(), mlLhs)
+	mlPi = pairing.Select(isInfPi, pairing.One(), mlPi)
+	// we use the safe final exponentiation as the Miller loop product can be 1.
+	res := pairing.FinalExponentiation(pairing.Mul(mlLhs, mlPi))
+	pairing.AssertIsEqual(res, pairing.One())
+}
+
+// decompressG1BLS decompresses a BLS12-381 G1 point given in the compressed
+// ZCash serialization. The returned point is on the curve, but the subgroup
+// membership is not checked. If the encoding is the one of the point at
+// infinity, it returns the generator and isInfinity is 1.
+func decompressG1BLS(api frontend.API, compressed [48]uints.U8) (P *sw_bls12381.G1Affine, isInfinity frontend.Variable) {
+	var emfp sw_bls12381.BaseField
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	// the three most significant bits of the first byte are the flags:
+	// compression, infinity and the sign of y.
+	msb := api.ToBinary(compressed[0].Val, 8)
+	api.AssertIsEqual(msb[7], 1)
+	isInfinity = msb[6]
+	isLargest := msb[5]
+	// the serialization is big-endian. We compose the limbs of X directly from
+	// the bytes.
+	bytesPerLimb := int(emfp.BitsPerLimb()) / 8
+	xLimbs := make([]frontend.Variable, emfp.NbLimbs())
+	for i := range xLimbs {
+		limb := frontend.Variable(0)
+		for j := 0; j < bytesPerLimb; j++ {
+			idx := len(compressed) - 1 - i*bytesPerLimb - j
+			b := compressed[idx].Val
+			if idx == 0 {
+				b = api.FromBinary(msb[:5]...)
+			}
+			limb = api.Add(limb, api.Mul(b, new(big.Int).Lsh(big.NewInt(1), uint(8*j))))
+		}
+		xLimbs[i] = limb
+	}
+	x := fpField.NewElement(xLimbs)
+	fpField.AssertIsInRange(x)
+
+	// the point at infinity is encoded as 0xc0 followed by zeros, that is with
+	// the sign bit and X set to zero.
+	api.AssertIsEqual(api.Mul(isInfinity, isLargest), 0)
+	api.AssertIsEqual(api.Mul(isInfinity, api.Sub(1, fpField.IsZero(x))), 0)
+
+	hintInputs := make([]frontend.Variable, len(compressed))
+	for i := range compressed {
+		hintInputs[i] = compressed[i].Val
+	}
+	yLimbs, err := api.Compiler().NewHint(decompressG1BLSHint, int(emfp.NbLimbs()), hintInputs...)
+	if err != nil {
+		panic(fmt.Sprintf("decompress hint: %v", err))
+	}
+	P = curve.Select(isInfinity, curve.Generator(), &sw_bls12381.G1Affine{
+		X: *x,
+		Y: *fpField.NewElement(yLimbs),
+	})
+	// (0,0) is accepted by AssertIsOnCurve as the point at infinity, but its
+	// encoding is not a valid compressed point.
+	api.AssertIsEqual(fpField.IsZero(&P.Y), 0)
+	curve.AssertIsOnCurve(P)
+
+	// y is lexicographically largest iff -y ≤ (p-1)/2. There is no sign for
+	// the point at infinity.
+	halfP := new(big.Int).Sub(emfp.Modulus(), big.NewInt(1))
+	halfP.Rsh(halfP, 1)
+	t := fpField.Select(isLargest, fpField.Neg(&P.Y), &P.Y)
+	t = fpField.Select(isInfinity, fpField.Zero(), t)
+	t = fpField.Reduce(t)
+	fpField.AssertIsInRange(t)
+	fpField.AssertIsLessOrEqual(t, fpField.NewElement(halfP))
+	return P, isInfinity
+}
@@ -0,0 +1,165 @@
+package evmprecompiles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/kzg"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type pointEvaluationCircuit struct {
+	tauG2 bls12381.G2Affine
+
+	VersionedHash [32]uints.U8
+	Z, Y          sw_bls12381.Scalar
+	Commitment    [48]uints.U8
+	Proof         [48]uints.U8
+}
+
+func (c *pointEvaluationCircuit) Define(api frontend.API) error {
+	KZGPointEvaluation(api, c.VersionedHash, &c.Z, &c.Y, c.Commitment, c.Proof, c.tauG2)
+	return nil
+}
+
+func testRoutinePointEvaluation(t *testing.T, constant bool) (circuit, witness *pointEvaluationCircuit) {
+	// random openings with a local SRS, see TestKZGPointEvaluationGeth for the
+	// Ethereum KZG ceremony.
+	var alpha fr.Element
+	alpha.SetRandom()
+	srs, err := kzg.NewSRS(16, alpha.BigInt(new(big.Int)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	poly := make([]fr.Element, 16)
+	for i := range poly {
+		if i == 0 || !constant {
+			poly[i].SetRandom()
+		}
+	}
+	commitment, err := kzg.Commit(poly, srs.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var z fr.Element
+	z.SetRandom()
+	proof, err := kzg.Open(poly, z, srs.Pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kzg.Verify(&commitment, &proof, z, srs.Vk); err != nil {
+		t.Fatal(err)
+	}
+	commitmentBytes := commitment.Bytes()
+	proofBytes := proof.H.Bytes()
+	versionedHash := sha256.Sum256(commitmentBytes[:])
+	versionedHash[0] = blobCommitmentVersionKZG
+
+	circuit = &pointEvaluationCircuit{tauG2: srs.Vk.G2[1]}
+	witness = &pointEvaluationCircuit{
+		VersionedHash: [32]uints.U8(uints.NewU8Array(versionedHash[:])),
+		Z:             emulated.ValueOf[sw_bls12381.ScalarField](z),
+		Y:             emulated.ValueOf[sw_bls12381.ScalarField](proof.ClaimedValue),
+		Commitment:    [48]uints.U8(uints.NewU8Array(commitmentBytes[:])),
+		Proof:         [48]uints.U8(uints.NewU8Array(proofBytes[:])),
+	}
+	return circuit, witness
+}
+
+func TestKZGPointEvaluation(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuit, witness := testRoutinePointEvaluation(t, false)
+	err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestKZGPointEvaluationConstant(t *testing.T) {
+	assert := test.NewAssert(t)
+	// the opening proof of a constant polynomial is the point at infinity
+	circuit, witness := testRoutinePointEvaluation(t, true)
+	err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong claimed value
+	witness.Y = emulated.ValueOf[sw_bls12381.ScalarField](42)
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestKZGPointEvaluationFailure(t *testing.T) {
+	assert := test.NewAssert(t)
+	circuit, witness := testRoutinePointEvaluation(t, false)
+	// wrong version byte
+	witness.VersionedHash[0] = uints.NewU8(0x02)
+	err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+
+	// wrong claimed value
+	circuit, witness = testRoutinePointEvaluation(t, false)
+	witness.Y = emulated.ValueOf[sw_bls12381.ScalarField](42)
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// mainnetTauG2 is [τ]₂ of the Ethereum KZG ceremony, in compressed form. It is
+// the second G2 point of the trusted setup of c-kzg-4844 and go-ethereum.
+const mainnetTauG2 = "b5bfd7dd8cdeb128843bc287230af38926187075cbfbefa81009a2ce615ac53d2914e5870cb452d2afaaab24f3499f72185cbfee53492714734429b7b38608e23926c911cceceac9a36851477ba4c60b087041de621000edc98edada20c1def2"
+
+// pointEvaluationTestVectors are the test vectors of go-ethereum
+// (core/vm/testdata/precompiles/pointEvaluation.json). The input is the
+// versioned hash, z, y, the commitment and the proof. The infinity vector is
+// the opening of the all-zero blob, whose commitment and proof are the point
+// at infinity, at the evaluation point of the first vector.
+var pointEvaluationTestVectors = []struct {
+	name  string
+	input string
+}{
+	{"pointEvaluation1", "01e798154708fe7789429634053cbf9f99b619f9f084048927333fce637f549b564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d3630624d25032e67a7e6a4910df5834b8fe70e6bcfeeac0352434196bdf4b2485d5a18f59a8d2a1a625a17f3fea0fe5eb8c896db3764f3185481bc22f91b4aaffcca25f26936857bc3a7c2539ea8ec3a952b7873033e038326e87ed3e1276fd140253fa08e9fc25fb2d9a98527fc22a2c9612fbeafdad446cbc7bcdbdcd780af2c16a"},
+	{"pointEvaluationInfinity", "010657f37554c781402a22917dee2f75def7ab966d7b770905398eba3c444014564c0a11a0f704f4fc3e8acfe0f8245f0ad1347b378fbf96e206da11a5d363060000000000000000000000000000000000000000000000000000000000000000c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000c00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"},
+}
+
+func pointEvaluationWitness(assert *test.Assert, input []byte) *pointEvaluationCircuit {
+	assert.Equal(192, len(input))
+	var z, y fr.Element
+	z.SetBytes(input[32:64])
+	y.SetBytes(input[64:96])
+	return &pointEvaluationCircuit{
+		VersionedHash: [32]uints.U8(uints.NewU8Array(input[:32])),
+		Z:             emulated.ValueOf[sw_bls12381.ScalarField](z),
+		Y:             emulated.ValueOf[sw_bls12381.ScalarField](y),
+		Commitment:    [48]uints.U8(uints.NewU8Array(input[96:144])),
+		Proof:         [48]uints.U8(uints.NewU8Array(input[144:192])),
+	}
+}
+
+func TestKZGPointEvaluationGeth(t *testing.T) {
+	assert := test.NewAssert(t)
+	tauBytes, err := hex.DecodeString(mainnetTauG2)
+	assert.NoError(err)
+	var tauG2 bls12381.G2Affine
+	_, err = tauG2.SetBytes(tauBytes)
+	assert.NoError(err)
+	for _, tv := range pointEvaluationTestVectors {
+		assert.Run(func(assert *test.Assert) {
+			input, err := hex.DecodeString(tv.input)
+			assert.NoError(err)
+			circuit := &pointEvaluationCircuit{tauG2: tauG2}
+			err = test.IsSolved(circuit, pointEvaluationWitness(assert, input), ecc.BN254.ScalarField())
+			assert.NoError(err)
+
+			// the opening doesn't hold for another claimed value
+			input[95] ^= 1
+			err = test.IsSolved(circuit, pointEvaluationWitness(assert, input), ecc.BN254.ScalarField())
+			assert.Error(err)
+		}, tv.name)
+	}
+}
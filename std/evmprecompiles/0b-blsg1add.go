@@ -0,0 +1,45 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECAddG1BLS implements [BLS12_G1ADD] precompile contract at address 0x0b.
+//
+// The coordinates of P and Q must be given with zero overflow. They are
+// asserted to be less than the base field modulus and the points are asserted
+// to be on the curve, but not in the prime-order subgroup, as per the EIP. The
+// point at infinity is represented as (0,0).
+//
+// [BLS12_G1ADD]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g1-addition
+func ECAddG1BLS(api frontend.API, P, Q *sw_bls12381.G1Affine) *sw_bls12381.G1Affine {
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	// 1- Check that the coordinates of P and Q are canonical
+	assertCoordinatesInRangeG1BLS(fpField, P)
+	assertCoordinatesInRangeG1BLS(fpField, Q)
+
+	// 2- Check that P and Q are on the curve
+	curve.AssertIsOnCurve(P)
+	curve.AssertIsOnCurve(Q)
+
+	// 3- We use AddUnified because P can be equal to Q, -Q and either or both
+	// can be (0,0)
+	return curve.AddUnified(P, Q)
+}
+
+func assertCoordinatesInRangeG1BLS(fpField *emulated.Field[sw_bls12381.BaseField], P *sw_bls12381.G1Affine) {
+	fpField.AssertIsInRange(&P.X)
+	fpField.AssertIsInRange(&P.Y)
+}
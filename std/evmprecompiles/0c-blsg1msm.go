@@ -0,0 +1,64 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMSMG1BLS implements [BLS12_G1MSM] precompile contract at address 0x0c.
+//
+// It returns ∑ᵢ [sᵢ]Pᵢ. The coordinates of the points must be given with zero
+// overflow. They are asserted to be less than the base field modulus and the
+// points are asserted to be in G1. The point at infinity is represented as
+// (0,0) and passes the subgroup check.
+//
+// The EIP encodes the scalars on 32 bytes, which may exceed the scalar field
+// modulus r. As all points are in G1, [s]P = [s mod r]P and it is up to the
+// caller to provide the scalars reduced modulo r.
+//
+// [BLS12_G1MSM]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g1-msm
+func ECMSMG1BLS(api frontend.API, P []*sw_bls12381.G1Affine, s []*sw_bls12381.Scalar) *sw_bls12381.G1Affine {
+	if len(P) != len(s) {
+		panic("P and s length mismatch")
+	}
+	if len(P) == 0 {
+		panic("empty input")
+	}
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+
+	var res *sw_bls12381.G1Affine
+	for i := range P {
+		// 1- Check that the coordinates of Pᵢ are canonical
+		assertCoordinatesInRangeG1BLS(fpField, P[i])
+
+		// 2- Check that Pᵢ is in G1. The subgroup check uses incomplete
+		// arithmetic so we check the generator instead when Pᵢ=(0,0).
+		isInfinity := api.And(fpField.IsZero(&P[i].X), fpField.IsZero(&P[i].Y))
+		pairing.AssertIsOnG1(curve.Select(isInfinity, curve.Generator(), P[i]))
+
+		// 3- Accumulate [sᵢ]Pᵢ. Pᵢ can be (0,0) and sᵢ can be zero.
+		q := curve.ScalarMul(P[i], s[i], algopts.WithCompleteArithmetic())
+		if res == nil {
+			res = q
+		} else {
+			res = curve.AddUnified(res, q)
+		}
+	}
+	return res
+}
@@ -0,0 +1,35 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+)
+
+// ECAddG2BLS implements [BLS12_G2ADD] precompile contract at address 0x0d.
+//
+// The coordinates of P and Q must be given with zero overflow. They are
+// asserted to be less than the base field modulus and the points are asserted
+// to be on the twist, but not in the prime-order subgroup, as per the EIP. The
+// point at infinity is represented as (0,0).
+//
+// [BLS12_G2ADD]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g2-addition
+func ECAddG2BLS(api frontend.API, P, Q *sw_bls12381.G2Affine) *sw_bls12381.G2Affine {
+	g2 := sw_bls12381.NewG2(api)
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	// 1- Check that the coordinates of P and Q are canonical
+	g2.AssertIsInRange(P)
+	g2.AssertIsInRange(Q)
+
+	// 2- Check that P and Q are on the twist
+	pairing.AssertIsOnTwist(P)
+	pairing.AssertIsOnTwist(Q)
+
+	// 3- We use AddUnified because P can be equal to Q, -Q and either or both
+	// can be (0,0)
+	return g2.AddUnified(P, Q)
+}
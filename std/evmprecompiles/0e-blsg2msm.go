@@ -0,0 +1,56 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+)
+
+// ECMSMG2BLS implements [BLS12_G2MSM] precompile contract at address 0x0e.
+//
+// It returns ∑ᵢ [sᵢ]Qᵢ. The coordinates of the points must be given with zero
+// overflow. They are asserted to be less than the base field modulus and the
+// points are asserted to be in G2. The point at infinity is represented as
+// (0,0) and passes the subgroup check.
+//
+// The EIP encodes the scalars on 32 bytes, which may exceed the scalar field
+// modulus r. As all points are in G2, [s]Q = [s mod r]Q and it is up to the
+// caller to provide the scalars reduced modulo r.
+//
+// [BLS12_G2MSM]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-g2-msm
+func ECMSMG2BLS(api frontend.API, Q []*sw_bls12381.G2Affine, s []*sw_bls12381.Scalar) *sw_bls12381.G2Affine {
+	if len(Q) != len(s) {
+		panic("Q and s length mismatch")
+	}
+	if len(Q) == 0 {
+		panic("empty input")
+	}
+	g2 := sw_bls12381.NewG2(api)
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	_, _, _, gen := bls12381.Generators()
+	g2Gen := sw_bls12381.NewG2Affine(gen)
+
+	var res *sw_bls12381.G2Affine
+	for i := range Q {
+		// 1- Check that the coordinates of Qᵢ are canonical
+		g2.AssertIsInRange(Q[i])
+
+		// 2- Check that Qᵢ is in G2. The subgroup check uses incomplete
+		// arithmetic so we check the generator instead when Qᵢ=(0,0).
+		pairing.AssertIsOnG2(g2.Select(g2.IsInfinity(Q[i]), &g2Gen, Q[i]))
+
+		// 3- Accumulate [sᵢ]Qᵢ. Qᵢ can be (0,0) and sᵢ can be zero.
+		q := g2.ScalarMul(Q[i], s[i])
+		if res == nil {
+			res = q
+		} else {
+			res = g2.AddUnified(res, q)
+		}
+	}
+	return res
+}
@@ -0,0 +1,75 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECPairBLS implements [BLS12_PAIRING_CHECK] precompile contract at address
+// 0x0f.
+//
+// It returns 1 if ∏ᵢ e(Pᵢ, Qᵢ) == 1 and 0 otherwise. The coordinates of the
+// points must be given with zero overflow. They are asserted to be less than
+// the base field modulus and the points are asserted to be in G1 and G2
+// respectively. The point at infinity is represented as (0,0) and the
+// corresponding pair contributes a neutral factor to the product.
+//
+// [BLS12_PAIRING_CHECK]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-pairing-check
+func ECPairBLS(api frontend.API, P []*sw_bls12381.G1Affine, Q []*sw_bls12381.G2Affine) frontend.Variable {
+	if len(P) != len(Q) {
+		panic("P and Q length mismatch")
+	}
+	if len(P) == 0 {
+		panic("empty input")
+	}
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		panic(fmt.Sprintf("new curve: %v", err))
+	}
+	g2 := sw_bls12381.NewG2(api)
+	pairing, err := sw_bls12381.NewPairing(api)
+	if err != nil {
+		panic(fmt.Sprintf("new pairing: %v", err))
+	}
+	_, _, _, gen := bls12381.Generators()
+	g2Gen := sw_bls12381.NewG2Affine(gen)
+
+	res := pairing.One()
+	for i := range P {
+		// 1- Check that the coordinates of Pᵢ and Qᵢ are canonical
+		assertCoordinatesInRangeG1BLS(fpField, P[i])
+		g2.AssertIsInRange(Q[i])
+
+		// 2- Check that Pᵢ is in G1 and Qᵢ is in G2. Neither the subgroup
+		// checks nor the Miller loop handle (0,0), so we use the generators
+		// instead and discard the corresponding Miller loop.
+		isInfP := api.And(fpField.IsZero(&P[i].X), fpField.IsZero(&P[i].Y))
+		isInfQ := g2.IsInfinity(Q[i])
+		p := curve.Select(isInfP, curve.Generator(), P[i])
+		q := g2.Select(isInfQ, &g2Gen, Q[i])
+		pairing.AssertIsOnG1(p)
+		pairing.AssertIsOnG2(q)
+
+		// 3- Accumulate the Miller loops
+		ml, err := pairing.MillerLoop([]*sw_bls12381.G1Affine{p}, []*sw_bls12381.G2Affine{q})
+		if err != nil {
+			panic(err)
+		}
+		ml = pairing.Select(api.Or(isInfP, isInfQ), pairing.One(), ml)
+		res = pairing.Mul(res, ml)
+	}
+
+	// 4- Check whether ∏ᵢ e(Pᵢ, Qᵢ) == 1. We use the safe final exponentiation
+	// as the Miller loop product can be 1.
+	res = pairing.FinalExponentiation(res)
+	return pairing.IsZero(pairing.Sub(res, pairing.One()))
+}
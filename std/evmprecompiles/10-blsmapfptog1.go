@@ -0,0 +1,30 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMapToG1BLS implements [BLS12_MAP_FP_TO_G1] precompile contract at address
+// 0x10.
+//
+// It maps the base field element u to G1 following the SSWU map of RFC 9380.
+// The element must be given with zero overflow and is asserted to be less than
+// the base field modulus.
+//
+// [BLS12_MAP_FP_TO_G1]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-mapping-fp-element-to-g1-point
+func ECMapToG1BLS(api frontend.API, u *emulated.Element[sw_bls12381.BaseField]) *sw_bls12381.G1Affine {
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	g1, err := sw_bls12381.NewG1(api)
+	if err != nil {
+		panic(fmt.Sprintf("new G1: %v", err))
+	}
+	fpField.AssertIsInRange(u)
+	return g1.MapToG1(u)
+}
@@ -0,0 +1,28 @@
+package evmprecompiles
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+// ECMapToG2BLS implements [BLS12_MAP_FP2_TO_G2] precompile contract at address
+// 0x11.
+//
+// It maps the 𝔽p² element u to G2 following the SSWU map of RFC 9380. Both
+// coordinates of u must be given with zero overflow and are asserted to be less
+// than the base field modulus.
+//
+// [BLS12_MAP_FP2_TO_G2]: https://eips.ethereum.org/EIPS/eip-2537#abi-for-mapping-fp2-element-to-g2-point
+func ECMapToG2BLS(api frontend.API, u *fields_bls12381.E2) *sw_bls12381.G2Affine {
+	fpField, err := emulated.NewField[sw_bls12381.BaseField](api)
+	if err != nil {
+		panic(fmt.Sprintf("new base field: %v", err))
+	}
+	fpField.AssertIsInRange(&u.A0)
+	fpField.AssertIsInRange(&u.A1)
+	return sw_bls12381.NewG2(api).MapToG2(u)
+}
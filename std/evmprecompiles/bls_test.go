@@ -0,0 +1,304 @@
+package evmprecompiles
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+func randomG1BLS() (bls12381.G1Affine, fr.Element) {
+	_, _, g1, _ := bls12381.Generators()
+	var s fr.Element
+	s.SetRandom()
+	var p bls12381.G1Affine
+	p.ScalarMultiplication(&g1, s.BigInt(new(big.Int)))
+	return p, s
+}
+
+func randomG2BLS() (bls12381.G2Affine, fr.Element) {
+	_, _, _, g2 := bls12381.Generators()
+	var s fr.Element
+	s.SetRandom()
+	var p bls12381.G2Affine
+	p.ScalarMultiplication(&g2, s.BigInt(new(big.Int)))
+	return p, s
+}
+
+type ecaddG1BLSCircuit struct {
+	X0       sw_bls12381.G1Affine
+	X1       sw_bls12381.G1Affine
+	Expected sw_bls12381.G1Affine
+}
+
+func (c *ecaddG1BLSCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	res := ECAddG1BLS(api, &c.X0, &c.X1)
+	curve.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECAddG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, _ := randomG1BLS()
+	Q, _ := randomG1BLS()
+	var infinity, sum bls12381.G1Affine
+	sum.Add(&P, &Q)
+	for _, tc := range [][3]bls12381.G1Affine{{P, Q, sum}, {P, infinity, P}, {infinity, infinity, infinity}} {
+		witness := ecaddG1BLSCircuit{
+			X0:       sw_bls12381.NewG1Affine(tc[0]),
+			X1:       sw_bls12381.NewG1Affine(tc[1]),
+			Expected: sw_bls12381.NewG1Affine(tc[2]),
+		}
+		err := test.IsSolved(&ecaddG1BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type ecmsmG1BLSCircuit struct {
+	Points   [3]sw_bls12381.G1Affine
+	Scalars  [3]sw_bls12381.Scalar
+	Expected sw_bls12381.G1Affine
+}
+
+func (c *ecmsmG1BLSCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	P := make([]*sw_bls12381.G1Affine, len(c.Points))
+	s := make([]*sw_bls12381.Scalar, len(c.Scalars))
+	for i := range c.Points {
+		P[i] = &c.Points[i]
+		s[i] = &c.Scalars[i]
+	}
+	res := ECMSMG1BLS(api, P, s)
+	curve.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMSMG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P0, s0 := randomG1BLS()
+	P1, s1 := randomG1BLS()
+	_, s2 := randomG1BLS()
+	// the last point is at infinity
+	var expected, tmp bls12381.G1Affine
+	expected.ScalarMultiplication(&P0, s0.BigInt(new(big.Int)))
+	tmp.ScalarMultiplication(&P1, s1.BigInt(new(big.Int)))
+	expected.Add(&expected, &tmp)
+	witness := ecmsmG1BLSCircuit{
+		Points:   [3]sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(P0), sw_bls12381.NewG1Affine(P1), sw_bls12381.NewG1Affine(bls12381.G1Affine{})},
+		Scalars:  [3]sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(s1), sw_bls12381.NewScalar(s2)},
+		Expected: sw_bls12381.NewG1Affine(expected),
+	}
+	err := test.IsSolved(&ecmsmG1BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestECMSMG1BLSNotInSubgroup(t *testing.T) {
+	assert := test.NewAssert(t)
+	// a point on the curve but not in G1
+	var x fp.Element
+	var P bls12381.G1Affine
+	for {
+		x.SetRandom()
+		var y, y2 fp.Element
+		y2.Square(&x).Mul(&y2, &x).Add(&y2, new(fp.Element).SetUint64(4))
+		if y.Sqrt(&y2) != nil {
+			P.X, P.Y = x, y
+			break
+		}
+	}
+	if P.IsInSubGroup() {
+		t.Skip("sampled point in subgroup")
+	}
+	var s fr.Element
+	s.SetOne()
+	witness := ecmsmG1BLSCircuit{
+		Points:   [3]sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(P), sw_bls12381.NewG1Affine(P), sw_bls12381.NewG1Affine(P)},
+		Scalars:  [3]sw_bls12381.Scalar{sw_bls12381.NewScalar(s), sw_bls12381.NewScalar(s), sw_bls12381.NewScalar(s)},
+		Expected: sw_bls12381.NewG1Affine(P),
+	}
+	err := test.IsSolved(&ecmsmG1BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type ecaddG2BLSCircuit struct {
+	X0       sw_bls12381.G2Affine
+	X1       sw_bls12381.G2Affine
+	Expected sw_bls12381.G2Affine
+}
+
+func (c *ecaddG2BLSCircuit) Define(api frontend.API) error {
+	res := ECAddG2BLS(api, &c.X0, &c.X1)
+	sw_bls12381.NewG2(api).AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECAddG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	P, _ := randomG2BLS()
+	Q, _ := randomG2BLS()
+	var infinity, sum bls12381.G2Affine
+	sum.Add(&P, &Q)
+	for _, tc := range [][3]bls12381.G2Affine{{P, Q, sum}, {infinity, Q, Q}} {
+		witness := ecaddG2BLSCircuit{
+			X0:       sw_bls12381.NewG2Affine(tc[0]),
+			X1:       sw_bls12381.NewG2Affine(tc[1]),
+			Expected: sw_bls12381.NewG2Affine(tc[2]),
+		}
+		err := test.IsSolved(&ecaddG2BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type ecmsmG2BLSCircuit struct {
+	Points   [2]sw_bls12381.G2Affine
+	Scalars  [2]sw_bls12381.Scalar
+	Expected sw_bls12381.G2Affine
+}
+
+func (c *ecmsmG2BLSCircuit) Define(api frontend.API) error {
+	Q := make([]*sw_bls12381.G2Affine, len(c.Points))
+	s := make([]*sw_bls12381.Scalar, len(c.Scalars))
+	for i := range c.Points {
+		Q[i] = &c.Points[i]
+		s[i] = &c.Scalars[i]
+	}
+	res := ECMSMG2BLS(api, Q, s)
+	sw_bls12381.NewG2(api).AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMSMG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	Q0, s0 := randomG2BLS()
+	_, s1 := randomG2BLS()
+	// the last point is at infinity
+	var expected bls12381.G2Affine
+	expected.ScalarMultiplication(&Q0, s0.BigInt(new(big.Int)))
+	witness := ecmsmG2BLSCircuit{
+		Points:   [2]sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(Q0), sw_bls12381.NewG2Affine(bls12381.G2Affine{})},
+		Scalars:  [2]sw_bls12381.Scalar{sw_bls12381.NewScalar(s0), sw_bls12381.NewScalar(s1)},
+		Expected: sw_bls12381.NewG2Affine(expected),
+	}
+	err := test.IsSolved(&ecmsmG2BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type ecpairBLSCircuit struct {
+	P        [2]sw_bls12381.G1Affine
+	Q        [2]sw_bls12381.G2Affine
+	Expected frontend.Variable
+}
+
+func (c *ecpairBLSCircuit) Define(api frontend.API) error {
+	P := []*sw_bls12381.G1Affine{&c.P[0], &c.P[1]}
+	Q := []*sw_bls12381.G2Affine{&c.Q[0], &c.Q[1]}
+	res := ECPairBLS(api, P, Q)
+	api.AssertIsEqual(res, c.Expected)
+	return nil
+}
+
+func TestECPairBLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	_, _, g1, g2 := bls12381.Generators()
+	var s fr.Element
+	s.SetRandom()
+	var sG1, s2G1, negG1 bls12381.G1Affine
+	var sQ bls12381.G2Affine
+	sG1.ScalarMultiplication(&g1, s.BigInt(new(big.Int)))
+	sQ.ScalarMultiplication(&g2, s.BigInt(new(big.Int)))
+	negG1.Neg(&g1)
+	s2G1.Double(&sG1)
+	var infinity1 bls12381.G1Affine
+	var infinity2 bls12381.G2Affine
+
+	testCases := []struct {
+		P        [2]bls12381.G1Affine
+		Q        [2]bls12381.G2Affine
+		expected int
+	}{
+		// e([s]G₁, G₂)⋅e(-G₁, [s]G₂) == 1
+		{[2]bls12381.G1Affine{sG1, negG1}, [2]bls12381.G2Affine{g2, sQ}, 1},
+		// e([2s]G₁, G₂)⋅e(-G₁, [s]G₂) != 1
+		{[2]bls12381.G1Affine{s2G1, negG1}, [2]bls12381.G2Affine{g2, sQ}, 0},
+		// e(0, G₂)⋅e(G₁, 0) == 1
+		{[2]bls12381.G1Affine{infinity1, g1}, [2]bls12381.G2Affine{g2, infinity2}, 1},
+		// e([s]G₁, G₂)⋅e(G₁, 0) != 1
+		{[2]bls12381.G1Affine{sG1, g1}, [2]bls12381.G2Affine{g2, infinity2}, 0},
+	}
+	for _, tc := range testCases {
+		witness := ecpairBLSCircuit{
+			P:        [2]sw_bls12381.G1Affine{sw_bls12381.NewG1Affine(tc.P[0]), sw_bls12381.NewG1Affine(tc.P[1])},
+			Q:        [2]sw_bls12381.G2Affine{sw_bls12381.NewG2Affine(tc.Q[0]), sw_bls12381.NewG2Affine(tc.Q[1])},
+			Expected: tc.expected,
+		}
+		err := test.IsSolved(&ecpairBLSCircuit{}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+type ecmapToG1BLSCircuit struct {
+	U        emulated.Element[sw_bls12381.BaseField]
+	Expected sw_bls12381.G1Affine
+}
+
+func (c *ecmapToG1BLSCircuit) Define(api frontend.API) error {
+	curve, err := sw_emulated.New[sw_bls12381.BaseField, sw_bls12381.ScalarField](api, sw_emulated.GetBLS12381Params())
+	if err != nil {
+		return err
+	}
+	res := ECMapToG1BLS(api, &c.U)
+	curve.AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMapToG1BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u fp.Element
+	u.SetRandom()
+	witness := ecmapToG1BLSCircuit{
+		U:        emulated.ValueOf[sw_bls12381.BaseField](u),
+		Expected: sw_bls12381.NewG1Affine(bls12381.MapToG1(u)),
+	}
+	err := test.IsSolved(&ecmapToG1BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type ecmapToG2BLSCircuit struct {
+	U        fields_bls12381.E2
+	Expected sw_bls12381.G2Affine
+}
+
+func (c *ecmapToG2BLSCircuit) Define(api frontend.API) error {
+	res := ECMapToG2BLS(api, &c.U)
+	sw_bls12381.NewG2(api).AssertIsEqual(res, &c.Expected)
+	return nil
+}
+
+func TestECMapToG2BLSCircuitShort(t *testing.T) {
+	assert := test.NewAssert(t)
+	var u bls12381.E2
+	u.A0.SetRandom()
+	u.A1.SetRandom()
+	witness := ecmapToG2BLSCircuit{
+		U:        fields_bls12381.FromE2(&u),
+		Expected: sw_bls12381.NewG2Affine(bls12381.MapToG2(u)),
+	}
+	err := test.IsSolved(&ecmapToG2BLSCircuit{}, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
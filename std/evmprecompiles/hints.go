@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
@@ -17,7 +18,7 @@ func init() {
 
 // GetHints returns all the hints used in this package.
 func GetHints() []solver.Hint {
-	return []solver.Hint{recoverPublicKeyHint}
+	return []solver.Hint{recoverPublicKeyHint, decompressG1BLSHint}
 }
 
 func recoverPublicKeyHintArgs(msg emulated.Element[emulated.Secp256k1Fr],
@@ -77,3 +78,28 @@ func recoverPublicKeyHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) err
 	outputs[2*emfp.NbLimbs()].SetInt64(int64(isZero))
 	return nil
 }
+
+func decompressG1BLSHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	var emfp emulated.BLS12381Fp
+	if len(inputs) != bls12381.SizeOfG1AffineCompressed {
+		return fmt.Errorf("expected %d input bytes got %d", bls12381.SizeOfG1AffineCompressed, len(inputs))
+	}
+	if len(outputs) != int(emfp.NbLimbs()) {
+		return fmt.Errorf("expected %d output limbs got %d", emfp.NbLimbs(), len(outputs))
+	}
+	var buf [bls12381.SizeOfG1AffineCompressed]byte
+	for i := range inputs {
+		if !inputs[i].IsUint64() || inputs[i].Uint64() > 0xff {
+			return fmt.Errorf("input %d is not a byte", i)
+		}
+		buf[i] = byte(inputs[i].Uint64())
+	}
+	var P bls12381.G1Affine
+	if _, err := P.SetBytes(buf[:]); err != nil {
+		return fmt.Errorf("decompress: %w", err)
+	}
+	if err := decompose(P.Y.BigInt(new(big.Int)), emfp.BitsPerLimb(), outputs); err != nil {
+		return fmt.Errorf("decompose y: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+// Package blake2b implements BLAKE2b hash computation as defined in RFC 7693.
+//
+// This package extends the BLAKE2b compression function [blake2b] into a full
+// unkeyed BLAKE2b hash. The digests correspond to
+// golang.org/x/crypto/blake2b.
+package blake2b
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/hash/internal/varlen"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake2b"
+)
+
+const (
+	blockSize = 128
+	nbRounds  = 12
+)
+
+type digest struct {
+	api  frontend.API
+	uapi *uints.BinaryField[uints.U64]
+	in   []uints.U8
+	size int
+}
+
+// New256 returns a new BLAKE2b-256 hasher.
+func New256(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest(api, 32)
+}
+
+// New384 returns a new BLAKE2b-384 hasher.
+func New384(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest(api, 48)
+}
+
+// New512 returns a new BLAKE2b-512 hasher.
+func New512(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest(api, 64)
+}
+
+func newDigest(api frontend.API, size int) (hash.BinaryFixedLengthHasher, error) {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return nil, err
+	}
+	return &digest{api: api, uapi: uapi, size: size}, nil
+}
+
+func (d *digest) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+// nbBlocks returns the number of blocks for the current input. The empty input
+// is hashed as a single zero block.
+func (d *digest) nbBlocks() int {
+	return max(1, (len(d.in)+blockSize-1)/blockSize)
+}
+
+// padded returns the input padded with zeros to a whole number of blocks.
+func (d *digest) padded(in []uints.U8) []uints.U8 {
+	buf := make([]uints.U8, 0, d.nbBlocks()*blockSize)
+	buf = append(buf, in...)
+	return append(buf, uints.NewU8Array(make([]uint8, cap(buf)-len(buf)))...)
+}
+
+func (d *digest) initialState() [8]uints.U64 {
+	var h [8]uints.U64
+	for i := range h {
+		h[i] = uints.NewU64(blake2b.IV[i])
+	}
+	// parameter block: digest length, no key, fanout and depth 1
+	h[0] = uints.NewU64(blake2b.IV[0] ^ 0x01010000 ^ uint64(d.size))
+	return h
+}
+
+func (d *digest) message(block []uints.U8) [16]uints.U64 {
+	var m [16]uints.U64
+	for i := range m {
+		m[i] = d.uapi.PackLSB(block[8*i : 8*i+8]...)
+	}
+	return m
+}
+
+func (d *digest) output(h [8]uints.U64) []uints.U8 {
+	var ret []uints.U8
+	for i := range h {
+		ret = append(ret, d.uapi.UnpackLSB(h[i])...)
+	}
+	return ret[:d.size]
+}
+
+func (d *digest) Sum() []uints.U8 {
+	padded := d.padded(d.in)
+	h := d.initialState()
+	for i := 0; i < d.nbBlocks(); i++ {
+		counter, final := uint64((i+1)*blockSize), 0
+		if i == d.nbBlocks()-1 {
+			counter, final = uint64(len(d.in)), 1
+		}
+		t := [2]uints.U64{uints.NewU64(counter), uints.NewU64(0)}
+		h = blake2b.Compress(d.uapi, d.api, nbRounds, h, d.message(padded[i*blockSize:]), t, final)
+	}
+	return d.output(h)
+}
+
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the number of bytes written.
+func (d *digest) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	nbBlocks := d.nbBlocks()
+	mask := varlen.Mask(d.api, length, len(d.in), nbBlocks*blockSize+1)
+	padded := d.padded(varlen.MaskBytes(d.api, d.in, mask))
+	last := varlen.LastBlock(d.api, mask, blockSize, nbBlocks)
+	lengthBytes := d.uapi.ValueOf(length)
+	h := d.initialState()
+	outputs := make([][]uints.U8, nbBlocks)
+	for i := 0; i < nbBlocks; i++ {
+		// the counter is the number of bytes hashed so far, which is length for
+		// the last block
+		counter := uints.NewU64(uint64((i + 1) * blockSize))
+		for j := range counter {
+			counter[j] = uints.U8{Val: d.api.Select(last[i], lengthBytes[j].Val, counter[j].Val)}
+		}
+		t := [2]uints.U64{counter, uints.NewU64(0)}
+		h = blake2b.Compress(d.uapi, d.api, nbRounds, h, d.message(padded[i*blockSize:]), t, last[i])
+		outputs[i] = d.output(h)
+	}
+	return varlen.Select(d.api, last, outputs)
+}
+
+func (d *digest) Reset() {
+	d.in = nil
+}
+
+func (d *digest) Size() int { return d.size }
@@ -0,0 +1,104 @@
+package blake2b
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2b"
+)
+
+type blake2bCircuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+}
+
+func (c *blake2bCircuit) Define(api frontend.API) error {
+	var h hash.BinaryFixedLengthHasher
+	var err error
+	if len(c.Expected) == 32 {
+		h, err = New256(api)
+	} else {
+		h, err = New512(api)
+	}
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBLAKE2b(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, l := range []int{0, 3, 127, 128, 129, 300} {
+		bts := make([]byte, l)
+		for i := range bts {
+			bts[i] = byte(i)
+		}
+		dgst256, dgst512 := blake2b.Sum256(bts), blake2b.Sum512(bts)
+		for _, dgst := range [][]byte{dgst256[:], dgst512[:]} {
+			witness := blake2bCircuit{
+				In:       uints.NewU8Array(bts),
+				Expected: uints.NewU8Array(dgst),
+			}
+			err := test.IsSolved(&blake2bCircuit{In: make([]uints.U8, l), Expected: make([]uints.U8, len(dgst))}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err, "length %d", l)
+		}
+	}
+}
+
+type blake2bFixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected [64]uints.U8
+}
+
+func (c *blake2bFixedLengthCircuit) Define(api frontend.API) error {
+	h, err := New512(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBLAKE2bFixedLength(t *testing.T) {
+	assert := test.NewAssert(t)
+	bts := make([]byte, 300)
+	for i := range bts {
+		bts[i] = byte(i)
+	}
+	for _, l := range []int{0, 1, 128, 129, 256, 300} {
+		dgst := blake2b.Sum512(bts[:l])
+		witness := blake2bFixedLengthCircuit{
+			In:     uints.NewU8Array(bts),
+			Length: l,
+		}
+		copy(witness.Expected[:], uints.NewU8Array(dgst[:]))
+		err := test.IsSolved(&blake2bFixedLengthCircuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, "length %d", l)
+	}
+	// length larger than the input
+	witness := blake2bFixedLengthCircuit{In: uints.NewU8Array(bts), Length: 301}
+	err := test.IsSolved(&blake2bFixedLengthCircuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -0,0 +1,97 @@
+package blake2s
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+	"golang.org/x/crypto/blake2s"
+)
+
+type blake2sCircuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+}
+
+func (c *blake2sCircuit) Define(api frontend.API) error {
+	h, err := New256(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBLAKE2s(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, l := range []int{0, 3, 63, 64, 65, 150} {
+		bts := make([]byte, l)
+		for i := range bts {
+			bts[i] = byte(i)
+		}
+		dgst256, dgst512 := blake2s.Sum256(bts), blake2s.Sum256(bts)
+		for _, dgst := range [][]byte{dgst256[:], dgst512[:]} {
+			witness := blake2sCircuit{
+				In:       uints.NewU8Array(bts),
+				Expected: uints.NewU8Array(dgst),
+			}
+			err := test.IsSolved(&blake2sCircuit{In: make([]uints.U8, l), Expected: make([]uints.U8, len(dgst))}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err, "length %d", l)
+		}
+	}
+}
+
+type blake2sFixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected [32]uints.U8
+}
+
+func (c *blake2sFixedLengthCircuit) Define(api frontend.API) error {
+	h, err := New256(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBLAKE2sFixedLength(t *testing.T) {
+	assert := test.NewAssert(t)
+	bts := make([]byte, 150)
+	for i := range bts {
+		bts[i] = byte(i)
+	}
+	for _, l := range []int{0, 1, 64, 65, 128, 150} {
+		dgst := blake2s.Sum256(bts[:l])
+		witness := blake2sFixedLengthCircuit{
+			In:     uints.NewU8Array(bts),
+			Length: l,
+		}
+		copy(witness.Expected[:], uints.NewU8Array(dgst[:]))
+		err := test.IsSolved(&blake2sFixedLengthCircuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, "length %d", l)
+	}
+	// length larger than the input
+	witness := blake2sFixedLengthCircuit{In: uints.NewU8Array(bts), Length: 151}
+	err := test.IsSolved(&blake2sFixedLengthCircuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
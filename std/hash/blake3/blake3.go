@@ -0,0 +1,276 @@
+// Package blake3 implements BLAKE3 hash computation.
+//
+// This package extends the BLAKE3 compression function [blake3] into the full
+// BLAKE3 hash in the default unkeyed mode with 32 bytes of output. Inputs
+// longer than a chunk of 1024 bytes are hashed using the BLAKE3 binary tree.
+package blake3
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/hash/internal/varlen"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/blake3"
+)
+
+const (
+	blockSize      = 64
+	chunkSize      = 1024
+	blocksPerChunk = chunkSize / blockSize
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+)
+
+type digest struct {
+	api  frontend.API
+	uapi *uints.BinaryField[uints.U32]
+	in   []uints.U8
+}
+
+// New returns a new BLAKE3 hasher.
+func New(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, err
+	}
+	return &digest{api: api, uapi: uapi}, nil
+}
+
+func (d *digest) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+// nbChunks returns the number of chunks for the current input. The empty input
+// is hashed as a single empty chunk.
+func (d *digest) nbChunks() int {
+	return max(1, (len(d.in)+chunkSize-1)/chunkSize)
+}
+
+func iv() [8]uints.U32 {
+	var ret [8]uints.U32
+	for i := range ret {
+		ret[i] = uints.NewU32(blake3.IV[i])
+	}
+	return ret
+}
+
+func counter(i int) [2]uints.U32 {
+	return [2]uints.U32{uints.NewU32(uint32(i)), uints.NewU32(uint32(uint64(i) >> 32))}
+}
+
+// word returns the word whose least significant byte is v and the other bytes
+// zero. The value v must fit in a byte.
+func word(v frontend.Variable) uints.U32 {
+	return uints.U32{uints.U8{Val: v}, uints.NewU8(0), uints.NewU8(0), uints.NewU8(0)}
+}
+
+// pad returns in padded with zeros to n bytes.
+func pad(in []uints.U8, n int) []uints.U8 {
+	return append(append([]uints.U8{}, in...), uints.NewU8Array(make([]uint8, n-len(in)))...)
+}
+
+func (d *digest) message(block []uints.U8) [16]uints.U32 {
+	var m [16]uints.U32
+	for i := range m {
+		m[i] = d.uapi.PackLSB(block[4*i : 4*i+4]...)
+	}
+	return m
+}
+
+func (d *digest) output(cv [8]uints.U32) []uints.U8 {
+	var ret []uints.U8
+	for i := range cv {
+		ret = append(ret, d.uapi.UnpackLSB(cv[i])...)
+	}
+	return ret
+}
+
+// chunk returns the chaining value of the chunk of the given index with the
+// data known at compile time.
+func (d *digest) chunk(data []uints.U8, index int, root bool) [8]uints.U32 {
+	nbBlocks := max(1, (len(data)+blockSize-1)/blockSize)
+	padded := pad(data, nbBlocks*blockSize)
+	cv := iv()
+	for j := 0; j < nbBlocks; j++ {
+		blockLen, flags := blockSize, 0
+		if j == 0 {
+			flags |= flagChunkStart
+		}
+		if j == nbBlocks-1 {
+			blockLen = len(data) - j*blockSize
+			flags |= flagChunkEnd
+			if root {
+				flags |= flagRoot
+			}
+		}
+		cv = blake3.Compress(d.uapi, cv, d.message(padded[j*blockSize:]), counter(index), uints.NewU32(uint32(blockLen)), uints.NewU32(uint32(flags)))
+	}
+	return cv
+}
+
+func (d *digest) parent(left, right [8]uints.U32, flags uint32) [8]uints.U32 {
+	var m [16]uints.U32
+	copy(m[:8], left[:])
+	copy(m[8:], right[:])
+	return blake3.Compress(d.uapi, iv(), m, counter(0), uints.NewU32(blockSize), uints.NewU32(flagParent|flags))
+}
+
+// tree computes the parent nodes of the BLAKE3 tree over the chaining values of
+// the chunks. The subtrees are memoized so that the roots of the trees over
+// the first c chunks for different c share their common nodes.
+type tree struct {
+	d    *digest
+	cvs  [][8]uints.U32
+	memo map[[2]int][8]uints.U32
+}
+
+func newTree(d *digest, cvs [][8]uints.U32) *tree {
+	return &tree{d: d, cvs: cvs, memo: make(map[[2]int][8]uints.U32)}
+}
+
+// leftSize returns the number of chunks in the left subtree of a tree of count
+// chunks, that is the largest power of two less than count.
+func leftSize(count int) int {
+	return 1 << (bits.Len(uint(count-1)) - 1)
+}
+
+// subtree returns the chaining value of the non-root subtree over count chunks
+// starting at start.
+func (t *tree) subtree(start, count int) [8]uints.U32 {
+	if count == 1 {
+		return t.cvs[start]
+	}
+	if cv, ok := t.memo[[2]int{start, count}]; ok {
+		return cv
+	}
+	l := leftSize(count)
+	cv := t.d.parent(t.subtree(start, l), t.subtree(start+l, count-l), 0)
+	t.memo[[2]int{start, count}] = cv
+	return cv
+}
+
+// root returns the root output of the tree over the first count ≥ 2 chunks.
+func (t *tree) root(count int) [8]uints.U32 {
+	l := leftSize(count)
+	return t.d.parent(t.subtree(0, l), t.subtree(l, count-l), flagRoot)
+}
+
+func (d *digest) Sum() []uints.U8 {
+	n := d.nbChunks()
+	if n == 1 {
+		return d.output(d.chunk(d.in, 0, true))
+	}
+	cvs := make([][8]uints.U32, n)
+	for i := range cvs {
+		cvs[i] = d.chunk(d.in[i*chunkSize:min((i+1)*chunkSize, len(d.in))], i, false)
+	}
+	return d.output(newTree(d, cvs).root(n))
+}
+
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the number of bytes written.
+//
+// As the shape of the tree depends on the number of chunks, we compute the
+// root for every possible number of chunks and select the correct one.
+func (d *digest) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	nbChunks := d.nbChunks()
+	nbBlocks := nbChunks * blocksPerChunk
+	mask := varlen.Mask(d.api, length, len(d.in), nbBlocks*blockSize+1)
+	padded := pad(varlen.MaskBytes(d.api, d.in, mask), nbBlocks*blockSize)
+	lastBlock := varlen.LastBlock(d.api, mask, blockSize, nbBlocks)
+	lastChunk := varlen.LastBlock(d.api, mask, chunkSize, nbChunks)
+
+	cvs := make([][8]uints.U32, nbChunks)
+	var rootInputs, rootMessages, rootOutputs [][]uints.U32
+	var rootEnds, rootBlockLen, rootFlags []frontend.Variable
+	for k := range cvs {
+		cv := iv()
+		ends := make([]frontend.Variable, blocksPerChunk)
+		outputs := make([][]uints.U32, blocksPerChunk)
+		for j := range ends {
+			b := k*blocksPerChunk + j
+			// the block ends the chunk if it is the last block of the input or
+			// the last block of a full chunk followed by more input
+			ends[j] = lastBlock[b]
+			if j == blocksPerChunk-1 {
+				ends[j] = d.api.Add(ends[j], mask[(k+1)*chunkSize])
+			}
+			blockLen := d.api.Select(lastBlock[b], d.api.Sub(length, b*blockSize), blockSize)
+			flags := d.api.Mul(ends[j], flagChunkEnd)
+			if j == 0 {
+				flags = d.api.Add(flags, flagChunkStart)
+			}
+			m := d.message(padded[b*blockSize:])
+			if k == 0 {
+				// keep the inputs to recompute the end of the first chunk as
+				// the root when the input fits in a single chunk
+				rootInputs = append(rootInputs, append([]uints.U32{}, cv[:]...))
+				rootMessages = append(rootMessages, m[:])
+				rootBlockLen = append(rootBlockLen, blockLen)
+				rootFlags = append(rootFlags, flags)
+			}
+			cv = blake3.Compress(d.uapi, cv, m, counter(k), word(blockLen), word(flags))
+			outputs[j] = append([]uints.U32{}, cv[:]...)
+		}
+		copy(cvs[k][:], d.selectWords(ends, outputs))
+		if k == 0 {
+			rootEnds = ends
+		}
+	}
+
+	// root when the input fits in a single chunk
+	var rootCV [8]uints.U32
+	var m [16]uints.U32
+	copy(rootCV[:], d.selectWords(rootEnds, rootInputs))
+	copy(m[:], d.selectWords(rootEnds, rootMessages))
+	blockLen := d.selectVariables(rootEnds, rootBlockLen)
+	flags := d.api.Add(d.selectVariables(rootEnds, rootFlags), flagRoot)
+	root := blake3.Compress(d.uapi, rootCV, m, counter(0), word(blockLen), word(flags))
+	rootOutputs = append(rootOutputs, root[:])
+
+	// roots when the input spans several chunks
+	t := newTree(d, cvs)
+	for c := 2; c <= nbChunks; c++ {
+		root := t.root(c)
+		rootOutputs = append(rootOutputs, root[:])
+	}
+	var res [8]uints.U32
+	copy(res[:], d.selectWords(lastChunk, rootOutputs))
+	return d.output(res)
+}
+
+// selectWords returns in[i] for the single index i where selector[i] = 1.
+func (d *digest) selectWords(selector []frontend.Variable, in [][]uints.U32) []uints.U32 {
+	bts := make([][]uints.U8, len(in))
+	for i := range in {
+		for j := range in[i] {
+			bts[i] = append(bts[i], in[i][j][:]...)
+		}
+	}
+	selected := varlen.Select(d.api, selector, bts)
+	res := make([]uints.U32, len(in[0]))
+	for j := range res {
+		copy(res[j][:], selected[4*j:4*j+4])
+	}
+	return res
+}
+
+// selectVariables returns in[i] for the single index i where selector[i] = 1.
+func (d *digest) selectVariables(selector []frontend.Variable, in []frontend.Variable) frontend.Variable {
+	var res frontend.Variable = 0
+	for i := range in {
+		res = d.api.Add(res, d.api.Mul(selector[i], in[i]))
+	}
+	return res
+}
+
+func (d *digest) Reset() {
+	d.in = nil
+}
+
+func (d *digest) Size() int { return 32 }
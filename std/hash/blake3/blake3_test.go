@@ -0,0 +1,111 @@
+package blake3
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+// test vectors for the input i mod 251 of the given length, as in the official
+// BLAKE3 test vectors.
+var testVectors = []struct {
+	length int
+	digest string
+}{
+	{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"},
+	{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213"},
+	{63, "e9bc37a594daad83be9470df7f7b3798297c3d834ce80ba85d6e207627b7db7b"},
+	{64, "4eed7141ea4a5cd4b788606bd23f46e212af9cacebacdc7d1f4c6dc7f2511b98"},
+	{65, "de1e5fa0be70df6d2be8fffd0e99ceaa8eb6e8c93a63f2d8d1c30ecb6b263dee"},
+	{1023, "10108970eeda3eb932baac1428c7a2163b0e924c9a9e25b35bba72b28f70bd11"},
+	{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7"},
+	{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444"},
+	{2048, "e776b6028c7cd22a4d0ba182a8bf62205d2ef576467e838ed6f2529b85fba24a"},
+	{2049, "5f4d72f40d7a5f82b15ca2b2e44b1de3c2ef86c426c95c1af0b6879522563030"},
+	{3073, "7124b49501012f81cc7f11ca069ec9226cecb8a2c850cfe644e327d22d3e1cd3"},
+}
+
+func input(length int) []byte {
+	bts := make([]byte, length)
+	for i := range bts {
+		bts[i] = byte(i % 251)
+	}
+	return bts
+}
+
+type blake3Circuit struct {
+	In       []uints.U8
+	Expected [32]uints.U8
+}
+
+func (c *blake3Circuit) Define(api frontend.API) error {
+	h, err := New(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBLAKE3(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, tv := range testVectors {
+		dgst, err := hex.DecodeString(tv.digest)
+		assert.NoError(err)
+		witness := blake3Circuit{In: uints.NewU8Array(input(tv.length))}
+		copy(witness.Expected[:], uints.NewU8Array(dgst))
+		err = test.IsSolved(&blake3Circuit{In: make([]uints.U8, tv.length)}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, "length %d", tv.length)
+	}
+}
+
+type blake3FixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected [32]uints.U8
+}
+
+func (c *blake3FixedLengthCircuit) Define(api frontend.API) error {
+	h, err := New(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestBLAKE3FixedLength(t *testing.T) {
+	assert := test.NewAssert(t)
+	const maxLength = 2049
+	for _, tv := range testVectors {
+		if tv.length > maxLength {
+			continue
+		}
+		dgst, err := hex.DecodeString(tv.digest)
+		assert.NoError(err)
+		witness := blake3FixedLengthCircuit{In: uints.NewU8Array(input(maxLength)), Length: tv.length}
+		copy(witness.Expected[:], uints.NewU8Array(dgst))
+		err = test.IsSolved(&blake3FixedLengthCircuit{In: make([]uints.U8, maxLength)}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, "length %d", tv.length)
+	}
+}
@@ -0,0 +1,75 @@
+// Package varlen implements helpers for hashing inputs whose length is only
+// known at solving time, as required by the FixedLengthSum method of the binary
+// hashers.
+package varlen
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Mask returns a slice m of n boolean variables where m[i] = 1 if i < length
+// and m[i] = 0 otherwise. It asserts that 0 ≤ length ≤ maxLength.
+func Mask(api frontend.API, length frontend.Variable, maxLength, n int) []frontend.Variable {
+	res := make([]frontend.Variable, n)
+	// acc = 1 if i ≥ length and 0 otherwise
+	var acc frontend.Variable = 0
+	for i := 0; i <= maxLength || i < n; i++ {
+		if i <= maxLength {
+			acc = api.Add(acc, api.IsZero(api.Sub(length, i)))
+		}
+		if i < n {
+			res[i] = api.Sub(1, acc)
+		}
+	}
+	// length is exactly one of 0, …, maxLength
+	api.AssertIsEqual(acc, 1)
+	return res
+}
+
+// MaskBytes returns the bytes of in where the bytes at positions with mask 0
+// are set to zero. The mask must be boolean and at least as long as in.
+func MaskBytes(api frontend.API, in []uints.U8, mask []frontend.Variable) []uints.U8 {
+	res := make([]uints.U8, len(in))
+	for i := range in {
+		res[i] = uints.U8{Val: api.Mul(in[i].Val, mask[i])}
+	}
+	return res
+}
+
+// LastBlock returns a slice s of nbBlocks boolean variables where s[i] = 1 if
+// the block i of blockSize bytes contains the last byte of the input described
+// by mask (as returned by [Mask]) and s[i] = 0 otherwise. For the empty input,
+// s[0] = 1. The mask must have at least nbBlocks*blockSize+1 elements.
+func LastBlock(api frontend.API, mask []frontend.Variable, blockSize, nbBlocks int) []frontend.Variable {
+	res := make([]frontend.Variable, nbBlocks)
+	for i := range res {
+		// length > i*blockSize, except for the first block which is also the
+		// last one for the empty input
+		var started frontend.Variable = 1
+		if i > 0 {
+			started = mask[i*blockSize]
+		}
+		// length ≤ (i+1)*blockSize
+		res[i] = api.Mul(started, api.Sub(1, mask[(i+1)*blockSize]))
+	}
+	return res
+}
+
+// Select returns the bytes ∑ᵢ selector[i]⋅in[i], that is in[i] for the single
+// index i where selector[i] = 1. The selector must be one-hot.
+func Select(api frontend.API, selector []frontend.Variable, in [][]uints.U8) []uints.U8 {
+	res := make([]uints.U8, len(in[0]))
+	for j := range res {
+		terms := make([]frontend.Variable, len(in))
+		for i := range in {
+			terms[i] = api.Mul(selector[i], in[i][j].Val)
+		}
+		if len(terms) == 1 {
+			res[j] = uints.U8{Val: terms[0]}
+		} else {
+			res[j] = uints.U8{Val: api.Add(terms[0], terms[1], terms[2:]...)}
+		}
+	}
+	return res
+}
@@ -0,0 +1,92 @@
+package poseidon
+
+import (
+	"errors"
+	stdhash "hash"
+	"math/big"
+
+	"github.com/consensys/gnark/std/permutation/poseidon"
+)
+
+type digest struct {
+	modulus  *big.Int
+	h        *big.Int   // digest of the data already hashed, nil if none
+	nbChunks int        // number of chunks already hashed
+	data     []*big.Int // data to be hashed in the next call to Sum
+}
+
+// NewNative returns the native counterpart of [Poseidon] over the field with
+// the given modulus, for example to compute the expected digest when
+// generating a witness.
+//
+// The input is written as big-endian encodings of field elements of
+// [stdhash.Hash.BlockSize] bytes each. As for [Poseidon], Sum flushes the data
+// and the digest is used as first input for the data written afterwards.
+func NewNative(modulus *big.Int) (stdhash.Hash, error) {
+	if _, err := poseidon.GetParameters(modulus, poseidon.MinWidth); err != nil {
+		return nil, err
+	}
+	return &digest{modulus: new(big.Int).Set(modulus)}, nil
+}
+
+// Write appends the field elements encoded in p to the data to be hashed. It
+// returns an error if the length of p is not a multiple of the block size or if
+// an element is not reduced.
+func (d *digest) Write(p []byte) (int, error) {
+	if len(p)%d.BlockSize() != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements")
+	}
+	var elems []*big.Int
+	for i := 0; i < len(p); i += d.BlockSize() {
+		x := new(big.Int).SetBytes(p[i : i+d.BlockSize()])
+		if x.Cmp(d.modulus) >= 0 {
+			return 0, errors.New("not a field element")
+		}
+		elems = append(elems, x)
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+// Sum appends the digest of the data written so far to b.
+func (d *digest) Sum(b []byte) []byte {
+	for len(d.data) > 0 {
+		// the index of the chunk separates chained chunks from a single chunk
+		state := []*big.Int{big.NewInt(int64(d.nbChunks))}
+		if d.h != nil {
+			state = append(state, d.h)
+		}
+		n := min(maxInputs+1-len(state), len(d.data))
+		state = append(state, d.data[:n]...)
+		d.data = d.data[n:]
+		params, err := poseidon.GetParameters(d.modulus, len(state))
+		if err != nil {
+			panic(err)
+		}
+		if err = params.Permute(state); err != nil {
+			panic(err)
+		}
+		d.h = state[0]
+		d.nbChunks++
+	}
+	d.data = nil
+	res := make([]byte, d.Size())
+	if d.h != nil {
+		d.h.FillBytes(res)
+	}
+	return append(b, res...)
+}
+
+func (d *digest) Reset() {
+	d.h = nil
+	d.nbChunks = 0
+	d.data = nil
+}
+
+func (d *digest) Size() int {
+	return (d.modulus.BitLen() + 7) / 8
+}
+
+func (d *digest) BlockSize() int {
+	return d.Size()
+}
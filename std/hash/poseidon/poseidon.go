@@ -0,0 +1,102 @@
+// Package poseidon provides a ZKP-circuit function to compute a Poseidon hash.
+//
+// The digest of n ≤ 16 elements x₁, …, xₙ is the first element of the state
+// after applying the Poseidon permutation of width n+1 to [0, x₁, …, xₙ]. For
+// BN254 this coincides with Poseidon(n) of circomlib. Longer inputs are
+// absorbed by chunks of at most 15 elements, each chunk being hashed together
+// with the previous digest as first element. The first element of the state is
+// then the index k ≥ 1 of the chunk instead of 0, so that the digest of a
+// chained chunk differs from the digest of a single chunk with the same
+// elements: the digest of x₁, …, x₁₇ is the first element of the permutation of
+// [1, H(x₁, …, x₁₆), x₁₇], while H(H(x₁, …, x₁₆), x₁₇) permutes
+// [0, H(x₁, …, x₁₆), x₁₇].
+//
+// The hash function is registered as "poseidon" in [hash.GetFieldHasher]. See
+// [NewNative] for the native counterpart.
+package poseidon
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/permutation/poseidon"
+)
+
+func init() {
+	hash.Register("poseidon", func(api frontend.API) (hash.FieldHasher, error) {
+		h, err := NewPoseidon(api)
+		if err != nil {
+			return nil, err
+		}
+		return h, nil
+	})
+}
+
+// maxInputs is the maximal number of inputs hashed with a single permutation.
+const maxInputs = poseidon.MaxWidth - 1
+
+// Poseidon computes the Poseidon hash of native field elements in-circuit. It
+// implements [hash.FieldHasher].
+type Poseidon struct {
+	api      frontend.API
+	h        frontend.Variable   // digest of the data already hashed, nil if none
+	nbChunks int                 // number of chunks already hashed
+	data     []frontend.Variable // data to be hashed in the next call to Sum
+}
+
+// NewPoseidon returns a new Poseidon hasher over the native field of api. It
+// returns an error if the native field is not the scalar field of BN254,
+// BLS12-377 or BLS12-381.
+func NewPoseidon(api frontend.API) (*Poseidon, error) {
+	if _, err := poseidon.GetParameters(api.Compiler().Field(), poseidon.MinWidth); err != nil {
+		return nil, err
+	}
+	return &Poseidon{api: api}, nil
+}
+
+// Write adds more data to the running hash.
+func (h *Poseidon) Write(data ...frontend.Variable) {
+	h.data = append(h.data, data...)
+}
+
+// Reset resets the hasher to its initial state.
+func (h *Poseidon) Reset() {
+	h.data = nil
+	h.h = nil
+	h.nbChunks = 0
+}
+
+// Sum returns the digest of the data written so far. The data is flushed and
+// the digest is used as first input for the data written afterwards. If no
+// data has been written, it returns 0.
+func (h *Poseidon) Sum() frontend.Variable {
+	for len(h.data) > 0 {
+		var inputs []frontend.Variable
+		if h.h != nil {
+			inputs = append(inputs, h.h)
+		}
+		n := min(maxInputs-len(inputs), len(h.data))
+		inputs = append(inputs, h.data[:n]...)
+		h.data = h.data[n:]
+		h.h = h.hash(h.nbChunks, inputs)
+		h.nbChunks++
+	}
+	h.data = nil
+	if h.h == nil {
+		return 0
+	}
+	return h.h
+}
+
+// hash returns the first element of the permutation of [k, inputs...], where k
+// is the index of the chunk.
+func (h *Poseidon) hash(k int, inputs []frontend.Variable) frontend.Variable {
+	perm, err := poseidon.NewPermutation(h.api, len(inputs)+1)
+	if err != nil {
+		panic(err)
+	}
+	res, err := perm.Permute(append([]frontend.Variable{k}, inputs...))
+	if err != nil {
+		panic(err)
+	}
+	return res[0]
+}
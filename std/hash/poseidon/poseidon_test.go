@@ -0,0 +1,100 @@
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/test"
+)
+
+type poseidonCircuit struct {
+	In       []frontend.Variable
+	Expected frontend.Variable
+}
+
+func (c *poseidonCircuit) Define(api frontend.API) error {
+	h, err := hash.GetFieldHasher("poseidon", api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In...)
+	api.AssertIsEqual(h.Sum(), c.Expected)
+	return nil
+}
+
+func TestPoseidon(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381} {
+		for _, n := range []int{0, 1, 2, 16, 17, 40} {
+			native, err := NewNative(curve.ScalarField())
+			assert.NoError(err)
+			witness := poseidonCircuit{In: make([]frontend.Variable, n)}
+			for i := range witness.In {
+				buf := make([]byte, native.BlockSize())
+				big.NewInt(int64(i)).FillBytes(buf)
+				_, err = native.Write(buf)
+				assert.NoError(err)
+				witness.In[i] = i
+			}
+			witness.Expected = new(big.Int).SetBytes(native.Sum(nil))
+			circuit := poseidonCircuit{In: make([]frontend.Variable, n)}
+			assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(curve))
+		}
+	}
+}
+
+func TestNativeCircomlib(t *testing.T) {
+	assert := test.NewAssert(t)
+	h, err := NewNative(ecc.BN254.ScalarField())
+	assert.NoError(err)
+	buf := make([]byte, 2*h.BlockSize())
+	buf[h.BlockSize()-1] = 1
+	buf[2*h.BlockSize()-1] = 2
+	_, err = h.Write(buf)
+	assert.NoError(err)
+	assert.Equal("7853200120776062878684798364095072458815029376092732009249414926327459813530", new(big.Int).SetBytes(h.Sum(nil)).String())
+}
+
+func TestChainingCollision(t *testing.T) {
+	// the digest of x₁, …, x₁₇ is computed by chaining two chunks, it must
+	// differ from the digest of [H(x₁, …, x₁₆), x₁₇].
+	assert := test.NewAssert(t)
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381} {
+		native, err := NewNative(curve.ScalarField())
+		assert.NoError(err)
+		buf := make([]byte, native.BlockSize())
+		in := make([]frontend.Variable, 17)
+		for i := range in {
+			big.NewInt(int64(i)).FillBytes(buf)
+			_, err = native.Write(buf)
+			assert.NoError(err)
+			in[i] = i
+		}
+		long := new(big.Int).SetBytes(native.Sum(nil))
+
+		native.Reset()
+		for i := 0; i < 16; i++ {
+			big.NewInt(int64(i)).FillBytes(buf)
+			_, err = native.Write(buf)
+			assert.NoError(err)
+		}
+		first := native.Sum(nil)
+		native.Reset()
+		_, err = native.Write(first)
+		assert.NoError(err)
+		big.NewInt(16).FillBytes(buf)
+		_, err = native.Write(buf)
+		assert.NoError(err)
+		short := new(big.Int).SetBytes(native.Sum(nil))
+		assert.NotEqual(long, short)
+
+		// in-circuit, the digest of x₁, …, x₁₇ is the native one and not
+		// the colliding digest.
+		circuit := poseidonCircuit{In: make([]frontend.Variable, 17)}
+		assert.CheckCircuit(&circuit, test.WithValidAssignment(&poseidonCircuit{In: in, Expected: long}),
+			test.WithInvalidAssignment(&poseidonCircuit{In: in, Expected: short}), test.WithCurves(curve))
+	}
+}
@@ -0,0 +1,78 @@
+package poseidon2
+
+import (
+	"errors"
+	stdhash "hash"
+	"math/big"
+
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+type digest struct {
+	params *poseidon2.Parameters
+	h      *big.Int   // current digest
+	data   []*big.Int // data to be hashed in the next call to Sum
+}
+
+// NewNative returns the native counterpart of [Poseidon2] over the field with
+// the given modulus, for example to compute the expected digest when
+// generating a witness.
+//
+// The input is written as big-endian encodings of field elements of
+// [stdhash.Hash.BlockSize] bytes each. As for [Poseidon2], Sum flushes the
+// data and the digest is used as chaining value for the data written
+// afterwards.
+func NewNative(modulus *big.Int) (stdhash.Hash, error) {
+	params, err := poseidon2.GetParameters(modulus, width)
+	if err != nil {
+		return nil, err
+	}
+	return &digest{params: params, h: new(big.Int)}, nil
+}
+
+// Write appends the field elements encoded in p to the data to be hashed. It
+// returns an error if the length of p is not a multiple of the block size or if
+// an element is not reduced.
+func (d *digest) Write(p []byte) (int, error) {
+	if len(p)%d.BlockSize() != 0 {
+		return 0, errors.New("invalid input length: must represent a list of field elements")
+	}
+	var elems []*big.Int
+	for i := 0; i < len(p); i += d.BlockSize() {
+		x := new(big.Int).SetBytes(p[i : i+d.BlockSize()])
+		if x.Cmp(d.params.Modulus) >= 0 {
+			return 0, errors.New("not a field element")
+		}
+		elems = append(elems, x)
+	}
+	d.data = append(d.data, elems...)
+	return len(p), nil
+}
+
+// Sum appends the digest of the data written so far to b.
+func (d *digest) Sum(b []byte) []byte {
+	for _, x := range d.data {
+		state := []*big.Int{d.h, x, new(big.Int)}
+		if err := d.params.Permute(state); err != nil {
+			panic(err)
+		}
+		d.h = state[0]
+	}
+	d.data = nil
+	res := make([]byte, d.Size())
+	d.h.FillBytes(res)
+	return append(b, res...)
+}
+
+func (d *digest) Reset() {
+	d.h = new(big.Int)
+	d.data = nil
+}
+
+func (d *digest) Size() int {
+	return (d.params.Modulus.BitLen() + 7) / 8
+}
+
+func (d *digest) BlockSize() int {
+	return d.Size()
+}
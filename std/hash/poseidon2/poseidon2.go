@@ -0,0 +1,73 @@
+// Package poseidon2 provides a ZKP-circuit function to compute a Poseidon2
+// hash.
+//
+// The hash function uses the Merkle-Damgård construction over the compression
+// function c(h, x) = P([h, x, 0])₀ where P is the Poseidon2 permutation of
+// width 3. This is the compression function of the Merkle trees in the
+// HorizenLabs reference implementation. The initial digest is 0.
+//
+// The hash function is registered as "poseidon2" in [hash.GetFieldHasher]. See
+// [NewNative] for the native counterpart.
+package poseidon2
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/permutation/poseidon2"
+)
+
+func init() {
+	hash.Register("poseidon2", func(api frontend.API) (hash.FieldHasher, error) {
+		h, err := NewPoseidon2(api)
+		if err != nil {
+			return nil, err
+		}
+		return h, nil
+	})
+}
+
+const width = 3
+
+// Poseidon2 computes the Poseidon2 hash of native field elements in-circuit.
+// It implements [hash.FieldHasher].
+type Poseidon2 struct {
+	perm *poseidon2.Permutation
+	h    frontend.Variable   // current digest
+	data []frontend.Variable // data to be hashed in the next call to Sum
+}
+
+// NewPoseidon2 returns a new Poseidon2 hasher over the native field of api. It
+// returns an error if the native field is not the scalar field of BN254,
+// BLS12-377 or BLS12-381.
+func NewPoseidon2(api frontend.API) (*Poseidon2, error) {
+	perm, err := poseidon2.NewPermutation(api, width)
+	if err != nil {
+		return nil, err
+	}
+	return &Poseidon2{perm: perm, h: 0}, nil
+}
+
+// Write adds more data to the running hash.
+func (h *Poseidon2) Write(data ...frontend.Variable) {
+	h.data = append(h.data, data...)
+}
+
+// Reset resets the hasher to its initial state.
+func (h *Poseidon2) Reset() {
+	h.data = nil
+	h.h = 0
+}
+
+// Sum returns the digest of the data written so far. The data is flushed and
+// the digest is used as chaining value for the data written afterwards.
+func (h *Poseidon2) Sum() frontend.Variable {
+	for _, x := range h.data {
+		res, err := h.perm.Permute([]frontend.Variable{h.h, x, 0})
+		if err != nil {
+			panic(err)
+		}
+		h.h = res[0]
+	}
+	h.data = nil
+	return h.h
+}
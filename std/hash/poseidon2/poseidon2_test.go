@@ -0,0 +1,47 @@
+package poseidon2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/test"
+)
+
+type poseidon2Circuit struct {
+	In       []frontend.Variable
+	Expected frontend.Variable
+}
+
+func (c *poseidon2Circuit) Define(api frontend.API) error {
+	h, err := hash.GetFieldHasher("poseidon2", api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In...)
+	api.AssertIsEqual(h.Sum(), c.Expected)
+	return nil
+}
+
+func TestPoseidon2(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381} {
+		for _, n := range []int{0, 1, 2, 3} {
+			native, err := NewNative(curve.ScalarField())
+			assert.NoError(err)
+			witness := poseidon2Circuit{In: make([]frontend.Variable, n)}
+			for i := range witness.In {
+				buf := make([]byte, native.BlockSize())
+				big.NewInt(int64(i)).FillBytes(buf)
+				_, err = native.Write(buf)
+				assert.NoError(err)
+				witness.In[i] = i
+			}
+			witness.Expected = new(big.Int).SetBytes(native.Sum(nil))
+			circuit := poseidon2Circuit{In: make([]frontend.Variable, n)}
+			assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(curve))
+		}
+	}
+}
@@ -1,7 +1,8 @@
 // Package sha2 implements SHA2 hash computation.
 //
 // This package extends the SHA2 permutation function [sha2] into a full SHA2
-// hash.
+// hash. SHA-256 is computed over 32-bit words and SHA-384 and SHA-512 over
+// 64-bit words.
 package sha2
 
 import (
@@ -9,6 +10,7 @@ import (
 
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/hash/internal/varlen"
 	"github.com/consensys/gnark/std/math/uints"
 	"github.com/consensys/gnark/std/permutation/sha2"
 )
@@ -18,17 +20,20 @@ var _seed = uints.NewU32Array([]uint32{
 })
 
 type digest struct {
+	api       frontend.API
 	uapi      *uints.BinaryField[uints.U32]
 	in        []uints.U8
 	is_padded bool
 }
 
-func New(api frontend.API, is_padded bool) (hash.BinaryHasher, error) {
+// New returns a new SHA-256 hasher. If is_padded is set, then the written input
+// is assumed to be already padded and is hashed as is.
+func New(api frontend.API, is_padded bool) (hash.BinaryFixedLengthHasher, error) {
 	uapi, err := uints.New[uints.U32](api)
 	if err != nil {
 		return nil, err
 	}
-	return &digest{uapi: uapi, is_padded: is_padded}, nil
+	return &digest{api: api, uapi: uapi, is_padded: is_padded}, nil
 }
 
 func (d *digest) Write(data []uints.U8) {
@@ -55,6 +60,14 @@ func (d *digest) padded(bytesLen int) []uints.U8 {
 	return buf
 }
 
+func (d *digest) output(runningDigest [8]uints.U32) []uints.U8 {
+	var ret []uints.U8
+	for i := range runningDigest {
+		ret = append(ret, d.uapi.UnpackMSB(runningDigest[i])...)
+	}
+	return ret
+}
+
 func (d *digest) Sum() []uints.U8 {
 	var runningDigest [8]uints.U32
 	var buf [64]uints.U8
@@ -67,23 +80,30 @@ func (d *digest) Sum() []uints.U8 {
 		copy(buf[:], padded[i*64:(i+1)*64])
 		runningDigest = sha2.Permute(d.uapi, runningDigest, buf)
 	}
-	var ret []uints.U8
-	for i := range runningDigest {
-		ret = append(ret, d.uapi.UnpackMSB(runningDigest[i])...)
-	}
-	return ret
+	return d.output(runningDigest)
 }
 
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the number of bytes written. It panics if the
+// hasher was created for already padded input.
 func (d *digest) FixedLengthSum(length frontend.Variable) []uints.U8 {
-	panic("TODO")
-	// we need to do two things here -- first the padding has to be put to the
-	// right place. For that we need to know how many blocks we have used. We
-	// need to fit at least 9 more bytes (padding byte and 8 bytes for input
-	// length). Knowing the block, we have to keep running track if the current
-	// block is the expected one.
-	//
-	// idea - have a mask for blocks where 1 is only for the block we want to
-	// use.
+	if d.is_padded {
+		panic("fixed length sum of padded input")
+	}
+	padded, last, err := fixedLengthPadded(d.api, d.in, length, 64, 8)
+	if err != nil {
+		panic(err)
+	}
+	var runningDigest [8]uints.U32
+	var buf [64]uints.U8
+	copy(runningDigest[:], _seed)
+	outputs := make([][]uints.U8, len(last))
+	for i := range last {
+		copy(buf[:], padded[i*64:(i+1)*64])
+		runningDigest = sha2.Permute(d.uapi, runningDigest, buf)
+		outputs[i] = d.output(runningDigest)
+	}
+	return varlen.Select(d.api, last, outputs)
 }
 
 func (d *digest) Reset() {
@@ -91,3 +111,62 @@ func (d *digest) Reset() {
 }
 
 func (d *digest) Size() int { return 32 }
+
+// fixedLengthPadded returns the first length bytes of in followed by the SHA-2
+// padding for blocks of blockSize bytes and a lenSize-byte big-endian bit
+// length. The padded message is as long as the padding of the whole input
+// would be, and the blocks after the padding are zero. It also returns the
+// one-hot selector of the last block of the padded message.
+func fixedLengthPadded(api frontend.API, in []uints.U8, length frontend.Variable, blockSize, lenSize int) ([]uints.U8, []frontend.Variable, error) {
+	nbBlocks := (len(in) + 1 + lenSize + blockSize - 1) / blockSize
+	n := nbBlocks * blockSize
+	mask := varlen.Mask(api, length, len(in), n+1)
+	masked := varlen.MaskBytes(api, in, mask)
+	// the padding ends at byte length+lenSize, so the last block is the one
+	// containing it. We obtain the corresponding mask by shifting.
+	endMask := make([]frontend.Variable, n+1)
+	for i := range endMask {
+		if i <= lenSize {
+			endMask[i] = 1
+		} else {
+			endMask[i] = mask[i-lenSize-1]
+		}
+	}
+	last := varlen.LastBlock(api, endMask, blockSize, nbBlocks)
+	// the bit length fits in 64 bits, the remaining bytes are zero
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return nil, nil, err
+	}
+	lenBytes := make([]uints.U8, lenSize-8, lenSize)
+	for i := range lenBytes {
+		lenBytes[i] = uints.NewU8(0)
+	}
+	lenBytes = append(lenBytes, uapi.UnpackMSB(uapi.ValueOf(api.Mul(length, 8)))...)
+
+	res := make([]uints.U8, n)
+	for i := range res {
+		var terms []frontend.Variable
+		if i < len(in) {
+			terms = append(terms, masked[i].Val)
+		}
+		// the padding byte 0x80 is at position length
+		var isEnd frontend.Variable
+		if i == 0 {
+			isEnd = api.Sub(1, mask[0])
+		} else {
+			isEnd = api.Sub(mask[i-1], mask[i])
+		}
+		terms = append(terms, api.Mul(isEnd, 0x80))
+		// the length is at the end of the last block
+		if r := i%blockSize - (blockSize - lenSize); r >= 0 {
+			terms = append(terms, api.Mul(last[i/blockSize], lenBytes[r].Val))
+		}
+		if len(terms) == 1 {
+			res[i] = uints.U8{Val: terms[0]}
+		} else {
+			res[i] = uints.U8{Val: api.Add(terms[0], terms[1], terms[2:]...)}
+		}
+	}
+	return res, last, nil
+}
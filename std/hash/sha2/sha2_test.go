@@ -17,7 +17,7 @@ type sha2Circuit struct {
 }
 
 func (c *sha2Circuit) Define(api frontend.API) error {
-	h, err := New(api)
+	h, err := New(api, false)
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,7 @@ type sha2FixedLengthCircuit struct {
 }
 
 func (c *sha2FixedLengthCircuit) Define(api frontend.API) error {
-	h, err := New(api)
+	h, err := New(api, false)
 	if err != nil {
 		return err
 	}
@@ -76,16 +76,23 @@ func (c *sha2FixedLengthCircuit) Define(api frontend.API) error {
 }
 
 func TestSHA2FixedLengthSum(t *testing.T) {
+	assert := test.NewAssert(t)
 	bts := make([]byte, 144)
-	length := 56
-	dgst := sha256.Sum256(bts[:length])
-	witness := sha2FixedLengthCircuit{
-		In:     uints.NewU8Array(bts),
-		Length: length,
+	for i := range bts {
+		bts[i] = byte(i)
 	}
-	copy(witness.Expected[:], uints.NewU8Array(dgst[:]))
-	err := test.IsSolved(&sha2FixedLengthCircuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
-	if err != nil {
-		t.Fatal(err)
+	for _, length := range []int{0, 1, 55, 56, 64, 119, 120, 144} {
+		dgst := sha256.Sum256(bts[:length])
+		witness := sha2FixedLengthCircuit{
+			In:     uints.NewU8Array(bts),
+			Length: length,
+		}
+		copy(witness.Expected[:], uints.NewU8Array(dgst[:]))
+		err := test.IsSolved(&sha2FixedLengthCircuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+		assert.NoError(err, "length %d", length)
 	}
+	// length larger than the input
+	witness := sha2FixedLengthCircuit{In: uints.NewU8Array(bts), Length: 145}
+	err := test.IsSolved(&sha2FixedLengthCircuit{In: make([]uints.U8, len(bts))}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
 }
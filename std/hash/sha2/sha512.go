@@ -0,0 +1,114 @@
+package sha2
+
+import (
+	"encoding/binary"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/hash/internal/varlen"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/std/permutation/sha2"
+)
+
+var _seed512 = uints.NewU64Array([]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+})
+
+var _seed384 = uints.NewU64Array([]uint64{
+	0xcbbb9d5dc1059ed8, 0x629a292a367cd507, 0x9159015a3070dd17, 0x152fecd8f70e5939,
+	0x67332667ffc00b31, 0x8eb44a8768581511, 0xdb0c2e0d64f98fa7, 0x47b5481dbefa4fa4,
+})
+
+const blockSize512 = 128
+
+type digest512 struct {
+	api  frontend.API
+	uapi *uints.BinaryField[uints.U64]
+	in   []uints.U8
+	seed []uints.U64
+	size int
+}
+
+// New512 returns a new SHA-512 hasher.
+func New512(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest512(api, _seed512, 64)
+}
+
+// New384 returns a new SHA-384 hasher.
+func New384(api frontend.API) (hash.BinaryFixedLengthHasher, error) {
+	return newDigest512(api, _seed384, 48)
+}
+
+func newDigest512(api frontend.API, seed []uints.U64, size int) (hash.BinaryFixedLengthHasher, error) {
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return nil, err
+	}
+	return &digest512{api: api, uapi: uapi, seed: seed, size: size}, nil
+}
+
+func (d *digest512) Write(data []uints.U8) {
+	d.in = append(d.in, data...)
+}
+
+// padded returns the input followed by the padding byte, zeros and the 128-bit
+// big-endian bit length of the input.
+func (d *digest512) padded() []uints.U8 {
+	zeroPadLen := blockSize512 - 17 - len(d.in)%blockSize512
+	if zeroPadLen < 0 {
+		zeroPadLen += blockSize512
+	}
+	buf := make([]uints.U8, 0, len(d.in)+17+zeroPadLen)
+	buf = append(buf, d.in...)
+	buf = append(buf, uints.NewU8(0x80))
+	buf = append(buf, uints.NewU8Array(make([]uint8, zeroPadLen+8))...)
+	lenbuf := make([]uint8, 8)
+	binary.BigEndian.PutUint64(lenbuf, uint64(8*len(d.in)))
+	return append(buf, uints.NewU8Array(lenbuf)...)
+}
+
+func (d *digest512) output(runningDigest [8]uints.U64) []uints.U8 {
+	var ret []uints.U8
+	for i := range runningDigest {
+		ret = append(ret, d.uapi.UnpackMSB(runningDigest[i])...)
+	}
+	return ret[:d.size]
+}
+
+func (d *digest512) Sum() []uints.U8 {
+	var runningDigest [8]uints.U64
+	var buf [blockSize512]uints.U8
+	copy(runningDigest[:], d.seed)
+	padded := d.padded()
+	for i := 0; i < len(padded)/blockSize512; i++ {
+		copy(buf[:], padded[i*blockSize512:(i+1)*blockSize512])
+		runningDigest = sha2.Permute512(d.uapi, runningDigest, buf)
+	}
+	return d.output(runningDigest)
+}
+
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the number of bytes written.
+func (d *digest512) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	padded, last, err := fixedLengthPadded(d.api, d.in, length, blockSize512, 16)
+	if err != nil {
+		panic(err)
+	}
+	var runningDigest [8]uints.U64
+	var buf [blockSize512]uints.U8
+	copy(runningDigest[:], d.seed)
+	outputs := make([][]uints.U8, len(last))
+	for i := range last {
+		copy(buf[:], padded[i*blockSize512:(i+1)*blockSize512])
+		runningDigest = sha2.Permute512(d.uapi, runningDigest, buf)
+		outputs[i] = d.output(runningDigest)
+	}
+	return varlen.Select(d.api, last, outputs)
+}
+
+func (d *digest512) Reset() {
+	d.in = nil
+}
+
+func (d *digest512) Size() int { return d.size }
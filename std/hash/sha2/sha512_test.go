@@ -0,0 +1,113 @@
+package sha2
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type sha512Circuit struct {
+	In       []uints.U8
+	Expected []uints.U8
+}
+
+func (c *sha512Circuit) Define(api frontend.API) error {
+	var h hash.BinaryFixedLengthHasher
+	var err error
+	if len(c.Expected) == 48 {
+		h, err = New384(api)
+	} else {
+		h, err = New512(api)
+	}
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.Sum()
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestSHA512(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, l := range []int{0, 3, 111, 112, 128, 300} {
+		bts := make([]byte, l)
+		for i := range bts {
+			bts[i] = byte(i)
+		}
+		dgst384, dgst512 := sha512.Sum384(bts), sha512.Sum512(bts)
+		for _, dgst := range [][]byte{dgst384[:], dgst512[:]} {
+			witness := sha512Circuit{
+				In:       uints.NewU8Array(bts),
+				Expected: uints.NewU8Array(dgst),
+			}
+			err := test.IsSolved(&sha512Circuit{In: make([]uints.U8, l), Expected: make([]uints.U8, len(dgst))}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err, "length %d", l)
+		}
+	}
+}
+
+type sha512FixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected []uints.U8
+}
+
+func (c *sha512FixedLengthCircuit) Define(api frontend.API) error {
+	var h hash.BinaryFixedLengthHasher
+	var err error
+	if len(c.Expected) == 48 {
+		h, err = New384(api)
+	} else {
+		h, err = New512(api)
+	}
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestSHA512FixedLengthSum(t *testing.T) {
+	assert := test.NewAssert(t)
+	bts := make([]byte, 240)
+	for i := range bts {
+		bts[i] = byte(i)
+	}
+	for _, l := range []int{0, 1, 111, 112, 128, 239, 240} {
+		dgst384, dgst512 := sha512.Sum384(bts[:l]), sha512.Sum512(bts[:l])
+		for _, dgst := range [][]byte{dgst384[:], dgst512[:]} {
+			witness := sha512FixedLengthCircuit{
+				In:       uints.NewU8Array(bts),
+				Length:   l,
+				Expected: uints.NewU8Array(dgst),
+			}
+			err := test.IsSolved(&sha512FixedLengthCircuit{In: make([]uints.U8, len(bts)), Expected: make([]uints.U8, len(dgst))}, &witness, ecc.BN254.ScalarField())
+			assert.NoError(err, "length %d", l)
+		}
+	}
+	// length larger than the input
+	dgst := sha512.Sum512(bts)
+	witness := sha512FixedLengthCircuit{In: uints.NewU8Array(bts), Length: 241, Expected: uints.NewU8Array(dgst[:])}
+	err := test.IsSolved(&sha512FixedLengthCircuit{In: make([]uints.U8, len(bts)), Expected: make([]uints.U8, 64)}, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
@@ -1,11 +1,14 @@
 package sha3
 
 import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/internal/varlen"
 	"github.com/consensys/gnark/std/math/uints"
 	"github.com/consensys/gnark/std/permutation/keccakf"
 )
 
 type digest struct {
+	api       frontend.API
 	uapi      *uints.BinaryField[uints.U64]
 	state     [25]uints.U64 // 1600 bits state: 25 x 64
 	in        []uints.U8    // input to be digested
@@ -32,6 +35,21 @@ func (d *digest) Sum() []uints.U8 {
 	return d.squeezeBlocks()
 }
 
+// FixedLengthSum returns the digest of the first length bytes of the input.
+// The length must be at most the number of bytes written. The state is
+// absorbed with all blocks needed for the whole input and the digest is taken
+// from the state after absorbing the block containing the padding.
+func (d *digest) FixedLengthSum(length frontend.Variable) []uints.U8 {
+	padded, last := d.fixedLengthPadding(length)
+	blocks := d.composeBlocks(padded)
+	outputs := make([][]uints.U8, len(blocks))
+	for i := range blocks {
+		d.absorbing(blocks[i : i+1])
+		outputs[i] = d.squeezeBlocks()
+	}
+	return varlen.Select(d.api, last, outputs)
+}
+
 func (d *digest) padding() []uints.U8 {
 	padded := make([]uints.U8, len(d.in))
 	copy(padded[:], d.in[:])
@@ -51,6 +69,43 @@ func (d *digest) padding() []uints.U8 {
 	return padded
 }
 
+// fixedLengthPadding returns the first length bytes of the input followed by
+// the padding and zeros up to the padded length of the whole input. It also
+// returns the one-hot selector of the block containing the padding.
+func (d *digest) fixedLengthPadding(length frontend.Variable) ([]uints.U8, []frontend.Variable) {
+	nbBlocks := len(d.in)/d.rate + 1
+	n := nbBlocks * d.rate
+	mask := varlen.Mask(d.api, length, len(d.in), n+1)
+	masked := varlen.MaskBytes(d.api, d.in, mask)
+	// the padding starts at byte length, so the last block is the one
+	// containing it. We obtain the corresponding mask by shifting.
+	endMask := make([]frontend.Variable, n+1)
+	endMask[0] = 1
+	copy(endMask[1:], mask)
+	last := varlen.LastBlock(d.api, endMask, d.rate, nbBlocks)
+
+	padded := make([]uints.U8, n)
+	for i := range padded {
+		var terms []frontend.Variable
+		if i < len(d.in) {
+			terms = append(terms, masked[i].Val)
+		}
+		// the domain separation byte is at position length. Its bits are
+		// distinct from the final padding bit, so adding them is the same as
+		// xoring when both are in the last byte of the block.
+		terms = append(terms, d.api.Mul(d.api.Sub(endMask[i], mask[i]), d.dsbyte))
+		if i%d.rate == d.rate-1 {
+			terms = append(terms, d.api.Mul(last[i/d.rate], 0x80))
+		}
+		if len(terms) == 1 {
+			padded[i] = uints.U8{Val: terms[0]}
+		} else {
+			padded[i] = uints.U8{Val: d.api.Add(terms[0], terms[1], terms[2:]...)}
+		}
+	}
+	return padded, last
+}
+
 func (d *digest) composeBlocks(padded []uints.U8) [][]uints.U64 {
 	blocks := make([][]uints.U64, len(padded)/d.rate)
 
@@ -15,7 +15,7 @@ import (
 )
 
 type testCase struct {
-	zk     func(api frontend.API) (zkhash.BinaryHasher, error)
+	zk     func(api frontend.API) (zkhash.BinaryFixedLengthHasher, error)
 	native func() hash.Hash
 }
 
@@ -88,3 +88,70 @@ func TestSHA3(t *testing.T) {
 		}, name)
 	}
 }
+
+type sha3FixedLengthCircuit struct {
+	In       []uints.U8
+	Length   frontend.Variable
+	Expected []uints.U8
+
+	hasher string
+}
+
+func (c *sha3FixedLengthCircuit) Define(api frontend.API) error {
+	newHasher, ok := testCases[c.hasher]
+	if !ok {
+		return fmt.Errorf("hash function unknown: %s", c.hasher)
+	}
+	h, err := newHasher.zk(api)
+	if err != nil {
+		return err
+	}
+	uapi, err := uints.New[uints.U64](api)
+	if err != nil {
+		return err
+	}
+
+	h.Write(c.In)
+	res := h.FixedLengthSum(c.Length)
+
+	for i := range c.Expected {
+		uapi.ByteAssertEq(c.Expected[i], res[i])
+	}
+	return nil
+}
+
+func TestSHA3FixedLengthSum(t *testing.T) {
+	assert := test.NewAssert(t)
+	in := make([]byte, 300)
+	_, err := rand.Reader.Read(in)
+	assert.NoError(err)
+
+	for name := range testCases {
+		assert.Run(func(assert *test.Assert) {
+			name := name
+			strategy := testCases[name]
+			// cover the lengths where the padding fits in one or two bytes
+			// and where it starts a new block
+			for _, length := range []int{0, 1, 70, 71, 72, 134, 135, 136, 299, 300} {
+				h := strategy.native()
+				h.Write(in[:length])
+				expected := h.Sum(nil)
+
+				circuit := &sha3FixedLengthCircuit{
+					In:       make([]uints.U8, len(in)),
+					Expected: make([]uints.U8, len(expected)),
+					hasher:   name,
+				}
+
+				witness := &sha3FixedLengthCircuit{
+					In:       uints.NewU8Array(in),
+					Length:   length,
+					Expected: uints.NewU8Array(expected),
+				}
+
+				err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+				assert.NoError(err, "length %d", length)
+			}
+		}, name)
+	}
+}
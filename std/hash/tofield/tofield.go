@@ -0,0 +1,142 @@
+// Package tofield implements hashing of byte strings to field elements as
+// defined in [RFC 9380] section 5.
+//
+// The message is first expanded with [ExpandMsgXmd] over SHA-256 and the
+// expanded bytes are then interpreted as big-endian integers reduced modulo the
+// emulated field modulus. The outputs correspond to the hash_to_field function
+// of the hash-to-curve suites, for example as implemented by
+// github.com/consensys/gnark-crypto/ecc/bls12-381/fp.Hash.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+package tofield
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// securityLevel is the target security level k in bits used for computing the
+// number of bytes per field element.
+const securityLevel = 128
+
+// ExpandMsgXmd expands the message msg with the domain separation tag dst into
+// lenInBytes pseudo-random bytes using SHA-256, as defined in [RFC 9380]
+// section 5.3.1.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func ExpandMsgXmd(api frontend.API, msg []uints.U8, dst []byte, lenInBytes int) ([]uints.U8, error) {
+	const bInBytes = 32 // output size of SHA-256
+	const sInBytes = 64 // input block size of SHA-256
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 65535 {
+		return nil, errors.New("invalid lenInBytes")
+	}
+	if len(dst) > 255 {
+		return nil, errors.New("invalid dst size")
+	}
+	uapi, err := uints.New[uints.U32](api)
+	if err != nil {
+		return nil, fmt.Errorf("new uints api: %w", err)
+	}
+	dstPrime := uints.NewU8Array(append(append([]byte{}, dst...), byte(len(dst))))
+
+	// b_0 = H(Z_pad || msg || l_i_b_str || I2OSP(0, 1) || DST_prime)
+	h, err := sha2.New(api, false)
+	if err != nil {
+		return nil, fmt.Errorf("new hasher: %w", err)
+	}
+	h.Write(uints.NewU8Array(make([]byte, sInBytes)))
+	h.Write(msg)
+	h.Write(uints.NewU8Array([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0}))
+	h.Write(dstPrime)
+	b0 := h.Sum()
+
+	// b_1 = H(b_0 || I2OSP(1, 1) || DST_prime)
+	if h, err = sha2.New(api, false); err != nil {
+		return nil, fmt.Errorf("new hasher: %w", err)
+	}
+	h.Write(b0)
+	h.Write([]uints.U8{uints.NewU8(1)})
+	h.Write(dstPrime)
+	bi := h.Sum()
+	res := append([]uints.U8{}, bi...)
+
+	// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime)
+	for i := 2; i <= ell; i++ {
+		if h, err = sha2.New(api, false); err != nil {
+			return nil, fmt.Errorf("new hasher: %w", err)
+		}
+		for j := 0; j < bInBytes; j += 4 {
+			x := uapi.Xor(uapi.PackLSB(b0[j:j+4]...), uapi.PackLSB(bi[j:j+4]...))
+			h.Write(uapi.UnpackLSB(x))
+		}
+		h.Write([]uints.U8{uints.NewU8(uint8(i))})
+		h.Write(dstPrime)
+		bi = h.Sum()
+		res = append(res, bi...)
+	}
+	return res[:lenInBytes], nil
+}
+
+// HashToField hashes the message msg with the domain separation tag dst into
+// count elements of the emulated field T, as defined in [RFC 9380] section
+// 5.2. For extension fields of degree m, call the function with count⋅m
+// elements and use consecutive elements as the coefficients.
+//
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+func HashToField[T emulated.FieldParams](api frontend.API, msg []uints.U8, dst []byte, count int) ([]*emulated.Element[T], error) {
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return nil, fmt.Errorf("new field: %w", err)
+	}
+	var fp T
+	// L = ceil((ceil(log2(p)) + k) / 8)
+	L := (fp.Modulus().BitLen() + securityLevel + 7) / 8
+	uniform, err := ExpandMsgXmd(api, msg, dst, count*L)
+	if err != nil {
+		return nil, fmt.Errorf("expand message: %w", err)
+	}
+	res := make([]*emulated.Element[T], count)
+	for i := range res {
+		res[i] = fromBytes(api, f, uniform[i*L:(i+1)*L])
+	}
+	return res, nil
+}
+
+// fromBytes returns the big-endian integer in reduced modulo the field
+// modulus. The bytes are split into chunks of the width of an element, which
+// are recomposed using Horner's rule.
+func fromBytes[T emulated.FieldParams](api frontend.API, f *emulated.Field[T], in []uints.U8) *emulated.Element[T] {
+	var fp T
+	chunkBits := int(fp.NbLimbs() * fp.BitsPerLimb())
+	bits := make([]frontend.Variable, 0, 8*len(in))
+	for i := len(in) - 1; i >= 0; i-- {
+		bits = append(bits, api.ToBinary(in[i].Val, 8)...)
+	}
+	shift := new(big.Int).Lsh(big.NewInt(1), uint(chunkBits))
+	shift.Mod(shift, fp.Modulus())
+	var res *emulated.Element[T]
+	for start := ((len(bits) - 1) / chunkBits) * chunkBits; start >= 0; start -= chunkBits {
+		chunk := make([]frontend.Variable, chunkBits)
+		for j := range chunk {
+			if start+j < len(bits) {
+				chunk[j] = bits[start+j]
+			} else {
+				chunk[j] = 0
+			}
+		}
+		c := f.FromBits(chunk...)
+		if res == nil {
+			res = c
+		} else {
+			res = f.Add(f.Mul(res, f.NewElement(shift)), c)
+		}
+	}
+	return f.Reduce(res)
+}
@@ -0,0 +1,103 @@
+package tofield
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fp_bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
+	fp_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/field/hash"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type expandCircuit struct {
+	Msg      []uints.U8
+	Expected []uints.U8
+
+	dst []byte
+}
+
+func (c *expandCircuit) Define(api frontend.API) error {
+	res, err := ExpandMsgXmd(api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range c.Expected {
+		api.AssertIsEqual(c.Expected[i].Val, res[i].Val)
+	}
+	return nil
+}
+
+func TestExpandMsgXmd(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	for _, tc := range []struct {
+		msg string
+		len int
+	}{
+		{"", 32},
+		{"abc", 32},
+		{"abcdef0123456789", 128},
+		{"q128_qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq", 100},
+	} {
+		expected, err := hash.ExpandMsgXmd([]byte(tc.msg), dst, tc.len)
+		assert.NoError(err)
+		circuit := &expandCircuit{Msg: make([]uints.U8, len(tc.msg)), Expected: make([]uints.U8, tc.len), dst: dst}
+		witness := &expandCircuit{Msg: uints.NewU8Array([]byte(tc.msg)), Expected: uints.NewU8Array(expected)}
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err, tc.msg)
+	}
+}
+
+type hashToFieldCircuit[T emulated.FieldParams] struct {
+	Msg      []uints.U8
+	Expected []emulated.Element[T]
+
+	dst []byte
+}
+
+func (c *hashToFieldCircuit[T]) Define(api frontend.API) error {
+	f, err := emulated.NewField[T](api)
+	if err != nil {
+		return err
+	}
+	res, err := HashToField[T](api, c.Msg, c.dst, len(c.Expected))
+	if err != nil {
+		return err
+	}
+	for i := range c.Expected {
+		f.AssertIsEqual(res[i], &c.Expected[i])
+	}
+	return nil
+}
+
+func TestHashToField(t *testing.T) {
+	assert := test.NewAssert(t)
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	msg := []byte("abcdef0123456789")
+	assert.Run(func(assert *test.Assert) {
+		expected, err := fp_bls12381.Hash(msg, dst, 2)
+		assert.NoError(err)
+		circuit := &hashToFieldCircuit[emulated.BLS12381Fp]{Msg: make([]uints.U8, len(msg)), Expected: make([]emulated.Element[emulated.BLS12381Fp], 2), dst: dst}
+		witness := &hashToFieldCircuit[emulated.BLS12381Fp]{Msg: uints.NewU8Array(msg)}
+		for i := range expected {
+			witness.Expected = append(witness.Expected, emulated.ValueOf[emulated.BLS12381Fp](expected[i]))
+		}
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "bls12381")
+	assert.Run(func(assert *test.Assert) {
+		expected, err := fp_bn254.Hash(msg, dst, 2)
+		assert.NoError(err)
+		circuit := &hashToFieldCircuit[emulated.BN254Fp]{Msg: make([]uints.U8, len(msg)), Expected: make([]emulated.Element[emulated.BN254Fp], 2), dst: dst}
+		witness := &hashToFieldCircuit[emulated.BN254Fp]{Msg: uints.NewU8Array(msg)}
+		for i := range expected {
+			witness.Expected = append(witness.Expected, emulated.ValueOf[emulated.BN254Fp](expected[i]))
+		}
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "bn254")
+}
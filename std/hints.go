@@ -7,6 +7,8 @@ import (
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bls12381"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/fields_bw6761"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls12377"
 	"github.com/consensys/gnark/std/algebra/native/fields_bls24315"
@@ -20,6 +22,8 @@ import (
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/std/rangecheck"
 	"github.com/consensys/gnark/std/selector"
+	"github.com/consensys/gnark/std/signature/ecdsa"
+	"github.com/consensys/gnark/std/signature/schnorr"
 )
 
 var registerOnce sync.Once
@@ -51,9 +55,14 @@ func registerHints() {
 	solver.RegisterHint(fields_bls24315.GetHints()...)
 	// emulated curves
 	solver.RegisterHint(sw_emulated.GetHints()...)
+	solver.RegisterHint(sw_bls12381.GetHints()...)
+	solver.RegisterHint(sw_bn254.GetHints()...)
 	// native curves
 	solver.RegisterHint(sw_bls12377.GetHints()...)
 	solver.RegisterHint(sw_bls24315.GetHints()...)
+	// signatures
+	solver.RegisterHint(ecdsa.GetHints()...)
+	solver.RegisterHint(schnorr.GetHints()...)
 }
 
 func init() {
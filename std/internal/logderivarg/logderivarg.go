@@ -146,6 +146,59 @@ func Build(api frontend.API, table Table, queries Table) error {
 	return nil
 }
 
+// BuildPermutation builds the argument that the rows of a and b are equal as
+// multisets, that is b is a permutation of a. Instead of the counts, it checks
+//
+//	∑_{f∈a} 1/(x-∑_{i∈[n]}r_i*f_i) == ∑_{s∈b} 1/(x-∑_{i∈[n]}r_i*s_i).
+func BuildPermutation(api frontend.API, a Table, b Table) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("table length mismatch")
+	}
+	if len(a) == 0 {
+		return nil
+	}
+	nbRow := len(a[0])
+	var toCommit []frontend.Variable
+	for _, t := range []Table{a, b} {
+		for i := range t {
+			if len(t[i]) != nbRow {
+				return fmt.Errorf("table row length mismatch")
+			}
+			for j := range t[i] {
+				if _, isConst := api.Compiler().ConstantValue(t[i][j]); !isConst {
+					toCommit = append(toCommit, t[i][j])
+				}
+			}
+		}
+	}
+
+	multicommit.WithCommitment(api, func(api frontend.API, commitment frontend.Variable) error {
+		rowCoeffs, challenge := randLinearCoefficients(api, nbRow, commitment)
+		sums := make([]frontend.Variable, 2)
+		for k, t := range []Table{a, b} {
+			toInvert := make([]frontend.Variable, len(t))
+			for i := range t {
+				toInvert[i] = api.Sub(challenge, randLinearCombination(api, rowCoeffs, t[i]))
+			}
+			if bapi, ok := api.(frontend.BatchInverter); ok {
+				toInvert = bapi.BatchInvert(toInvert)
+			} else {
+				for i := range toInvert {
+					toInvert[i] = api.Inverse(toInvert[i])
+				}
+			}
+			var sum frontend.Variable = 0
+			for i := range toInvert {
+				sum = api.Add(sum, toInvert[i])
+			}
+			sums[k] = sum
+		}
+		api.AssertIsEqual(sums[0], sums[1])
+		return nil
+	}, toCommit...)
+	return nil
+}
+
 func randLinearCoefficients(api frontend.API, nbRow int, commitment frontend.Variable) (rowCoeffs []frontend.Variable, challenge frontend.Variable) {
 	if nbRow == 1 {
 		return []frontend.Variable{1}, commitment
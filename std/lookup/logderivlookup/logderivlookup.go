@@ -16,6 +16,9 @@
 //
 // The complexity of the lookups is linear in the size of the table and the
 // number of queries (O(n+m)).
+//
+// Additionally, [MultiTable] stores several values per index and [Memory]
+// allows to write to the stored values.
 package logderivlookup
 
 import (
@@ -47,8 +50,16 @@ type result struct {
 // New returns a new [*Table]. It additionally defers building the
 // log-derivative argument.
 func New(api frontend.API) *Table {
-	t := &Table{api: api}
+	t := newTable(api)
 	api.Compiler().Defer(t.commit)
+	return t
+}
+
+// newTable returns a new [*Table] without building the log-derivative
+// argument. It is used for the columns of a [MultiTable], which builds the
+// argument for all columns at once.
+func newTable(api frontend.API) *Table {
+	t := &Table{api: api}
 
 	// each table has a unique blueprint
 	t.bID = api.Compiler().AddBlueprint(&t.blueprint)
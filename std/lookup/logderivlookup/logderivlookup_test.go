@@ -91,3 +91,92 @@ func BenchmarkCompileManyLookup(b *testing.B) {
 		}
 	})
 }
+
+type XorCircuit struct {
+	A, B, Expected [20]frontend.Variable
+}
+
+func (c *XorCircuit) Define(api frontend.API) error {
+	t := NewMulti(api, 3)
+	for a := 0; a < 16; a++ {
+		for b := 0; b < 16; b++ {
+			t.Insert(a, b, a^b)
+		}
+	}
+	inds := make([]frontend.Variable, len(c.A))
+	for i := range c.A {
+		inds[i] = api.Add(api.Mul(c.A[i], 16), c.B[i])
+	}
+	rows := t.Lookup(inds...)
+	for i := range rows {
+		api.AssertIsEqual(rows[i][0], c.A[i])
+		api.AssertIsEqual(rows[i][1], c.B[i])
+		api.AssertIsEqual(rows[i][2], c.Expected[i])
+	}
+	return nil
+}
+
+func TestMultiTable(t *testing.T) {
+	assert := test.NewAssert(t)
+	var witness XorCircuit
+	for i := range witness.A {
+		a, b := i%16, (7*i+3)%16
+		witness.A[i], witness.B[i], witness.Expected[i] = a, b, a^b
+	}
+	assert.CheckCircuit(&XorCircuit{}, test.WithValidAssignment(&witness))
+
+	witness.Expected[5] = (witness.Expected[5].(int) + 1) % 16
+	assert.Error(test.IsSolved(&XorCircuit{}, &witness, ecc.BN254.ScalarField()))
+}
+
+type MemoryCircuit struct {
+	Init     [16]frontend.Variable
+	Addrs    [50]frontend.Variable
+	Values   [50]frontend.Variable
+	Expected [50]frontend.Variable
+}
+
+func (c *MemoryCircuit) Define(api frontend.API) error {
+	m := NewMemory(api, c.Init[:])
+	// every step reads the value at the address, checks it and then writes
+	// the sum of the read value and the new value.
+	for i := range c.Addrs {
+		v := m.Read(c.Addrs[i])
+		api.AssertIsEqual(v, c.Expected[i])
+		m.Write(c.Addrs[i], api.Add(v, c.Values[i]))
+	}
+	return nil
+}
+
+func TestMemory(t *testing.T) {
+	assert := test.NewAssert(t)
+	field := ecc.BN254.ScalarField()
+	var witness MemoryCircuit
+	mem := make([]*big.Int, len(witness.Init))
+	for i := range mem {
+		mem[i], _ = rand.Int(rand.Reader, field)
+		witness.Init[i] = new(big.Int).Set(mem[i])
+	}
+	for i := range witness.Addrs {
+		addr, _ := rand.Int(rand.Reader, big.NewInt(int64(len(mem))))
+		val, _ := rand.Int(rand.Reader, field)
+		a := addr.Int64()
+		witness.Addrs[i] = a
+		witness.Values[i] = val
+		witness.Expected[i] = new(big.Int).Set(mem[a])
+		mem[a] = new(big.Int).Add(mem[a], val)
+		mem[a].Mod(mem[a], field)
+	}
+	assert.CheckCircuit(&MemoryCircuit{}, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+
+	assert.Run(func(assert *test.Assert) {
+		w := witness
+		w.Expected[len(w.Expected)-1] = 0
+		assert.Error(test.IsSolved(&MemoryCircuit{}, &w, field))
+	}, "wrong_value")
+	assert.Run(func(assert *test.Assert) {
+		w := witness
+		w.Addrs[10] = len(w.Init)
+		assert.Error(test.IsSolved(&MemoryCircuit{}, &w, field))
+	}, "out_of_range")
+}
@@ -0,0 +1,153 @@
+package logderivlookup
+
+import (
+	"math/bits"
+
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/internal/logderivarg"
+	"github.com/consensys/gnark/std/rangecheck"
+)
+
+// Memory is a read/write memory of fixed size with variable addresses.
+//
+// The memory consistency is checked using the offline memory checking
+// technique of [BEG+91]. The initialization of address a to value v is the
+// operation 0 ≤ t < size which writes (a, v, t) with t = a. Every following
+// operation t reads the tuple (a, v', t') written by the last operation on a
+// and writes (a, v, t), where v = v' for reads. Finally, we read the last
+// tuple of every address. The memory is consistent if t' < t for every
+// operation and the multisets of written and read tuples are equal, which we
+// check with the log-derivative argument.
+//
+// The values v' and t' are computed by the prover, the memory operations cost
+// only a range check and their share of the argument.
+//
+// [BEG+91]: https://doi.org/10.1109/SFCS.1991.185352
+type Memory struct {
+	api      frontend.API
+	rchecker frontend.Rangechecker
+
+	size   int
+	nbOps  int
+	writes [][]frontend.Variable
+	reads  [][]frontend.Variable
+	closed bool
+
+	// the blueprint stores the operations to compute the values read by the
+	// prover.
+	bID       constraint.BlueprintID
+	blueprint constraint.BlueprintMemoryHint
+}
+
+// NewMemory returns a new [*Memory] whose address i is initialized to init[i].
+// It additionally defers building the log-derivative argument.
+func NewMemory(api frontend.API, init []frontend.Variable) *Memory {
+	if len(init) == 0 {
+		panic("memory must have at least one address")
+	}
+	m := &Memory{
+		api:      api,
+		rchecker: rangecheck.New(api),
+		size:     len(init),
+	}
+	m.bID = api.Compiler().AddBlueprint(&m.blueprint)
+	api.Compiler().Defer(m.commit)
+	for i := range init {
+		m.addOp(i, init[i])
+		m.writes = append(m.writes, []frontend.Variable{i, init[i], i})
+	}
+	return m
+}
+
+// Size returns the number of addresses of the memory.
+func (m *Memory) Size() int {
+	return m.size
+}
+
+// Read returns the value at addr. It panics during compile time when the
+// memory is already committed. The address must be less than the size of the
+// memory, otherwise the proof cannot be generated.
+func (m *Memory) Read(addr frontend.Variable) frontend.Variable {
+	return m.access(addr, nil)
+}
+
+// Write sets the value at addr to val. It panics during compile time when the
+// memory is already committed. The address must be less than the size of the
+// memory, otherwise the proof cannot be generated.
+func (m *Memory) Write(addr, val frontend.Variable) {
+	m.access(addr, val)
+}
+
+// access performs the operation on the address, which is a read if val is
+// nil. It returns the value before the operation.
+func (m *Memory) access(addr, val frontend.Variable) frontend.Variable {
+	if m.closed {
+		panic("accessing a committed memory")
+	}
+	t := m.nbOps
+	m.addOp(addr, val)
+	prev := m.query(t, addr)
+	// t' < t
+	m.rchecker.Check(m.api.Sub(t-1, prev[1]), bits.Len(uint(t)))
+	m.reads = append(m.reads, []frontend.Variable{addr, prev[0], prev[1]})
+	if val == nil {
+		val = prev[0]
+	}
+	m.writes = append(m.writes, []frontend.Variable{addr, val, t})
+	return prev[0]
+}
+
+// addOp appends the operation to the blueprint. The operation is a read if
+// val is nil.
+func (m *Memory) addOp(addr, val frontend.Variable) {
+	compiler := m.api.Compiler()
+	if val == nil {
+		m.blueprint.OpsCalldata = append(m.blueprint.OpsCalldata, 0)
+		compiler.ToCanonicalVariable(addr).Compress(&m.blueprint.OpsCalldata)
+	} else {
+		m.blueprint.OpsCalldata = append(m.blueprint.OpsCalldata, 1)
+		compiler.ToCanonicalVariable(addr).Compress(&m.blueprint.OpsCalldata)
+		compiler.ToCanonicalVariable(val).Compress(&m.blueprint.OpsCalldata)
+	}
+	m.nbOps++
+}
+
+// query returns the value and the operation index of the last operation
+// before the operation nbOps for every address.
+func (m *Memory) query(nbOps int, addrs ...frontend.Variable) []frontend.Variable {
+	compiler := m.api.Compiler()
+
+	calldata := make([]uint32, 3, 3+len(addrs)*2+2)
+	calldata[1] = uint32(nbOps)
+	calldata[2] = uint32(len(addrs))
+	for _, addr := range addrs {
+		compiler.ToCanonicalVariable(addr).Compress(&calldata)
+	}
+	// by convention, first calldata is len of inputs
+	calldata[0] = uint32(len(calldata))
+
+	outputs := compiler.AddInstruction(m.bID, calldata)
+	if len(outputs) != 2*len(addrs) {
+		panic("sanity check")
+	}
+	res := make([]frontend.Variable, len(outputs))
+	for i := range outputs {
+		res[i] = compiler.InternalVariable(outputs[i])
+	}
+	return res
+}
+
+func (m *Memory) commit(api frontend.API) error {
+	m.closed = true
+	addrs := make([]frontend.Variable, m.size)
+	for i := range addrs {
+		addrs[i] = i
+	}
+	last := m.query(m.nbOps, addrs...)
+	reads := m.reads
+	for i := range addrs {
+		reads = append(reads, []frontend.Variable{i, last[2*i], last[2*i+1]})
+	}
+	return logderivarg.BuildPermutation(api, m.writes, reads)
+}
@@ -0,0 +1,117 @@
+package logderivlookup
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/internal/logderivarg"
+)
+
+// MultiTable holds all the entries and queries of a lookup table with several
+// columns. It is a matrix where the first column is the index and the next
+// columns the stored values:
+//
+//	1 x_11 ... x_1k
+//	2 x_21 ... x_2k
+//	...
+//	n x_n1 ... x_nk
+//
+// When performing a query for index i, the prover returns the row (x_i1, ...,
+// x_ik) and we check that all queried tuples (i, x_i1, ..., x_ik) are included
+// in the table using a single log-derivative argument with random linear
+// combinations of the columns. For example, the table of the XOR of bytes a
+// and b stores the row (a, b, a^b) at index 256*a+b.
+type MultiTable struct {
+	api frontend.API
+
+	// every column is a table whose blueprint solves the queries. The
+	// argument is built for all the columns at once.
+	columns   []*Table
+	immutable bool
+}
+
+// NewMulti returns a new [*MultiTable] with nbColumns columns. It additionally
+// defers building the log-derivative argument.
+func NewMulti(api frontend.API, nbColumns int) *MultiTable {
+	if nbColumns < 1 {
+		panic("table must have at least one column")
+	}
+	t := &MultiTable{api: api, columns: make([]*Table, nbColumns)}
+	for i := range t.columns {
+		t.columns[i] = newTable(api)
+	}
+	api.Compiler().Defer(t.commit)
+	return t
+}
+
+// Insert inserts the row into the lookup table and returns its index as a
+// constant. It panics if the table is already committed or if the row length
+// is not the number of columns.
+func (t *MultiTable) Insert(row ...frontend.Variable) (index int) {
+	if t.immutable {
+		panic("inserting into committed lookup table")
+	}
+	if len(row) != len(t.columns) {
+		panic(fmt.Sprintf("row length %d, expected %d", len(row), len(t.columns)))
+	}
+	for i := range t.columns {
+		index = t.columns[i].Insert(row[i])
+	}
+	return index
+}
+
+// Lookup lookups up rows from the lookup table given by the indices inds. It
+// returns a row for every index. It panics during compile time when looking up
+// from a committed or empty table. It panics during solving time when the
+// index is out of bounds.
+func (t *MultiTable) Lookup(inds ...frontend.Variable) (rows [][]frontend.Variable) {
+	if t.immutable {
+		panic("looking up from a committed lookup table")
+	}
+	if len(inds) == 0 {
+		return nil
+	}
+	rows = make([][]frontend.Variable, len(inds))
+	for i := range rows {
+		rows[i] = make([]frontend.Variable, len(t.columns))
+	}
+	for j := range t.columns {
+		vals := t.columns[j].Lookup(inds...)
+		for i := range vals {
+			rows[i][j] = vals[i]
+		}
+	}
+	return rows
+}
+
+func (t *MultiTable) entryTable() [][]frontend.Variable {
+	tbl := make([][]frontend.Variable, len(t.columns[0].entries))
+	for i := range tbl {
+		tbl[i] = make([]frontend.Variable, len(t.columns)+1)
+		tbl[i][0] = i
+		for j := range t.columns {
+			tbl[i][j+1] = t.columns[j].entries[i]
+		}
+	}
+	return tbl
+}
+
+func (t *MultiTable) resultsTable() [][]frontend.Variable {
+	tbl := make([][]frontend.Variable, len(t.columns[0].results))
+	for i := range tbl {
+		tbl[i] = make([]frontend.Variable, len(t.columns)+1)
+		tbl[i][0] = t.columns[0].results[i].ind
+		for j := range t.columns {
+			tbl[i][j+1] = t.columns[j].results[i].val
+		}
+	}
+	return tbl
+}
+
+func (t *MultiTable) commit(api frontend.API) error {
+	t.immutable = true
+	for i := range t.columns {
+		t.columns[i].immutable = true
+	}
+	return logderivarg.Build(api, t.entryTable(), t.resultsTable())
+}
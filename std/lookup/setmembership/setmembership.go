@@ -0,0 +1,63 @@
+// Package setmembership implements set membership checks using the
+// log-derivative argument.
+//
+// The set is a list of distinct values, which may be variables. For the
+// queries q_1, ..., q_m we check that
+//
+//	∑_{f∈F} count(f)/(x-f) == ∑_{i∈[m]} 1/(x-q_i),
+//
+// where count(f) is provided by the prover and x is a challenge derived from
+// the commitment to the set, the queries and the counts. The argument is built
+// once for all queries after the circuit has been defined, so the complexity
+// is linear in the size of the set and the number of queries (O(n+m)).
+package setmembership
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/internal/logderivarg"
+)
+
+// Set holds the values of the set and the queried values.
+type Set struct {
+	api frontend.API
+
+	entries   []frontend.Variable
+	queries   []frontend.Variable
+	immutable bool
+}
+
+// New returns a new empty [*Set]. It additionally defers building the
+// log-derivative argument.
+func New(api frontend.API) *Set {
+	s := &Set{api: api}
+	api.Compiler().Defer(s.commit)
+	return s
+}
+
+// Insert inserts the values into the set. The values in the set must be
+// distinct, otherwise a proof cannot be generated. It panics if the set is
+// already committed.
+func (s *Set) Insert(vals ...frontend.Variable) {
+	if s.immutable {
+		panic("inserting into committed set")
+	}
+	s.entries = append(s.entries, vals...)
+}
+
+// AssertContains asserts that every value is in the set. The values can be
+// inserted into the set after the query. It panics if the set is already
+// committed.
+func (s *Set) AssertContains(vals ...frontend.Variable) {
+	if s.immutable {
+		panic("querying a committed set")
+	}
+	s.queries = append(s.queries, vals...)
+}
+
+func (s *Set) commit(api frontend.API) error {
+	s.immutable = true
+	if len(s.queries) == 0 {
+		return nil
+	}
+	return logderivarg.Build(api, logderivarg.AsTable(s.entries), logderivarg.AsTable(s.queries))
+}
@@ -0,0 +1,39 @@
+package setmembership
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type setCircuit struct {
+	Set     [10]frontend.Variable
+	Queries [20]frontend.Variable
+}
+
+func (c *setCircuit) Define(api frontend.API) error {
+	s := New(api)
+	s.AssertContains(c.Queries[:10]...)
+	s.Insert(c.Set[:]...)
+	s.Insert(1000, 2000)
+	s.AssertContains(c.Queries[10:]...)
+	return nil
+}
+
+func TestSetMembership(t *testing.T) {
+	assert := test.NewAssert(t)
+	var witness setCircuit
+	for i := range witness.Set {
+		witness.Set[i] = 3*i + 1
+	}
+	for i := range witness.Queries {
+		witness.Queries[i] = 3*((7*i)%len(witness.Set)) + 1
+	}
+	witness.Queries[15] = 2000
+	assert.CheckCircuit(&setCircuit{}, test.WithValidAssignment(&witness))
+
+	witness.Queries[3] = 2
+	assert.Error(test.IsSolved(&setCircuit{}, &witness, ecc.BN254.ScalarField()))
+}
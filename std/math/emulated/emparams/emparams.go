@@ -282,6 +282,41 @@ type BLS24315Fr struct{ fourLimbPrimeField }
 
 func (fr BLS24315Fr) Modulus() *big.Int { return ecc.BLS24_315.ScalarField() }
 
+// Curve25519Fp provides type parametrization for field emulation:
+//   - limbs: 4
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed (base 16)
+//	57896044618658097711785492504343953926634992332820282019728792003956564819949 (base 10)
+//
+// This is the base field of the Curve25519 curve and its birationally
+// equivalent twisted Edwards curve Edwards25519.
+type Curve25519Fp struct{ fourLimbPrimeField }
+
+func (fp Curve25519Fp) Modulus() *big.Int {
+	val, _ := new(big.Int).SetString("7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed", 16)
+	return val
+}
+
+// Curve25519Fr provides type parametrization for field emulation:
+//   - limbs: 4
+//   - limb width: 64 bits
+//
+// The prime modulus for type parametrisation is:
+//
+//	0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed (base 16)
+//	7237005577332262213973186563042994240857116359379907606001950938285454250989 (base 10)
+//
+// This is the order of the prime order subgroup of the Curve25519 curve.
+type Curve25519Fr struct{ fourLimbPrimeField }
+
+func (fr Curve25519Fr) Modulus() *big.Int {
+	val, _ := new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+	return val
+}
+
 // Mod1e4096 provides type parametrization for emulated aritmetic:
 //   - limbs: 64
 //   - limb width: 64 bits
@@ -1,10 +1,12 @@
 package uints
 
 import (
+	"math/big"
 	"math/bits"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/test"
 )
@@ -79,3 +81,92 @@ func TestRshift(t *testing.T) {
 	err = test.IsSolved(&rshiftCircuit{Shift: 11}, &rshiftCircuit{Shift: 11, In: NewU32(0x12345678), Expected: NewU32(0x12345678 >> 11)}, ecc.BN254.ScalarField())
 	assert.NoError(err)
 }
+
+type addCircuit struct {
+	In       [3]U64
+	Expected U64
+}
+
+func (c *addCircuit) Define(api frontend.API) error {
+	uapi, err := New[U64](api)
+	if err != nil {
+		return err
+	}
+	res := uapi.Add(c.In[:]...)
+	uapi.AssertEq(res, c.Expected)
+	return nil
+}
+
+func TestAdd(t *testing.T) {
+	assert := test.NewAssert(t)
+	a, b, c := uint64(0xfedcba9876543210), uint64(0xffffffffffffffff), uint64(0x8000000000000001)
+	err := test.IsSolved(&addCircuit{}, &addCircuit{In: [3]U64{NewU64(a), NewU64(b), NewU64(c)}, Expected: NewU64(a + b + c)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+	err = test.IsSolved(&addCircuit{}, &addCircuit{In: [3]U64{NewU64(a), NewU64(b), NewU64(c)}, Expected: NewU64(a + b)}, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+// maliciousToBytes returns a toBytes hint which decomposes the input plus
+// offset. If the carry is requested, it is set so that the bytes and the carry
+// still recompose the input.
+func maliciousToBytes(offset int64) solver.Hint {
+	return func(m *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+		nbLimbs := int(inputs[0].Uint64())
+		base := new(big.Int).Lsh(big.NewInt(1), uint(8*nbLimbs))
+		v := new(big.Int).Add(inputs[1], big.NewInt(offset))
+		v.Mod(v, base)
+		tmp := new(big.Int).Set(v)
+		for i := 0; i < nbLimbs; i++ {
+			outputs[i].Mod(tmp, big.NewInt(256))
+			tmp.Rsh(tmp, 8)
+		}
+		if len(outputs) == nbLimbs+1 {
+			// carry = (input - v) / 2^(8*nbLimbs) in the field
+			outputs[nbLimbs].Sub(inputs[1], v)
+			outputs[nbLimbs].Mul(outputs[nbLimbs], new(big.Int).ModInverse(base, m))
+			outputs[nbLimbs].Mod(outputs[nbLimbs], m)
+		}
+		return nil
+	}
+}
+
+func TestAddMaliciousCarry(t *testing.T) {
+	// the bytes of the sum and the omitted carry are computed by the prover.
+	// Without checking that the carry is small, the prover could return the
+	// bytes of any value and the carry matching them.
+	assert := test.NewAssert(t)
+	a, b, c := uint64(0xfedcba9876543210), uint64(0xffffffffffffffff), uint64(0x8000000000000001)
+	assert.CheckCircuit(&addCircuit{},
+		test.WithInvalidAssignment(&addCircuit{In: [3]U64{NewU64(a), NewU64(b), NewU64(c)}, Expected: NewU64(a + b + c + 1)}),
+		test.WithCurves(ecc.BN254), test.NoTestEngine(), test.WithSolverOpts(solver.OverrideHint(solver.GetHintID(toBytes), maliciousToBytes(1))))
+}
+
+type valueOfCircuit struct {
+	In       frontend.Variable
+	Expected U32
+}
+
+func (c *valueOfCircuit) Define(api frontend.API) error {
+	uapi, err := New[U32](api)
+	if err != nil {
+		return err
+	}
+	res := uapi.ValueOf(c.In)
+	uapi.AssertEq(res, c.Expected)
+	return nil
+}
+
+func TestValueOf(t *testing.T) {
+	assert := test.NewAssert(t)
+	err := test.IsSolved(&valueOfCircuit{}, &valueOfCircuit{In: 0x12345678, Expected: NewU32(0x12345678)}, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestValueOfMaliciousBytes(t *testing.T) {
+	// the bytes are computed by the prover. Without checking that they
+	// recompose to the input, the prover could return the bytes of any value.
+	assert := test.NewAssert(t)
+	assert.CheckCircuit(&valueOfCircuit{},
+		test.WithInvalidAssignment(&valueOfCircuit{In: 0x12345678, Expected: NewU32(0x12345679)}),
+		test.WithCurves(ecc.BN254), test.NoTestEngine(), test.WithSolverOpts(solver.OverrideHint(solver.GetHintID(toBytes), maliciousToBytes(1))))
+}
@@ -0,0 +1,116 @@
+// Package blake2b implements the BLAKE2b compression function F as defined in
+// RFC 7693.
+package blake2b
+
+import (
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// IV is the BLAKE2b initialization vector.
+var IV = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+var _sigma = [10][16]int{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+}
+
+func mix(uapi *uints.BinaryField[uints.U64], v *[16]uints.U64, a, b, c, d int, x, y uints.U64) {
+	v[a] = uapi.Add(v[a], v[b], x)
+	v[d] = uapi.Lrot(uapi.Xor(v[d], v[a]), -32)
+	v[c] = uapi.Add(v[c], v[d])
+	v[b] = uapi.Lrot(uapi.Xor(v[b], v[c]), -24)
+	v[a] = uapi.Add(v[a], v[b], y)
+	v[d] = uapi.Lrot(uapi.Xor(v[d], v[a]), -16)
+	v[c] = uapi.Add(v[c], v[d])
+	v[b] = uapi.Lrot(uapi.Xor(v[b], v[c]), -63)
+}
+
+// Compress applies the BLAKE2b compression function F with the given number of
+// rounds on the state h, message block m and offset counter t. The boolean
+// final indicates whether this is the last block. The number of rounds is
+// fixed at circuit compile time, see [CompressVarRounds] otherwise.
+func Compress(uapi *uints.BinaryField[uints.U64], api frontend.API, rounds int, h [8]uints.U64, m [16]uints.U64, t [2]uints.U64, final frontend.Variable) [8]uints.U64 {
+	v := initState(uapi, api, h, t, final)
+	for i := 0; i < rounds; i++ {
+		round(uapi, &v, m, i)
+	}
+	return finalize(uapi, h, v)
+}
+
+// CompressVarRounds applies the BLAKE2b compression function F as [Compress],
+// but the number of rounds is a variable. The circuit computes maxRounds rounds
+// and keeps the state after the given number of rounds, so rounds must be at
+// most maxRounds, otherwise the proof cannot be generated.
+func CompressVarRounds(uapi *uints.BinaryField[uints.U64], api frontend.API, rounds frontend.Variable, maxRounds int, h [8]uints.U64, m [16]uints.U64, t [2]uints.U64, final frontend.Variable) [8]uints.U64 {
+	if maxRounds < 0 {
+		panic("negative maximum number of rounds")
+	}
+	v := initState(uapi, api, h, t, final)
+	// done is 1 once the given number of rounds is applied. As rounds equals
+	// at most one i, done is boolean.
+	var done frontend.Variable = api.IsZero(rounds)
+	for i := 0; i < maxRounds; i++ {
+		next := v
+		round(uapi, &next, m, i)
+		for j := range v {
+			for k := range v[j] {
+				v[j][k].Val = api.Select(done, v[j][k].Val, next[j][k].Val)
+			}
+		}
+		done = api.Add(done, api.IsZero(api.Sub(rounds, i+1)))
+	}
+	// rounds ≤ maxRounds
+	api.AssertIsEqual(done, 1)
+	return finalize(uapi, h, v)
+}
+
+// initState returns the working vector v of the compression function.
+func initState(uapi *uints.BinaryField[uints.U64], api frontend.API, h [8]uints.U64, t [2]uints.U64, final frontend.Variable) [16]uints.U64 {
+	var v [16]uints.U64
+	copy(v[:8], h[:])
+	for i := range IV {
+		v[i+8] = uints.NewU64(IV[i])
+	}
+	v[12] = uapi.Xor(v[12], t[0])
+	v[13] = uapi.Xor(v[13], t[1])
+	api.AssertIsBoolean(final)
+	inverted := uapi.Not(v[14])
+	for i := range v[14] {
+		v[14][i].Val = api.Select(final, inverted[i].Val, v[14][i].Val)
+	}
+	return v
+}
+
+// round applies the round i of the compression function on v.
+func round(uapi *uints.BinaryField[uints.U64], v *[16]uints.U64, m [16]uints.U64, i int) {
+	s := &_sigma[i%10]
+	mix(uapi, v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+	mix(uapi, v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+	mix(uapi, v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+	mix(uapi, v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+	mix(uapi, v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+	mix(uapi, v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+	mix(uapi, v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+	mix(uapi, v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+}
+
+// finalize returns the new state from the state h and the working vector v.
+func finalize(uapi *uints.BinaryField[uints.U64], h [8]uints.U64, v [16]uints.U64) [8]uints.U64 {
+	var ret [8]uints.U64
+	for i := range ret {
+		ret[i] = uapi.Xor(h[i], v[i], v[i+8])
+	}
+	return ret
+}
@@ -0,0 +1,64 @@
+// Package blake3 implements the BLAKE3 compression function.
+//
+// See the [BLAKE3] specification.
+//
+// [BLAKE3]: https://github.com/BLAKE3-team/BLAKE3-specs/blob/master/blake3.pdf
+package blake3
+
+import (
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// IV is the BLAKE3 initialization vector.
+var IV = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+const nbRounds = 7
+
+var _permutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func g(uapi *uints.BinaryField[uints.U32], v *[16]uints.U32, a, b, c, d int, x, y uints.U32) {
+	v[a] = uapi.Add(v[a], v[b], x)
+	v[d] = uapi.Lrot(uapi.Xor(v[d], v[a]), -16)
+	v[c] = uapi.Add(v[c], v[d])
+	v[b] = uapi.Lrot(uapi.Xor(v[b], v[c]), -12)
+	v[a] = uapi.Add(v[a], v[b], y)
+	v[d] = uapi.Lrot(uapi.Xor(v[d], v[a]), -8)
+	v[c] = uapi.Add(v[c], v[d])
+	v[b] = uapi.Lrot(uapi.Xor(v[b], v[c]), -7)
+}
+
+// Compress applies the BLAKE3 compression function on the chaining value cv,
+// the message block m, the counter t, the number of bytes blockLen of the
+// block and the domain separation flags. It returns the first 8 words of the
+// output, which are the new chaining value.
+func Compress(uapi *uints.BinaryField[uints.U32], cv [8]uints.U32, m [16]uints.U32, t [2]uints.U32, blockLen, flags uints.U32) [8]uints.U32 {
+	var v [16]uints.U32
+	copy(v[:8], cv[:])
+	for i := 0; i < 4; i++ {
+		v[i+8] = uints.NewU32(IV[i])
+	}
+	v[12], v[13], v[14], v[15] = t[0], t[1], blockLen, flags
+	for r := 0; r < nbRounds; r++ {
+		g(uapi, &v, 0, 4, 8, 12, m[0], m[1])
+		g(uapi, &v, 1, 5, 9, 13, m[2], m[3])
+		g(uapi, &v, 2, 6, 10, 14, m[4], m[5])
+		g(uapi, &v, 3, 7, 11, 15, m[6], m[7])
+		g(uapi, &v, 0, 5, 10, 15, m[8], m[9])
+		g(uapi, &v, 1, 6, 11, 12, m[10], m[11])
+		g(uapi, &v, 2, 7, 8, 13, m[12], m[13])
+		g(uapi, &v, 3, 4, 9, 14, m[14], m[15])
+		var permuted [16]uints.U32
+		for i := range permuted {
+			permuted[i] = m[_permutation[i]]
+		}
+		m = permuted
+	}
+	var ret [8]uints.U32
+	for i := range ret {
+		ret[i] = uapi.Xor(v[i], v[i+8])
+	}
+	return ret
+}
@@ -0,0 +1,38 @@
+// Package multiset implements multiset equality checks using the
+// log-derivative argument.
+//
+// To check that b is a permutation of a, we check that
+//
+//	∑_{i∈[n]} 1/(x-a_i) == ∑_{i∈[n]} 1/(x-b_i),
+//
+// where x is a challenge derived from the commitment to a and b. For tuples,
+// we check the equation for random linear combinations of the elements of
+// the tuples. The check is performed after the circuit has been defined.
+package multiset
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/internal/logderivarg"
+)
+
+// AssertIsPermutation asserts that the multisets of the elements of a and b are
+// equal, that is b is a permutation of a. It returns an error if a and b have
+// different lengths.
+func AssertIsPermutation(api frontend.API, a, b []frontend.Variable) error {
+	return AssertIsTuplePermutation(api, logderivarg.AsTable(a), logderivarg.AsTable(b))
+}
+
+// AssertIsTuplePermutation asserts that the multisets of the tuples of a and b
+// are equal, that is b is a permutation of a. It returns an error if a and b
+// have different lengths or if the tuples have different lengths.
+func AssertIsTuplePermutation(api frontend.API, a, b [][]frontend.Variable) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("length mismatch: %d != %d", len(a), len(b))
+	}
+	if err := logderivarg.BuildPermutation(api, a, b); err != nil {
+		return fmt.Errorf("build permutation: %w", err)
+	}
+	return nil
+}
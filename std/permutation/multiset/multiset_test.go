@@ -0,0 +1,61 @@
+package multiset
+
+import (
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+type permutationCircuit struct {
+	A, B [10]frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	return AssertIsPermutation(api, c.A[:], c.B[:])
+}
+
+type tuplePermutationCircuit struct {
+	A, B [10][2]frontend.Variable
+}
+
+func (c *tuplePermutationCircuit) Define(api frontend.API) error {
+	a := make([][]frontend.Variable, len(c.A))
+	b := make([][]frontend.Variable, len(c.B))
+	for i := range c.A {
+		a[i] = c.A[i][:]
+		b[i] = c.B[i][:]
+	}
+	return AssertIsTuplePermutation(api, a, b)
+}
+
+func TestPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	var witness permutationCircuit
+	for i := range witness.A {
+		witness.A[i] = i % 4
+		witness.B[(3*i+1)%len(witness.B)] = i % 4
+	}
+	assert.CheckCircuit(&permutationCircuit{}, test.WithValidAssignment(&witness))
+
+	// same elements, different multiplicities
+	witness.B[0], witness.B[1] = 0, 0
+	witness.A[0], witness.A[1] = 0, 1
+	assert.Error(test.IsSolved(&permutationCircuit{}, &witness, ecc.BN254.ScalarField()))
+}
+
+func TestTuplePermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	var witness tuplePermutationCircuit
+	for i := range witness.A {
+		j := (3*i + 1) % len(witness.B)
+		witness.A[i] = [2]frontend.Variable{i, 10 * i}
+		witness.B[j] = [2]frontend.Variable{i, 10 * i}
+	}
+	assert.CheckCircuit(&tuplePermutationCircuit{}, test.WithValidAssignment(&witness))
+
+	// the elements of the tuples are permutations, but not the tuples
+	witness.B[0][1], witness.B[1][1] = witness.B[1][1], witness.B[0][1]
+	assert.Error(test.IsSolved(&tuplePermutationCircuit{}, &witness, ecc.BN254.ScalarField()))
+}
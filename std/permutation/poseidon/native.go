@@ -0,0 +1,48 @@
+package poseidon
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Permute applies the Poseidon permutation to state in place using native
+// arithmetic. The elements of state must be reduced modulo p.Modulus. It is the
+// counterpart of [Permutation.Permute] for witness generation.
+func (p *Parameters) Permute(state []*big.Int) error {
+	if len(state) != p.Width {
+		return fmt.Errorf("state length %d mismatch width %d", len(state), p.Width)
+	}
+	alpha := big.NewInt(int64(p.Alpha))
+	tmp := make([]*big.Int, p.Width)
+	for i := range tmp {
+		tmp[i] = new(big.Int)
+	}
+	var t big.Int
+	for r := range p.RoundConstants {
+		for i := range state {
+			state[i].Add(state[i], p.RoundConstants[r][i])
+		}
+		if p.isFullRound(r) {
+			for i := range state {
+				state[i].Exp(state[i], alpha, p.Modulus)
+			}
+		} else {
+			state[0].Exp(state[0], alpha, p.Modulus)
+		}
+		for i := range tmp {
+			tmp[i].SetUint64(0)
+			for j := range state {
+				tmp[i].Add(tmp[i], t.Mul(p.MDS[i][j], state[j]))
+			}
+		}
+		for i := range state {
+			state[i].Mod(tmp[i], p.Modulus)
+		}
+	}
+	return nil
+}
+
+// isFullRound returns true if round r applies the S-box to the whole state.
+func (p *Parameters) isFullRound(r int) bool {
+	return r < p.NbFullRounds/2 || r >= p.NbFullRounds/2+p.NbPartialRounds
+}
@@ -0,0 +1,158 @@
+package poseidon
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/internal/grain"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+const (
+	// MinWidth is the smallest supported width of the permutation.
+	MinWidth = 2
+	// MaxWidth is the largest supported width of the permutation.
+	MaxWidth = 17
+
+	nbFullRounds = 8
+)
+
+// Parameters are the parameters of the Poseidon permutation of a given width
+// over a given prime field.
+type Parameters struct {
+	// Modulus is the modulus of the field.
+	Modulus *big.Int
+	// Width is the size t of the state.
+	Width int
+	// Alpha is the exponent of the S-box x ↦ xᵅ.
+	Alpha int
+	// NbFullRounds is the number of rounds where the S-box is applied to the
+	// whole state. Half of them are applied before the partial rounds.
+	NbFullRounds int
+	// NbPartialRounds is the number of rounds where the S-box is applied to the
+	// first element of the state only.
+	NbPartialRounds int
+	// RoundConstants are the constants added to the state at the beginning of
+	// every round.
+	RoundConstants [][]*big.Int
+	// MDS is the matrix applied to the state at the end of every round.
+	MDS [][]*big.Int
+}
+
+type curveParameters struct {
+	alpha int
+	// nbPartialRounds[i] is the number of partial rounds for the width
+	// MinWidth+i. The numbers are computed for 128 bits of security with the
+	// reference script and rounded up to a multiple of the width, as in
+	// circomlib.
+	nbPartialRounds [MaxWidth - MinWidth + 1]int
+}
+
+var curves = map[ecc.ID]curveParameters{
+	ecc.BN254: {
+		alpha:           5,
+		nbPartialRounds: [...]int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68},
+	},
+	ecc.BLS12_381: {
+		alpha:           5,
+		nbPartialRounds: [...]int{56, 57, 56, 60, 60, 63, 64, 63, 60, 66, 60, 65, 70, 60, 64, 68},
+	},
+	ecc.BLS12_377: {
+		// 5 is not coprime with r-1
+		alpha:           17,
+		nbPartialRounds: [...]int{32, 33, 32, 35, 36, 35, 32, 36, 40, 33, 36, 39, 42, 45, 32, 34},
+	},
+}
+
+type parametersKey struct {
+	curve ecc.ID
+	width int
+}
+
+var parametersCache sync.Map
+
+// GetParameters returns the parameters of the Poseidon permutation of the
+// given width over the scalar field of BN254, BLS12-377 or BLS12-381. The round
+// constants and the MDS matrix are derived with the Grain LFSR as in the
+// reference implementation. For BN254 they coincide with the ones used in
+// circomlib.
+func GetParameters(field *big.Int, width int) (*Parameters, error) {
+	curve := utils.FieldToCurve(field)
+	cp, ok := curves[curve]
+	if !ok {
+		return nil, fmt.Errorf("unsupported field %s", field.String())
+	}
+	if width < MinWidth || width > MaxWidth {
+		return nil, fmt.Errorf("width %d not in [%d, %d]", width, MinWidth, MaxWidth)
+	}
+	key := parametersKey{curve: curve, width: width}
+	if p, ok := parametersCache.Load(key); ok {
+		return p.(*Parameters), nil
+	}
+	p := newParameters(field, width, cp.alpha, nbFullRounds, cp.nbPartialRounds[width-MinWidth])
+	parametersCache.Store(key, p)
+	return p, nil
+}
+
+func newParameters(modulus *big.Int, width, alpha, nbFullRounds, nbPartialRounds int) *Parameters {
+	p := &Parameters{
+		Modulus:         new(big.Int).Set(modulus),
+		Width:           width,
+		Alpha:           alpha,
+		NbFullRounds:    nbFullRounds,
+		NbPartialRounds: nbPartialRounds,
+		RoundConstants:  make([][]*big.Int, nbFullRounds+nbPartialRounds),
+	}
+	g := grain.New(modulus.BitLen(), width, nbFullRounds, nbPartialRounds)
+	for i := range p.RoundConstants {
+		p.RoundConstants[i] = make([]*big.Int, width)
+		for j := range p.RoundConstants[i] {
+			p.RoundConstants[i][j] = g.FieldElement(modulus)
+		}
+	}
+	p.MDS = newCauchyMatrix(g, modulus, width)
+	return p
+}
+
+// newCauchyMatrix returns the matrix Mᵢⱼ = 1/(xᵢ+yⱼ) where xᵢ and yⱼ are
+// pairwise distinct elements sampled with the LFSR, resampling them until the
+// matrix is well-defined.
+func newCauchyMatrix(g *grain.LFSR, modulus *big.Int, width int) [][]*big.Int {
+	for {
+		xy := make([]*big.Int, 2*width)
+		for i := range xy {
+			xy[i] = g.Bits(modulus.BitLen())
+			xy[i].Mod(xy[i], modulus)
+		}
+		if !distinct(xy) {
+			continue
+		}
+		xs, ys := xy[:width], xy[width:]
+		m := make([][]*big.Int, width)
+		ok := true
+		for i := 0; i < width && ok; i++ {
+			m[i] = make([]*big.Int, width)
+			for j := 0; j < width && ok; j++ {
+				m[i][j] = new(big.Int).Add(xs[i], ys[j])
+				m[i][j].Mod(m[i][j], modulus)
+				ok = m[i][j].ModInverse(m[i][j], modulus) != nil
+			}
+		}
+		if ok {
+			return m
+		}
+	}
+}
+
+func distinct(v []*big.Int) bool {
+	for i := range v {
+		for j := i + 1; j < len(v); j++ {
+			if v[i].Cmp(v[j]) == 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+// Package poseidon implements the Poseidon permutation over the scalar fields
+// of BN254, BLS12-377 and BLS12-381.
+//
+// The permutation follows the original Poseidon paper [Poseidon] with the
+// S-box x ↦ xᵅ. Both the in-circuit permutation [Permutation] and the native
+// counterpart [Parameters.Permute] are provided. For the sponge construction
+// see [github.com/consensys/gnark/std/hash/poseidon].
+//
+// [Poseidon]: https://eprint.iacr.org/2019/458
+package poseidon
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Permutation is the in-circuit Poseidon permutation of a fixed width.
+type Permutation struct {
+	api    frontend.API
+	params *Parameters
+}
+
+// NewPermutation returns a new Poseidon permutation of the given width over the
+// native field of api.
+func NewPermutation(api frontend.API, width int) (*Permutation, error) {
+	params, err := GetParameters(api.Compiler().Field(), width)
+	if err != nil {
+		return nil, fmt.Errorf("get parameters: %w", err)
+	}
+	return &Permutation{api: api, params: params}, nil
+}
+
+// Permute applies the permutation to state and returns the new state. It
+// returns an error if the length of state does not match the width of the
+// permutation.
+func (p *Permutation) Permute(state []frontend.Variable) ([]frontend.Variable, error) {
+	if len(state) != p.params.Width {
+		return nil, fmt.Errorf("state length %d mismatch width %d", len(state), p.params.Width)
+	}
+	res := make([]frontend.Variable, len(state))
+	copy(res, state)
+	for r := range p.params.RoundConstants {
+		for i := range res {
+			res[i] = p.api.Add(res[i], p.params.RoundConstants[r][i])
+		}
+		if p.params.isFullRound(r) {
+			for i := range res {
+				res[i] = p.sBox(res[i])
+			}
+		} else {
+			res[0] = p.sBox(res[0])
+		}
+		res = p.matMul(res)
+	}
+	return res, nil
+}
+
+// sBox returns xᵅ.
+func (p *Permutation) sBox(x frontend.Variable) frontend.Variable {
+	res := x
+	for i := bits.Len(uint(p.params.Alpha)) - 2; i >= 0; i-- {
+		res = p.api.Mul(res, res)
+		if (p.params.Alpha>>i)&1 == 1 {
+			res = p.api.Mul(res, x)
+		}
+	}
+	return res
+}
+
+// matMul returns MDS⋅state.
+func (p *Permutation) matMul(state []frontend.Variable) []frontend.Variable {
+	res := make([]frontend.Variable, len(state))
+	for i := range res {
+		res[i] = p.api.Mul(p.params.MDS[i][0], state[0])
+		for j := 1; j < len(state); j++ {
+			res[i] = p.api.Add(res[i], p.api.Mul(p.params.MDS[i][j], state[j]))
+		}
+	}
+	return res
+}
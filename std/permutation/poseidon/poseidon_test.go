@@ -0,0 +1,73 @@
+package poseidon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+func TestPermuteNative(t *testing.T) {
+	assert := test.NewAssert(t)
+	// circomlib Poseidon(inputs) is the first element of the permutation of
+	// [0, inputs...]
+	testCases := []struct {
+		inputs   []int64
+		expected string
+	}{
+		{[]int64{1}, "18586133768512220936620570745912940619677854269274689475585506675881198879027"},
+		{[]int64{1, 2}, "7853200120776062878684798364095072458815029376092732009249414926327459813530"},
+		{[]int64{1, 2, 3}, "6542985608222806190361240322586112750744169038454362455181422643027100751666"},
+		{[]int64{1, 2, 3, 4}, "18821383157269793795438455681495246036402687001665670618754263018637548127333"},
+	}
+	for _, tc := range testCases {
+		params, err := GetParameters(ecc.BN254.ScalarField(), len(tc.inputs)+1)
+		assert.NoError(err)
+		state := []*big.Int{new(big.Int)}
+		for _, v := range tc.inputs {
+			state = append(state, big.NewInt(v))
+		}
+		assert.NoError(params.Permute(state))
+		assert.Equal(tc.expected, state[0].String())
+	}
+}
+
+type permutationCircuit struct {
+	In       [3]frontend.Variable
+	Expected [3]frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	p, err := NewPermutation(api, len(c.In))
+	if err != nil {
+		return err
+	}
+	res, err := p.Permute(c.In[:])
+	if err != nil {
+		return err
+	}
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381} {
+		params, err := GetParameters(curve.ScalarField(), 3)
+		assert.NoError(err)
+		state := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+		var witness permutationCircuit
+		for i := range state {
+			witness.In[i] = new(big.Int).Set(state[i])
+		}
+		assert.NoError(params.Permute(state))
+		for i := range state {
+			witness.Expected[i] = state[i]
+		}
+		assert.CheckCircuit(&permutationCircuit{}, test.WithValidAssignment(&witness), test.WithCurves(curve))
+	}
+}
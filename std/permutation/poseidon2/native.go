@@ -0,0 +1,55 @@
+package poseidon2
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Permute applies the Poseidon2 permutation to state in place using native
+// arithmetic. The elements of state must be reduced modulo p.Modulus. It is the
+// counterpart of [Permutation.Permute] for witness generation.
+func (p *Parameters) Permute(state []*big.Int) error {
+	if len(state) != p.Width {
+		return fmt.Errorf("state length %d mismatch width %d", len(state), p.Width)
+	}
+	alpha := big.NewInt(int64(p.Alpha))
+	p.matMulExternal(state)
+	for r := range p.RoundConstants {
+		for i := range p.RoundConstants[r] {
+			state[i].Add(state[i], p.RoundConstants[r][i])
+			state[i].Exp(state[i], alpha, p.Modulus)
+		}
+		if p.isFullRound(r) {
+			p.matMulExternal(state)
+		} else {
+			p.matMulInternal(state)
+		}
+	}
+	return nil
+}
+
+// matMulExternal multiplies state by circ(2,1) or circ(2,1,1), that is adds
+// the sum of the elements to every element.
+func (p *Parameters) matMulExternal(state []*big.Int) {
+	sum := new(big.Int)
+	for i := range state {
+		sum.Add(sum, state[i])
+	}
+	for i := range state {
+		state[i].Add(state[i], sum)
+		state[i].Mod(state[i], p.Modulus)
+	}
+}
+
+// matMulInternal multiplies state by J+D, that is computes sum+dᵢ⋅stateᵢ.
+func (p *Parameters) matMulInternal(state []*big.Int) {
+	sum := new(big.Int)
+	for i := range state {
+		sum.Add(sum, state[i])
+	}
+	for i := range state {
+		state[i].Mul(state[i], big.NewInt(p.InternalDiagonal[i]))
+		state[i].Add(state[i], sum)
+		state[i].Mod(state[i], p.Modulus)
+	}
+}
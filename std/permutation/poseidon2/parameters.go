@@ -0,0 +1,111 @@
+package poseidon2
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/internal/grain"
+	"github.com/consensys/gnark/internal/utils"
+)
+
+const nbFullRounds = 8
+
+// Parameters are the parameters of the Poseidon2 permutation of a given width
+// over a given prime field.
+type Parameters struct {
+	// Modulus is the modulus of the field.
+	Modulus *big.Int
+	// Width is the size t of the state. Only widths 2 and 3 are supported.
+	Width int
+	// Alpha is the exponent of the S-box x ↦ xᵅ.
+	Alpha int
+	// NbFullRounds is the number of external rounds. Half of them are applied
+	// before the internal rounds.
+	NbFullRounds int
+	// NbPartialRounds is the number of internal rounds.
+	NbPartialRounds int
+	// RoundConstants are the constants added to the state at the beginning of
+	// every round. For the internal rounds, only the first element of the state
+	// gets a constant.
+	RoundConstants [][]*big.Int
+	// InternalDiagonal is the diagonal D of the internal matrix J+D where J is
+	// the all-ones matrix.
+	InternalDiagonal []int64
+}
+
+type curveParameters struct {
+	alpha           int
+	nbPartialRounds int
+}
+
+// the number of partial rounds are computed for 128 bits of security with the
+// reference script and are the same for widths 2 and 3.
+var curves = map[ecc.ID]curveParameters{
+	ecc.BN254:     {alpha: 5, nbPartialRounds: 56},
+	ecc.BLS12_381: {alpha: 5, nbPartialRounds: 56},
+	// 5 is not coprime with r-1
+	ecc.BLS12_377: {alpha: 17, nbPartialRounds: 31},
+}
+
+// internal matrices for widths 2 and 3 as defined in the Poseidon2 paper.
+var internalDiagonals = map[int][]int64{
+	2: {1, 2},
+	3: {1, 1, 2},
+}
+
+type parametersKey struct {
+	curve ecc.ID
+	width int
+}
+
+var parametersCache sync.Map
+
+// GetParameters returns the parameters of the Poseidon2 permutation of the
+// given width over the scalar field of BN254, BLS12-377 or BLS12-381. The round
+// constants are derived with the Grain LFSR as in the reference
+// implementation. For BN254 and width 3 they coincide with the HorizenLabs
+// parameters.
+func GetParameters(field *big.Int, width int) (*Parameters, error) {
+	curve := utils.FieldToCurve(field)
+	cp, ok := curves[curve]
+	if !ok {
+		return nil, fmt.Errorf("unsupported field %s", field.String())
+	}
+	diag, ok := internalDiagonals[width]
+	if !ok {
+		return nil, fmt.Errorf("unsupported width %d", width)
+	}
+	key := parametersKey{curve: curve, width: width}
+	if p, ok := parametersCache.Load(key); ok {
+		return p.(*Parameters), nil
+	}
+	p := &Parameters{
+		Modulus:          new(big.Int).Set(field),
+		Width:            width,
+		Alpha:            cp.alpha,
+		NbFullRounds:     nbFullRounds,
+		NbPartialRounds:  cp.nbPartialRounds,
+		RoundConstants:   make([][]*big.Int, nbFullRounds+cp.nbPartialRounds),
+		InternalDiagonal: diag,
+	}
+	g := grain.New(field.BitLen(), width, nbFullRounds, cp.nbPartialRounds)
+	for r := range p.RoundConstants {
+		n := 1
+		if p.isFullRound(r) {
+			n = width
+		}
+		p.RoundConstants[r] = make([]*big.Int, n)
+		for i := range p.RoundConstants[r] {
+			p.RoundConstants[r][i] = g.FieldElement(field)
+		}
+	}
+	parametersCache.Store(key, p)
+	return p, nil
+}
+
+// isFullRound returns true if round r is an external round.
+func (p *Parameters) isFullRound(r int) bool {
+	return r < p.NbFullRounds/2 || r >= p.NbFullRounds/2+p.NbPartialRounds
+}
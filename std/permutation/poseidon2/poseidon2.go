@@ -0,0 +1,84 @@
+// Package poseidon2 implements the Poseidon2 permutation over the scalar fields
+// of BN254, BLS12-377 and BLS12-381.
+//
+// The permutation follows the Poseidon2 paper [Poseidon2] for widths 2 and 3.
+// Both the in-circuit permutation [Permutation] and the native counterpart
+// [Parameters.Permute] are provided. For the hash function see
+// [github.com/consensys/gnark/std/hash/poseidon2].
+//
+// [Poseidon2]: https://eprint.iacr.org/2023/323
+package poseidon2
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/consensys/gnark/frontend"
+)
+
+// Permutation is the in-circuit Poseidon2 permutation of a fixed width.
+type Permutation struct {
+	api    frontend.API
+	params *Parameters
+}
+
+// NewPermutation returns a new Poseidon2 permutation of the given width over
+// the native field of api.
+func NewPermutation(api frontend.API, width int) (*Permutation, error) {
+	params, err := GetParameters(api.Compiler().Field(), width)
+	if err != nil {
+		return nil, fmt.Errorf("get parameters: %w", err)
+	}
+	return &Permutation{api: api, params: params}, nil
+}
+
+// Permute applies the permutation to state and returns the new state. It
+// returns an error if the length of state does not match the width of the
+// permutation.
+func (p *Permutation) Permute(state []frontend.Variable) ([]frontend.Variable, error) {
+	if len(state) != p.params.Width {
+		return nil, fmt.Errorf("state length %d mismatch width %d", len(state), p.params.Width)
+	}
+	res := make([]frontend.Variable, len(state))
+	copy(res, state)
+	p.matMulExternal(res)
+	for r := range p.params.RoundConstants {
+		for i := range p.params.RoundConstants[r] {
+			res[i] = p.sBox(p.api.Add(res[i], p.params.RoundConstants[r][i]))
+		}
+		if p.params.isFullRound(r) {
+			p.matMulExternal(res)
+		} else {
+			p.matMulInternal(res)
+		}
+	}
+	return res, nil
+}
+
+// sBox returns xᵅ.
+func (p *Permutation) sBox(x frontend.Variable) frontend.Variable {
+	res := x
+	for i := bits.Len(uint(p.params.Alpha)) - 2; i >= 0; i-- {
+		res = p.api.Mul(res, res)
+		if (p.params.Alpha>>i)&1 == 1 {
+			res = p.api.Mul(res, x)
+		}
+	}
+	return res
+}
+
+// matMulExternal multiplies state in place by circ(2,1) or circ(2,1,1).
+func (p *Permutation) matMulExternal(state []frontend.Variable) {
+	sum := p.api.Add(state[0], state[1], state[2:]...)
+	for i := range state {
+		state[i] = p.api.Add(state[i], sum)
+	}
+}
+
+// matMulInternal multiplies state in place by J+D.
+func (p *Permutation) matMulInternal(state []frontend.Variable) {
+	sum := p.api.Add(state[0], state[1], state[2:]...)
+	for i := range state {
+		state[i] = p.api.Add(p.api.Mul(state[i], p.params.InternalDiagonal[i]), sum)
+	}
+}
@@ -0,0 +1,70 @@
+package poseidon2
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/test"
+)
+
+func TestPermuteNative(t *testing.T) {
+	assert := test.NewAssert(t)
+	// test vector of the HorizenLabs reference implementation
+	params, err := GetParameters(ecc.BN254.ScalarField(), 3)
+	assert.NoError(err)
+	state := []*big.Int{big.NewInt(0), big.NewInt(1), big.NewInt(2)}
+	assert.NoError(params.Permute(state))
+	expected := []string{
+		"0bb61d24daca55eebcb1929a82650f328134334da98ea4f847f760054f4a3033",
+		"303b6f7c86d043bfcbcc80214f26a30277a15d3f74ca654992defe7ff8d03570",
+		"1ed25194542b12eef8617361c3ba7c52e660b145994427cc86296242cf766ec8",
+	}
+	for i := range state {
+		assert.Equal(expected[i], fmt.Sprintf("%064x", state[i]))
+	}
+}
+
+type permutationCircuit struct {
+	In       []frontend.Variable
+	Expected []frontend.Variable
+}
+
+func (c *permutationCircuit) Define(api frontend.API) error {
+	p, err := NewPermutation(api, len(c.In))
+	if err != nil {
+		return err
+	}
+	res, err := p.Permute(c.In)
+	if err != nil {
+		return err
+	}
+	for i := range res {
+		api.AssertIsEqual(res[i], c.Expected[i])
+	}
+	return nil
+}
+
+func TestPermutation(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, curve := range []ecc.ID{ecc.BN254, ecc.BLS12_377, ecc.BLS12_381} {
+		for _, width := range []int{2, 3} {
+			params, err := GetParameters(curve.ScalarField(), width)
+			assert.NoError(err)
+			state := make([]*big.Int, width)
+			witness := permutationCircuit{In: make([]frontend.Variable, width), Expected: make([]frontend.Variable, width)}
+			for i := range state {
+				state[i] = big.NewInt(int64(i + 1))
+				witness.In[i] = i + 1
+			}
+			assert.NoError(params.Permute(state))
+			for i := range state {
+				witness.Expected[i] = state[i]
+			}
+			circuit := permutationCircuit{In: make([]frontend.Variable, width), Expected: make([]frontend.Variable, width)}
+			assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(curve))
+		}
+	}
+}
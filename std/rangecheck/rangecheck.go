@@ -10,6 +10,8 @@
 package rangecheck
 
 import (
+	"math/big"
+
 	"github.com/consensys/gnark/constraint/solver"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/frontend/cs/r1cs"
@@ -20,15 +22,57 @@ import (
 // package anyway in test.
 var _ = r1cs.NewBuilder
 
+// Rangechecker allows to range-check variables to be of specified width or
+// to be less than a bound.
+type Rangechecker interface {
+	frontend.Rangechecker
+	// CheckBound checks that the given variable v is less than bound. The
+	// bound must be positive and smaller than the half of the field modulus.
+	CheckBound(v frontend.Variable, bound *big.Int)
+}
+
 // New returns a new range checker depending on the frontend capabilities.
-func New(api frontend.API) frontend.Rangechecker {
+func New(api frontend.API) Rangechecker {
 	if rc, ok := api.(frontend.Rangechecker); ok {
-		return rc
+		return boundChecker{api: api, Rangechecker: rc}
 	}
 	if _, ok := api.(frontend.Committer); ok {
-		return newCommitRangechecker(api)
+		return boundChecker{api: api, Rangechecker: newCommitRangechecker(api)}
+	}
+	return boundChecker{api: api, Rangechecker: plainChecker{api: api}}
+}
+
+// boundChecker implements the bound checks using the width checks of the
+// underlying range checker.
+type boundChecker struct {
+	frontend.Rangechecker
+	api frontend.API
+}
+
+// CheckBound checks that v < bound. When bound is a power of two, it is a
+// single width check. Otherwise, let n be the bit-length of bound-1. We check
+// that both v and bound-1-v are n bits wide, which share the lookup table of
+// the width checks. If v ≥ bound, then bound-1-v is at least p-2^n, which is
+// not n bits wide as 2^(n+1) < p.
+func (c boundChecker) CheckBound(v frontend.Variable, bound *big.Int) {
+	if bound.Sign() <= 0 {
+		panic("bound must be positive")
+	}
+	max := new(big.Int).Sub(bound, big.NewInt(1))
+	nbBits := max.BitLen()
+	if nbBits+2 > c.api.Compiler().FieldBitLen() {
+		panic("bound too large")
+	}
+	if max.Sign() == 0 {
+		c.api.AssertIsEqual(v, 0)
+		return
+	}
+	c.Check(v, nbBits)
+	if new(big.Int).And(bound, max).Sign() == 0 {
+		// bound = 2^nbBits
+		return
 	}
-	return plainChecker{api: api}
+	c.Check(c.api.Sub(max, v), nbBits)
 }
 
 // GetHints returns all hints used in this package
@@ -44,3 +44,35 @@ func TestCheck(t *testing.T) {
 	_, err = frontend.Compile(ecc.BN254.ScalarField(), r1cs.NewBuilder, &circuit, frontend.WithCompressThreshold(100))
 	assert.NoError(err)
 }
+
+type CheckBoundCircuit struct {
+	Vals  []frontend.Variable
+	bound *big.Int
+}
+
+func (c *CheckBoundCircuit) Define(api frontend.API) error {
+	r := New(api)
+	for i := range c.Vals {
+		r.CheckBound(c.Vals[i], c.bound)
+	}
+	return nil
+}
+
+func TestCheckBound(t *testing.T) {
+	assert := test.NewAssert(t)
+	largeBound, _ := new(big.Int).SetString("21888242871839275222246405745257275088548364400416034343698204186575808", 10)
+	for _, bound := range []*big.Int{big.NewInt(1), big.NewInt(1000), big.NewInt(1024), big.NewInt(1025), largeBound} {
+		assert.Run(func(assert *test.Assert) {
+			max := new(big.Int).Sub(bound, big.NewInt(1))
+			vals := []frontend.Variable{0, max, new(big.Int).Rsh(bound, 1)}
+			circuit := CheckBoundCircuit{Vals: make([]frontend.Variable, len(vals)), bound: bound}
+			witness := CheckBoundCircuit{Vals: vals, bound: bound}
+			assert.CheckCircuit(&circuit, test.WithValidAssignment(&witness), test.WithCurves(ecc.BN254))
+
+			for _, invalid := range []*big.Int{bound, new(big.Int).Add(bound, big.NewInt(12345)), new(big.Int).Sub(ecc.BN254.ScalarField(), big.NewInt(1))} {
+				witness := CheckBoundCircuit{Vals: []frontend.Variable{0, invalid, 0}, bound: bound}
+				assert.Error(test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()))
+			}
+		}, bound.String())
+	}
+}
@@ -0,0 +1,677 @@
+package groth16
+
+import (
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315"
+	fr_bls24315 "github.com/consensys/gnark-crypto/ecc/bls24-315/fr"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
+	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16backend_bls12377 "github.com/consensys/gnark/backend/groth16/bls12-377"
+	groth16backend_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
+	groth16backend_bls24315 "github.com/consensys/gnark/backend/groth16/bls24-315"
+	groth16backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	groth16backend_bw6761 "github.com/consensys/gnark/backend/groth16/bw6-761"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bw6761"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls24315"
+	"github.com/consensys/gnark/std/commitments/pedersen"
+	"github.com/consensys/gnark/std/math/bits"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/recursion"
+)
+
+// FoldedProof is a typed folded Groth16 proof, see [groth16.FoldProofs]. Use
+// [ValueOfFoldedProof] to initialize the witness from the native folded proof
+// and [PlaceholderFoldedProof] for stub placeholder.
+//
+// The folded commitments are already accounted for in the folded public input
+// accumulator [FoldedWitness].H, so checking the folded proof only needs their
+// folded proof of knowledge. They are only bound in the transcript of
+// [Verifier.FoldProof].
+type FoldedProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT] struct {
+	Ar, Krs       G1El
+	Bs            G2El
+	Commitments   []pedersen.Commitment[G1El]
+	CommitmentPok pedersen.KnowledgeProof[G1El]
+}
+
+// PlaceholderFoldedProof returns a placeholder folded proof witness to be use
+// for compiling the outer circuit for witness alignment.
+func PlaceholderFoldedProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](ccs constraint.ConstraintSystem) FoldedProof[G1El, G2El] {
+	return FoldedProof[G1El, G2El]{
+		Commitments: make([]pedersen.Commitment[G1El], len(ccs.GetCommitments().(constraint.Groth16Commitments))),
+	}
+}
+
+// FoldedWitness is the public part of a folded instance: the folded public
+// input accumulator H, the error term E, the scalar Mu and the folded batched
+// commitment. Use [ValueOfFoldedWitness] to initialize the witness from the
+// native folded witness.
+type FoldedWitness[FR emulated.FieldParams, G1El algebra.G1ElementT, GtEl algebra.GtElementT] struct {
+	H          G1El
+	E          GtEl
+	Mu         emulated.Element[FR]
+	Commitment pedersen.Commitment[G1El]
+}
+
+// FoldingVerifyingKey is a typed Groth16 verifying key for checking folded
+// proofs. As e(α, β) is raised to the folded μ², it holds α and β instead of
+// their pairing. K is only used for folding proofs with [Verifier.FoldProof].
+// For witness creation use the method [ValueOfFoldingVerifyingKey] and for stub
+// placeholder use [PlaceholderFoldingVerifyingKey].
+type FoldingVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT] struct {
+	G1 struct {
+		Alpha G1El
+		K     []G1El
+	}
+	G2                           struct{ Beta, GammaNeg, DeltaNeg G2El }
+	CommitmentKey                pedersen.VerifyingKey[G2El]
+	PublicAndCommitmentCommitted [][]int
+}
+
+// PlaceholderFoldingVerifyingKey returns an empty folding verifying key for a
+// given compiled constraint system.
+func PlaceholderFoldingVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT](ccs constraint.ConstraintSystem) FoldingVerifyingKey[G1El, G2El] {
+	commitments := ccs.GetCommitments().(constraint.Groth16Commitments)
+	commitmentWires := commitments.CommitmentIndexes()
+
+	var ret FoldingVerifyingKey[G1El, G2El]
+	ret.G1.K = make([]G1El, ccs.GetNbPublicVariables()+len(commitments))
+	ret.PublicAndCommitmentCommitted = commitments.GetPublicAndCommitmentCommitted(commitmentWires, ccs.GetNbPublicVariables())
+	return ret
+}
+
+// ValueOfFoldedProof returns the typed witness of the native folded proof. It
+// returns an error if there is a mismatch between the type parameters and the
+// provided native folded proof.
+func ValueOfFoldedProof[G1El algebra.G1ElementT, G2El algebra.G2ElementT](proof groth16.FoldedProof) (FoldedProof[G1El, G2El], error) {
+	var err error
+	var ret FoldedProof[G1El, G2El]
+	switch ar := any(&ret).(type) {
+	case *FoldedProof[sw_bn254.G1Affine, sw_bn254.G2Affine]:
+		tProof, ok := proof.(*groth16backend_bn254.FoldedProof)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.FoldedProof, got %T", proof)
+		}
+		ar.Ar = sw_bn254.NewG1Affine(tProof.Ar)
+		ar.Krs = sw_bn254.NewG1Affine(tProof.Krs)
+		ar.Bs = sw_bn254.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]pedersen.Commitment[sw_bn254.G1Affine], len(tProof.Commitments))
+		for i := range tProof.Commitments {
+			ar.Commitments[i], err = pedersen.ValueOfCommitment[sw_bn254.G1Affine](tProof.Commitments[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment[%d]: %w", i, err)
+			}
+		}
+		ar.CommitmentPok, err = pedersen.ValueOfKnowledgeProof[sw_bn254.G1Affine](tProof.CommitmentPok)
+		if err != nil {
+			return ret, fmt.Errorf("commitment pok: %w", err)
+		}
+	case *FoldedProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine]:
+		tProof, ok := proof.(*groth16backend_bls12377.FoldedProof)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.FoldedProof, got %T", proof)
+		}
+		ar.Ar = sw_bls12377.NewG1Affine(tProof.Ar)
+		ar.Krs = sw_bls12377.NewG1Affine(tProof.Krs)
+		ar.Bs = sw_bls12377.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]pedersen.Commitment[sw_bls12377.G1Affine], len(tProof.Commitments))
+		for i := range tProof.Commitments {
+			ar.Commitments[i], err = pedersen.ValueOfCommitment[sw_bls12377.G1Affine](tProof.Commitments[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment[%d]: %w", i, err)
+			}
+		}
+		ar.CommitmentPok, err = pedersen.ValueOfKnowledgeProof[sw_bls12377.G1Affine](tProof.CommitmentPok)
+		if err != nil {
+			return ret, fmt.Errorf("commitment pok: %w", err)
+		}
+	case *FoldedProof[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
+		tProof, ok := proof.(*groth16backend_bls12381.FoldedProof)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.FoldedProof, got %T", proof)
+		}
+		ar.Ar = sw_bls12381.NewG1Affine(tProof.Ar)
+		ar.Krs = sw_bls12381.NewG1Affine(tProof.Krs)
+		ar.Bs = sw_bls12381.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]pedersen.Commitment[sw_bls12381.G1Affine], len(tProof.Commitments))
+		for i := range tProof.Commitments {
+			ar.Commitments[i], err = pedersen.ValueOfCommitment[sw_bls12381.G1Affine](tProof.Commitments[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment[%d]: %w", i, err)
+			}
+		}
+		ar.CommitmentPok, err = pedersen.ValueOfKnowledgeProof[sw_bls12381.G1Affine](tProof.CommitmentPok)
+		if err != nil {
+			return ret, fmt.Errorf("commitment pok: %w", err)
+		}
+	case *FoldedProof[sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
+		tProof, ok := proof.(*groth16backend_bls24315.FoldedProof)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.FoldedProof, got %T", proof)
+		}
+		ar.Ar = sw_bls24315.NewG1Affine(tProof.Ar)
+		ar.Krs = sw_bls24315.NewG1Affine(tProof.Krs)
+		ar.Bs = sw_bls24315.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]pedersen.Commitment[sw_bls24315.G1Affine], len(tProof.Commitments))
+		for i := range tProof.Commitments {
+			ar.Commitments[i], err = pedersen.ValueOfCommitment[sw_bls24315.G1Affine](tProof.Commitments[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment[%d]: %w", i, err)
+			}
+		}
+		ar.CommitmentPok, err = pedersen.ValueOfKnowledgeProof[sw_bls24315.G1Affine](tProof.CommitmentPok)
+		if err != nil {
+			return ret, fmt.Errorf("commitment pok: %w", err)
+		}
+	case *FoldedProof[sw_bw6761.G1Affine, sw_bw6761.G2Affine]:
+		tProof, ok := proof.(*groth16backend_bw6761.FoldedProof)
+		if !ok {
+			return ret, fmt.Errorf("expected bw6761.FoldedProof, got %T", proof)
+		}
+		ar.Ar = sw_bw6761.NewG1Affine(tProof.Ar)
+		ar.Krs = sw_bw6761.NewG1Affine(tProof.Krs)
+		ar.Bs = sw_bw6761.NewG2Affine(tProof.Bs)
+		ar.Commitments = make([]pedersen.Commitment[sw_bw6761.G1Affine], len(tProof.Commitments))
+		for i := range tProof.Commitments {
+			ar.Commitments[i], err = pedersen.ValueOfCommitment[sw_bw6761.G1Affine](tProof.Commitments[i])
+			if err != nil {
+				return ret, fmt.Errorf("commitment[%d]: %w", i, err)
+			}
+		}
+		ar.CommitmentPok, err = pedersen.ValueOfKnowledgeProof[sw_bw6761.G1Affine](tProof.CommitmentPok)
+		if err != nil {
+			return ret, fmt.Errorf("commitment pok: %w", err)
+		}
+	default:
+		return ret, fmt.Errorf("unknown parametric type combination")
+	}
+	return ret, nil
+}
+
+// ValueOfFoldedWitness returns the typed witness of the native folded witness,
+// for example as returned by [groth16.FoldingAccumulator].Snapshot. It returns
+// an error if there is a mismatch between the type parameters and the provided
+// native folded witness.
+func ValueOfFoldedWitness[FR emulated.FieldParams, G1El algebra.G1ElementT, GtEl algebra.GtElementT](foldedWitness groth16.FoldedWitness) (FoldedWitness[FR, G1El, GtEl], error) {
+	var err error
+	var ret FoldedWitness[FR, G1El, GtEl]
+	switch s := any(&ret).(type) {
+	case *FoldedWitness[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.GTEl]:
+		w, ok := foldedWitness.(*groth16backend_bn254.FoldedWitness)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.FoldedWitness, got %T", foldedWitness)
+		}
+		s.H = sw_bn254.NewG1Affine(w.H)
+		s.E = sw_bn254.NewGTEl(w.E)
+		s.Mu = emulated.ValueOf[sw_bn254.ScalarField](&w.Mu)
+		s.Commitment, err = pedersen.ValueOfCommitment[sw_bn254.G1Affine](w.Commitment)
+		if err != nil {
+			return ret, fmt.Errorf("commitment: %w", err)
+		}
+	case *FoldedWitness[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.GT]:
+		w, ok := foldedWitness.(*groth16backend_bls12377.FoldedWitness)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.FoldedWitness, got %T", foldedWitness)
+		}
+		s.H = sw_bls12377.NewG1Affine(w.H)
+		s.E = sw_bls12377.NewGTEl(w.E)
+		s.Mu = sw_bls12377.NewScalar(*new(fr_bls12377.Element).SetBigInt(&w.Mu))
+		s.Commitment, err = pedersen.ValueOfCommitment[sw_bls12377.G1Affine](w.Commitment)
+		if err != nil {
+			return ret, fmt.Errorf("commitment: %w", err)
+		}
+	case *FoldedWitness[sw_bls12381.ScalarField, sw_bls12381.G1Affine, sw_bls12381.GTEl]:
+		w, ok := foldedWitness.(*groth16backend_bls12381.FoldedWitness)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.FoldedWitness, got %T", foldedWitness)
+		}
+		s.H = sw_bls12381.NewG1Affine(w.H)
+		s.E = sw_bls12381.NewGTEl(w.E)
+		s.Mu = emulated.ValueOf[sw_bls12381.ScalarField](&w.Mu)
+		s.Commitment, err = pedersen.ValueOfCommitment[sw_bls12381.G1Affine](w.Commitment)
+		if err != nil {
+			return ret, fmt.Errorf("commitment: %w", err)
+		}
+	case *FoldedWitness[sw_bls24315.ScalarField, sw_bls24315.G1Affine, sw_bls24315.GT]:
+		w, ok := foldedWitness.(*groth16backend_bls24315.FoldedWitness)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.FoldedWitness, got %T", foldedWitness)
+		}
+		s.H = sw_bls24315.NewG1Affine(w.H)
+		s.E = sw_bls24315.NewGTEl(w.E)
+		s.Mu = sw_bls24315.NewScalar(*new(fr_bls24315.Element).SetBigInt(&w.Mu))
+		s.Commitment, err = pedersen.ValueOfCommitment[sw_bls24315.G1Affine](w.Commitment)
+		if err != nil {
+			return ret, fmt.Errorf("commitment: %w", err)
+		}
+	case *FoldedWitness[sw_bw6761.ScalarField, sw_bw6761.G1Affine, sw_bw6761.GTEl]:
+		w, ok := foldedWitness.(*groth16backend_bw6761.FoldedWitness)
+		if !ok {
+			return ret, fmt.Errorf("expected bw6761.FoldedWitness, got %T", foldedWitness)
+		}
+		s.H = sw_bw6761.NewG1Affine(w.H)
+		s.E = sw_bw6761.NewGTEl(w.E)
+		s.Mu = sw_bw6761.NewScalar(*new(fr_bw6761.Element).SetBigInt(&w.Mu))
+		s.Commitment, err = pedersen.ValueOfCommitment[sw_bw6761.G1Affine](w.Commitment)
+		if err != nil {
+			return ret, fmt.Errorf("commitment: %w", err)
+		}
+	default:
+		return ret, fmt.Errorf("unknown parametric type combination")
+	}
+	return ret, nil
+}
+
+// ValueOfFoldingVerifyingKey initializes witness from the given Groth16
+// verifying key. It returns an error if there is a mismatch between the type
+// parameters and the provided native verifying key.
+func ValueOfFoldingVerifyingKey[G1El algebra.G1ElementT, G2El algebra.G2ElementT](vk groth16.VerifyingKey) (FoldingVerifyingKey[G1El, G2El], error) {
+	var err error
+	var ret FoldingVerifyingKey[G1El, G2El]
+	switch s := any(&ret).(type) {
+	case *FoldingVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine]:
+		tVk, ok := vk.(*groth16backend_bn254.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bn254.VerifyingKey, got %T", vk)
+		}
+		s.G1.Alpha = sw_bn254.NewG1Affine(tVk.G1.Alpha)
+		s.G1.K = make([]sw_bn254.G1Affine, len(tVk.G1.K))
+		for i := range s.G1.K {
+			s.G1.K[i] = sw_bn254.NewG1Affine(tVk.G1.K[i])
+		}
+		var deltaNeg, gammaNeg bn254.G2Affine
+		deltaNeg.Neg(&tVk.G2.Delta)
+		gammaNeg.Neg(&tVk.G2.Gamma)
+		s.G2.Beta = sw_bn254.NewG2Affine(tVk.G2.Beta)
+		s.G2.DeltaNeg = sw_bn254.NewG2Affine(deltaNeg)
+		s.G2.GammaNeg = sw_bn254.NewG2Affine(gammaNeg)
+		s.CommitmentKey, err = pedersen.ValueOfVerifyingKey[sw_bn254.G2Affine](&tVk.CommitmentKey)
+		if err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+	case *FoldingVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine]:
+		tVk, ok := vk.(*groth16backend_bls12377.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12377.VerifyingKey, got %T", vk)
+		}
+		s.G1.Alpha = sw_bls12377.NewG1Affine(tVk.G1.Alpha)
+		s.G1.K = make([]sw_bls12377.G1Affine, len(tVk.G1.K))
+		for i := range s.G1.K {
+			s.G1.K[i] = sw_bls12377.NewG1Affine(tVk.G1.K[i])
+		}
+		var deltaNeg, gammaNeg bls12377.G2Affine
+		deltaNeg.Neg(&tVk.G2.Delta)
+		gammaNeg.Neg(&tVk.G2.Gamma)
+		s.G2.Beta = sw_bls12377.NewG2Affine(tVk.G2.Beta)
+		s.G2.DeltaNeg = sw_bls12377.NewG2Affine(deltaNeg)
+		s.G2.GammaNeg = sw_bls12377.NewG2Affine(gammaNeg)
+		s.CommitmentKey, err = pedersen.ValueOfVerifyingKey[sw_bls12377.G2Affine](&tVk.CommitmentKey)
+		if err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+	case *FoldingVerifyingKey[sw_bls12381.G1Affine, sw_bls12381.G2Affine]:
+		tVk, ok := vk.(*groth16backend_bls12381.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bls12381.VerifyingKey, got %T", vk)
+		}
+		s.G1.Alpha = sw_bls12381.NewG1Affine(tVk.G1.Alpha)
+		s.G1.K = make([]sw_bls12381.G1Affine, len(tVk.G1.K))
+		for i := range s.G1.K {
+			s.G1.K[i] = sw_bls12381.NewG1Affine(tVk.G1.K[i])
+		}
+		var deltaNeg, gammaNeg bls12381.G2Affine
+		deltaNeg.Neg(&tVk.G2.Delta)
+		gammaNeg.Neg(&tVk.G2.Gamma)
+		s.G2.Beta = sw_bls12381.NewG2Affine(tVk.G2.Beta)
+		s.G2.DeltaNeg = sw_bls12381.NewG2Affine(deltaNeg)
+		s.G2.GammaNeg = sw_bls12381.NewG2Affine(gammaNeg)
+		s.CommitmentKey, err = pedersen.ValueOfVerifyingKey[sw_bls12381.G2Affine](&tVk.CommitmentKey)
+		if err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+	case *FoldingVerifyingKey[sw_bls24315.G1Affine, sw_bls24315.G2Affine]:
+		tVk, ok := vk.(*groth16backend_bls24315.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bls24315.VerifyingKey, got %T", vk)
+		}
+		s.G1.Alpha = sw_bls24315.NewG1Affine(tVk.G1.Alpha)
+		s.G1.K = make([]sw_bls24315.G1Affine, len(tVk.G1.K))
+		for i := range s.G1.K {
+			s.G1.K[i] = sw_bls24315.NewG1Affine(tVk.G1.K[i])
+		}
+		var deltaNeg, gammaNeg bls24315.G2Affine
+		deltaNeg.Neg(&tVk.G2.Delta)
+		gammaNeg.Neg(&tVk.G2.Gamma)
+		s.G2.Beta = sw_bls24315.NewG2Affine(tVk.G2.Beta)
+		s.G2.DeltaNeg = sw_bls24315.NewG2Affine(deltaNeg)
+		s.G2.GammaNeg = sw_bls24315.NewG2Affine(gammaNeg)
+		s.CommitmentKey, err = pedersen.ValueOfVerifyingKey[sw_bls24315.G2Affine](&tVk.CommitmentKey)
+		if err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+	case *FoldingVerifyingKey[sw_bw6761.G1Affine, sw_bw6761.G2Affine]:
+		tVk, ok := vk.(*groth16backend_bw6761.VerifyingKey)
+		if !ok {
+			return ret, fmt.Errorf("expected bw6761.VerifyingKey, got %T", vk)
+		}
+		s.G1.Alpha = sw_bw6761.NewG1Affine(tVk.G1.Alpha)
+		s.G1.K = make([]sw_bw6761.G1Affine, len(tVk.G1.K))
+		for i := range s.G1.K {
+			s.G1.K[i] = sw_bw6761.NewG1Affine(tVk.G1.K[i])
+		}
+		var deltaNeg, gammaNeg bw6761.G2Affine
+		deltaNeg.Neg(&tVk.G2.Delta)
+		gammaNeg.Neg(&tVk.G2.Gamma)
+		s.G2.Beta = sw_bw6761.NewG2Affine(tVk.G2.Beta)
+		s.G2.DeltaNeg = sw_bw6761.NewG2Affine(deltaNeg)
+		s.G2.GammaNeg = sw_bw6761.NewG2Affine(gammaNeg)
+		s.CommitmentKey, err = pedersen.ValueOfVerifyingKey[sw_bw6761.G2Affine](&tVk.CommitmentKey)
+		if err != nil {
+			return ret, fmt.Errorf("commitment key: %w", err)
+		}
+	default:
+		return ret, fmt.Errorf("unknown parametric type combination")
+	}
+	return ret, nil
+}
+
+// AssertFoldedProof asserts that the folded proof holds for the given folded
+// witness and verifying key, that is
+//
+//	e(Ar, Bs) · e(μ·Krs, -δ) · e(μ·H, -γ) · e(-μ²·α, β) = E
+//
+// and that CommitmentPok is a proof of knowledge of the folded commitment.
+// This is a single pairing product check, whatever the number of folded
+// proofs.
+//
+// The folded witness is not derived from the public inputs of the folded
+// proofs: it must be computed, or checked, by the caller, for example with
+// [Verifier.FoldProof] or the native FoldingAccumulator.
+func (v *Verifier[FR, G1El, G2El, GtEl]) AssertFoldedProof(vk FoldingVerifyingKey[G1El, G2El], proof FoldedProof[G1El, G2El], witness FoldedWitness[FR, G1El, GtEl], opts ...VerifierOption) error {
+	opt, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+
+	if len(vk.PublicAndCommitmentCommitted) > 0 {
+		err = v.commitment.AssertCommitment(witness.Commitment, proof.CommitmentPok, vk.CommitmentKey, opt.pedopt...)
+		if err != nil {
+			return fmt.Errorf("assert commitment: %w", err)
+		}
+	}
+
+	if opt.forceSubgroupCheck {
+		v.pairing.AssertIsOnG1(&proof.Ar)
+		v.pairing.AssertIsOnG1(&proof.Krs)
+		v.pairing.AssertIsOnG2(&proof.Bs)
+		v.pairing.AssertIsOnG1(&witness.H)
+	}
+
+	muKrs := v.curve.ScalarMul(&proof.Krs, &witness.Mu, opt.algopt...)
+	muH := v.curve.ScalarMul(&witness.H, &witness.Mu, opt.algopt...)
+	// e(α, β)^{μ²} = e(μ²·α, β), there is no exponentiation in the target group
+	muSquaredAlpha := v.curve.Neg(v.curve.ScalarMul(&vk.G1.Alpha, v.scalarApi.Mul(&witness.Mu, &witness.Mu), opt.algopt...))
+
+	pairing, err := v.pairing.Pair([]*G1El{&proof.Ar, muKrs, muH, muSquaredAlpha}, []*G2El{&proof.Bs, &vk.G2.DeltaNeg, &vk.G2.GammaNeg, &vk.G2.Beta})
+	if err != nil {
+		return fmt.Errorf("pairing: %w", err)
+	}
+	v.pairing.AssertIsEqual(pairing, &witness.E)
+	return nil
+}
+
+// NewFoldedInstance returns the folded instance of a single proof, that is the
+// proof itself and the folded witness with the public input accumulator of
+// witness, E = 1 and μ = 1. It is the starting instance for
+// [Verifier.FoldProof].
+//
+// It is only implemented for BLS12-377 proofs in a BW6-761 circuit, see
+// [Verifier.FoldProof].
+func (v *Verifier[FR, G1El, G2El, GtEl]) NewFoldedInstance(vk FoldingVerifyingKey[G1El, G2El], proof Proof[G1El, G2El], witness Witness[FR], opts ...VerifierOption) (FoldedProof[G1El, G2El], FoldedWitness[FR, G1El, GtEl], error) {
+	var foldedProof FoldedProof[G1El, G2El]
+	var foldedWitness FoldedWitness[FR, G1El, GtEl]
+	opt, err := newCfg(opts...)
+	if err != nil {
+		return foldedProof, foldedWitness, fmt.Errorf("apply options: %w", err)
+	}
+	arith, err := getFoldingArithmetic[G1El, G2El, GtEl](v.api)
+	if err != nil {
+		return foldedProof, foldedWitness, err
+	}
+	kSum, commitment, err := v.computeKSum(vk.G1.K, vk.PublicAndCommitmentCommitted, proof, witness, opt)
+	if err != nil {
+		return foldedProof, foldedWitness, err
+	}
+	if len(vk.PublicAndCommitmentCommitted) == 0 {
+		// as natively, the batched commitment of a proof without commitments
+		// is the point at infinity
+		commitment.G1El = *arith.zeroG1()
+	}
+
+	foldedProof = FoldedProof[G1El, G2El]{
+		Ar:            proof.Ar,
+		Krs:           proof.Krs,
+		Bs:            proof.Bs,
+		Commitments:   append([]pedersen.Commitment[G1El](nil), proof.Commitments...),
+		CommitmentPok: proof.CommitmentPok,
+	}
+	foldedWitness = FoldedWitness[FR, G1El, GtEl]{
+		H:          *kSum,
+		E:          *arith.oneGt(),
+		Mu:         *v.scalarApi.One(),
+		Commitment: commitment,
+	}
+	return foldedProof, foldedWitness, nil
+}
+
+// FoldProof folds proof, with its public witness, into the folded instance
+// (foldedProof, foldedWitness) and returns the new folded instance. T is the
+// cross term of the folding step, as returned by [groth16.FoldProofs]. As in
+// the native FoldingAccumulator, the folding challenge r is derived from a
+// transcript binding both instances and T, and
+//
+//	H ← H + r·Σx.[Kvk(t)]₁, μ ← μ + r, E ← E · T^r, D ← D + r·Dᵢ
+//
+// and the proof elements are folded as Ar ← Ar + r·Arᵢ. The challenge is
+// computed with the in-circuit transcript, so the native prover must be
+// initialized with [GetNativeProverOptions].
+//
+// T is not checked. If it is not the cross term, then the folded instance
+// doesn't hold for [Verifier.AssertFoldedProof], except with negligible
+// probability. So folding N proofs with [Verifier.NewFoldedInstance] and
+// FoldProof and then asserting the folded instance attests that the N proofs
+// are valid. This allows to fold proofs incrementally, carrying the folded
+// instance from one outer circuit to the next.
+//
+// It is only implemented for BLS12-377 proofs in a BW6-761 circuit, as the G2
+// and target group arithmetic of the step is not part of the
+// [algebra.Pairing] interface.
+func (v *Verifier[FR, G1El, G2El, GtEl]) FoldProof(vk FoldingVerifyingKey[G1El, G2El], foldedProof FoldedProof[G1El, G2El], foldedWitness FoldedWitness[FR, G1El, GtEl], proof Proof[G1El, G2El], witness Witness[FR], T GtEl, opts ...VerifierOption) (FoldedProof[G1El, G2El], FoldedWitness[FR, G1El, GtEl], error) {
+	var fr FR
+	var resProof FoldedProof[G1El, G2El]
+	var resWitness FoldedWitness[FR, G1El, GtEl]
+	opt, err := newCfg(opts...)
+	if err != nil {
+		return resProof, resWitness, fmt.Errorf("apply options: %w", err)
+	}
+	arith, err := getFoldingArithmetic[G1El, G2El, GtEl](v.api)
+	if err != nil {
+		return resProof, resWitness, err
+	}
+	if len(foldedProof.Commitments) != len(vk.PublicAndCommitmentCommitted) || len(proof.Commitments) != len(vk.PublicAndCommitmentCommitted) {
+		return resProof, resWitness, fmt.Errorf("invalid number of commitments, expected %d", len(vk.PublicAndCommitmentCommitted))
+	}
+	kSum, commitment, err := v.computeKSum(vk.G1.K, vk.PublicAndCommitmentCommitted, proof, witness, opt)
+	if err != nil {
+		return resProof, resWitness, err
+	}
+
+	// derive the folding challenge, binding the same elements as natively
+	fs, err := recursion.NewTranscript(v.api, fr.Modulus(), []string{"r"})
+	if err != nil {
+		return resProof, resWitness, fmt.Errorf("new transcript: %w", err)
+	}
+	toBind := [][]frontend.Variable{v.curve.MarshalG1(foldedProof.Ar), arith.marshalG2(&foldedProof.Bs), v.curve.MarshalG1(foldedProof.Krs)}
+	for i := range foldedProof.Commitments {
+		toBind = append(toBind, v.curve.MarshalG1(foldedProof.Commitments[i].G1El))
+	}
+	toBind = append(toBind,
+		v.curve.MarshalG1(foldedProof.CommitmentPok.G1El),
+		v.curve.MarshalG1(foldedWitness.H), arith.marshalGt(&foldedWitness.E), v.curve.MarshalScalar(foldedWitness.Mu), v.curve.MarshalG1(foldedWitness.Commitment.G1El),
+		v.curve.MarshalG1(proof.Ar), arith.marshalG2(&proof.Bs), v.curve.MarshalG1(proof.Krs),
+	)
+	for i := range proof.Commitments {
+		toBind = append(toBind, v.curve.MarshalG1(proof.Commitments[i].G1El))
+	}
+	toBind = append(toBind, v.curve.MarshalG1(proof.CommitmentPok.G1El))
+	for i := range witness.Public {
+		toBind = append(toBind, v.curve.MarshalScalar(witness.Public[i]))
+	}
+	toBind = append(toBind, arith.marshalGt(&T))
+	for i := range toBind {
+		if err := fs.Bind("r", toBind[i]); err != nil {
+			return resProof, resWitness, fmt.Errorf("bind %d: %w", i, err)
+		}
+	}
+	challenge, err := fs.ComputeChallenge("r")
+	if err != nil {
+		return resProof, resWitness, fmt.Errorf("compute challenge: %w", err)
+	}
+	rBits := bits.ToBinary(v.api, challenge, bits.WithNbDigits(fr.Modulus().BitLen()))
+	r := v.scalarApi.FromBits(rBits...)
+
+	// the folded points are chosen by the prover, use complete arithmetic
+	algopt := append([]algopts.AlgebraOption{algopts.WithCompleteArithmetic()}, opt.algopt...)
+	fold := func(p, q *G1El) G1El {
+		return *v.curve.AddUnified(p, v.curve.ScalarMul(q, r, algopt...))
+	}
+
+	resProof.Ar = fold(&foldedProof.Ar, &proof.Ar)
+	resProof.Krs = fold(&foldedProof.Krs, &proof.Krs)
+	resProof.Bs = *arith.foldG2(&foldedProof.Bs, &proof.Bs, challenge)
+	resProof.Commitments = make([]pedersen.Commitment[G1El], len(foldedProof.Commitments))
+	for i := range resProof.Commitments {
+		resProof.Commitments[i].G1El = fold(&foldedProof.Commitments[i].G1El, &proof.Commitments[i].G1El)
+	}
+	resProof.CommitmentPok.G1El = fold(&foldedProof.CommitmentPok.G1El, &proof.CommitmentPok.G1El)
+
+	resWitness.H = fold(&foldedWitness.H, kSum)
+	resWitness.E = *arith.foldGt(&foldedWitness.E, &T, rBits)
+	resWitness.Mu = *v.scalarApi.Add(&foldedWitness.Mu, r)
+	resWitness.Commitment = foldedWitness.Commitment
+	if len(vk.PublicAndCommitmentCommitted) > 0 {
+		resWitness.Commitment.G1El = fold(&foldedWitness.Commitment.G1El, &commitment.G1El)
+	}
+	return resProof, resWitness, nil
+}
+
+// foldingArithmetic provides the operations of a folding step which are not
+// part of the [algebra.Curve] and [algebra.Pairing] interfaces.
+type foldingArithmetic[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] interface {
+	// zeroG1 returns the point at infinity (0,0).
+	zeroG1() *G1El
+	// oneGt returns the neutral element of the target group.
+	oneGt() *GtEl
+	// marshalG2 returns the binary decomposition of the point, as natively.
+	marshalG2(*G2El) []frontend.Variable
+	// marshalGt returns the binary decomposition of the element, as natively.
+	marshalGt(*GtEl) []frontend.Variable
+	// foldG2 returns p + [r]q.
+	foldG2(p, q *G2El, r frontend.Variable) *G2El
+	// foldGt returns e·t^r, where rBits is the little-endian binary
+	// decomposition of r.
+	foldGt(e, t *GtEl, rBits []frontend.Variable) *GtEl
+}
+
+// getFoldingArithmetic returns the folding arithmetic for the type parameters.
+// It returns an error if it is not implemented for the type combination.
+func getFoldingArithmetic[G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT](api frontend.API) (foldingArithmetic[G1El, G2El, GtEl], error) {
+	var ret foldingArithmetic[G1El, G2El, GtEl]
+	switch s := any(&ret).(type) {
+	case *foldingArithmetic[sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]:
+		*s = &foldingArithmeticBLS12377{api: api}
+	default:
+		return nil, fmt.Errorf("folding not implemented for the parametric type combination")
+	}
+	return ret, nil
+}
+
+// foldingArithmeticBLS12377 is the folding arithmetic of BLS12-377 proofs in a
+// BW6-761 circuit, where the G2 and target group arithmetic is native.
+type foldingArithmeticBLS12377 struct {
+	api frontend.API
+}
+
+func (f *foldingArithmeticBLS12377) zeroG1() *sw_bls12377.G1Affine {
+	return &sw_bls12377.G1Affine{X: 0, Y: 0}
+}
+
+func (f *foldingArithmeticBLS12377) oneGt() *sw_bls12377.GT {
+	var one sw_bls12377.GT
+	one.SetOne()
+	return &one
+}
+
+// marshalFp appends the big-endian binary decomposition of the
+// coordinates to res.
+func (f *foldingArithmeticBLS12377) marshalFp(res []frontend.Variable, coords ...frontend.Variable) []frontend.Variable {
+	nbBits := 8 * ((ecc.BLS12_377.BaseField().BitLen() + 7) / 8)
+	for i := range coords {
+		x := bits.ToBinary(f.api, coords[i], bits.WithNbDigits(nbBits))
+		for j := nbBits - 1; j >= 0; j-- {
+			res = append(res, x[j])
+		}
+	}
+	return res
+}
+
+func (f *foldingArithmeticBLS12377) marshalG2(p *sw_bls12377.G2Affine) []frontend.Variable {
+	// p.X.A1 | p.X.A0 | p.Y.A1 | p.Y.A0
+	res := f.marshalFp(nil, p.P.X.A1, p.P.X.A0, p.P.Y.A1, p.P.Y.A0)
+	// the infinity flag is the second most significant bit
+	res[1] = f.api.And(p.P.X.IsZero(f.api), p.P.Y.IsZero(f.api))
+	return res
+}
+
+func (f *foldingArithmeticBLS12377) marshalGt(e *sw_bls12377.GT) []frontend.Variable {
+	// C1.B2.A1 | C1.B2.A0 | C1.B1.A1 | ... | C0.B0.A0
+	return f.marshalFp(nil,
+		e.C1.B2.A1, e.C1.B2.A0, e.C1.B1.A1, e.C1.B1.A0, e.C1.B0.A1, e.C1.B0.A0,
+		e.C0.B2.A1, e.C0.B2.A0, e.C0.B1.A1, e.C0.B1.A0, e.C0.B0.A1, e.C0.B0.A0,
+	)
+}
+
+func (f *foldingArithmeticBLS12377) foldG2(p, q *sw_bls12377.G2Affine, r frontend.Variable) *sw_bls12377.G2Affine {
+	var res sw_bls12377.G2Affine
+	res.P.ScalarMul(f.api, q.P, r, algopts.WithCompleteArithmetic())
+	res.P.AddUnified(f.api, p.P)
+	return &res
+}
+
+func (f *foldingArithmeticBLS12377) foldGt(e, t *sw_bls12377.GT, rBits []frontend.Variable) *sw_bls12377.GT {
+	// T is chosen by the prover and may not be in the cyclotomic subgroup, so
+	// we don't use the cyclotomic squaring.
+	var res, tmp sw_bls12377.GT
+	res.SetOne()
+	for i := len(rBits) - 1; i >= 0; i-- {
+		res.Square(f.api, res)
+		tmp.Mul(f.api, res, *t)
+		res.Select(f.api, rBits[i], tmp, res)
+	}
+	res.Mul(f.api, res, *e)
+	return &res
+}
@@ -0,0 +1,282 @@
+package groth16
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	fr_bls12377 "github.com/consensys/gnark-crypto/ecc/bls12-377/fr"
+	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/consensys/gnark/backend"
+	"github.com/consensys/gnark/backend/groth16"
+	groth16backend_bls12377 "github.com/consensys/gnark/backend/groth16/bls12-377"
+	groth16backend_bn254 "github.com/consensys/gnark/backend/groth16/bn254"
+	"github.com/consensys/gnark/backend/witness"
+	"github.com/consensys/gnark/constraint"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/frontend/cs/r1cs"
+	"github.com/consensys/gnark/std/algebra"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/native/sw_bls12377"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/test"
+)
+
+type OuterFoldedCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Proof        FoldedProof[G1El, G2El]
+	VerifyingKey FoldingVerifyingKey[G1El, G2El]
+	InnerWitness FoldedWitness[FR, G1El, GtEl]
+}
+
+func (c *OuterFoldedCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	verifier, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+	return verifier.AssertFoldedProof(c.VerifyingKey, c.Proof, c.InnerWitness)
+}
+
+// getInnerProofs returns nbProofs proofs of the inner circuit with different
+// public inputs.
+func getInnerProofs(assert *test.Assert, field *big.Int, circuit frontend.Circuit, assign func(i int) frontend.Circuit, nbProofs int, opts ...backend.ProverOption) (constraint.ConstraintSystem, groth16.VerifyingKey, []groth16.Proof, []witness.Witness) {
+	innerCcs, err := frontend.Compile(field, r1cs.NewBuilder, circuit)
+	assert.NoError(err)
+	innerPK, innerVK, err := groth16.Setup(innerCcs)
+	assert.NoError(err)
+
+	proofs := make([]groth16.Proof, nbProofs)
+	publicWitness := make([]witness.Witness, nbProofs)
+	for i := range proofs {
+		innerWitness, err := frontend.NewWitness(assign(i), field)
+		assert.NoError(err)
+		proofs[i], err = groth16.Prove(innerCcs, innerPK, innerWitness, opts...)
+		assert.NoError(err)
+		publicWitness[i], err = innerWitness.Public()
+		assert.NoError(err)
+	}
+	return innerCcs, innerVK, proofs, publicWitness
+}
+
+func TestBLS12InBW6Folded(t *testing.T) {
+	assert := test.NewAssert(t)
+	for _, withCommitment := range []bool{false, true} {
+		assert.Run(func(assert *test.Assert) {
+			var circuit frontend.Circuit = &InnerCircuit{}
+			assign := func(i int) frontend.Circuit { return &InnerCircuit{P: 3, Q: 5 + i, N: 3 * (5 + i)} }
+			if withCommitment {
+				circuit = &InnerCircuitCommitment{}
+				assign = func(i int) frontend.Circuit { return &InnerCircuitCommitment{P: 3, Q: 5 + i, N: 3 * (5 + i)} }
+			}
+			innerCcs, innerVK, proofs, publicWitness := getInnerProofs(assert, ecc.BLS12_377.ScalarField(), circuit, assign, 3)
+
+			acc, err := groth16backend_bls12377.NewFoldingAccumulator(innerVK.(*groth16backend_bls12377.VerifyingKey))
+			assert.NoError(err)
+			for i := range proofs {
+				err = acc.Fold(proofs[i].(*groth16backend_bls12377.Proof), publicWitness[i].Vector().(fr_bls12377.Vector))
+				assert.NoError(err)
+			}
+			assert.NoError(acc.Decide())
+			foldedProof, foldedWitness := acc.Snapshot()
+
+			// outer proof
+			circuitVk, err := ValueOfFoldingVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerVK)
+			assert.NoError(err)
+			circuitWitness, err := ValueOfFoldedWitness[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.GT](foldedWitness)
+			assert.NoError(err)
+			circuitProof, err := ValueOfFoldedProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](foldedProof)
+			assert.NoError(err)
+
+			outerCircuit := &OuterFoldedCircuit[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+				Proof:        PlaceholderFoldedProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs),
+				VerifyingKey: PlaceholderFoldingVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs),
+			}
+			outerAssignment := &OuterFoldedCircuit[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+				InnerWitness: circuitWitness,
+				Proof:        circuitProof,
+				VerifyingKey: circuitVk,
+			}
+			err = test.IsSolved(outerCircuit, outerAssignment, ecc.BW6_761.ScalarField())
+			assert.NoError(err)
+
+			// the folded witness must match the folded proof
+			foldedWitness.Mu.Add(&foldedWitness.Mu, big.NewInt(1))
+			outerAssignment.InnerWitness, err = ValueOfFoldedWitness[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.GT](foldedWitness)
+			assert.NoError(err)
+			err = test.IsSolved(outerCircuit, outerAssignment, ecc.BW6_761.ScalarField())
+			assert.Error(err)
+		}, fmt.Sprintf("commitment=%t", withCommitment))
+	}
+}
+
+func TestBN254InBN254Folded(t *testing.T) {
+	assert := test.NewAssert(t)
+	innerCcs, innerVK, proofs, publicWitness := getInnerProofs(assert, ecc.BN254.ScalarField(), &InnerCircuitCommitment{},
+		func(i int) frontend.Circuit { return &InnerCircuitCommitment{P: 3, Q: 5 + i, N: 3 * (5 + i)} }, 2)
+
+	acc, err := groth16backend_bn254.NewFoldingAccumulator(innerVK.(*groth16backend_bn254.VerifyingKey))
+	assert.NoError(err)
+	for i := range proofs {
+		err = acc.Fold(proofs[i].(*groth16backend_bn254.Proof), publicWitness[i].Vector().(fr_bn254.Vector))
+		assert.NoError(err)
+	}
+	foldedProof, foldedWitness := acc.Snapshot()
+
+	// outer proof
+	circuitVk, err := ValueOfFoldingVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine](innerVK)
+	assert.NoError(err)
+	circuitWitness, err := ValueOfFoldedWitness[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.GTEl](foldedWitness)
+	assert.NoError(err)
+	circuitProof, err := ValueOfFoldedProof[sw_bn254.G1Affine, sw_bn254.G2Affine](foldedProof)
+	assert.NoError(err)
+
+	outerCircuit := &OuterFoldedCircuit[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]{
+		Proof:        PlaceholderFoldedProof[sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+		VerifyingKey: PlaceholderFoldingVerifyingKey[sw_bn254.G1Affine, sw_bn254.G2Affine](innerCcs),
+	}
+	outerAssignment := &OuterFoldedCircuit[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.G2Affine, sw_bn254.GTEl]{
+		InnerWitness: circuitWitness,
+		Proof:        circuitProof,
+		VerifyingKey: circuitVk,
+	}
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// the folded witness must match the folded proof
+	foldedWitness.Mu.Add(&foldedWitness.Mu, big.NewInt(1))
+	outerAssignment.InnerWitness, err = ValueOfFoldedWitness[sw_bn254.ScalarField, sw_bn254.G1Affine, sw_bn254.GTEl](foldedWitness)
+	assert.NoError(err)
+	err = test.IsSolved(outerCircuit, outerAssignment, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type OuterFoldStepCircuit[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.G2ElementT, GtEl algebra.GtElementT] struct {
+	Proofs        []Proof[G1El, G2El]
+	InnerWitness  []Witness[FR]
+	CrossTerms    []GtEl
+	VerifyingKey  FoldingVerifyingKey[G1El, G2El]
+	ExpectedProof FoldedProof[G1El, G2El]
+	Expected      FoldedWitness[FR, G1El, GtEl]
+}
+
+func (c *OuterFoldStepCircuit[FR, G1El, G2El, GtEl]) Define(api frontend.API) error {
+	verifier, err := NewVerifier[FR, G1El, G2El, GtEl](api)
+	if err != nil {
+		return fmt.Errorf("new verifier: %w", err)
+	}
+	foldedProof, foldedWitness, err := verifier.NewFoldedInstance(c.VerifyingKey, c.Proofs[0], c.InnerWitness[0])
+	if err != nil {
+		return fmt.Errorf("new folded instance: %w", err)
+	}
+	for i := 1; i < len(c.Proofs); i++ {
+		foldedProof, foldedWitness, err = verifier.FoldProof(c.VerifyingKey, foldedProof, foldedWitness, c.Proofs[i], c.InnerWitness[i], c.CrossTerms[i-1])
+		if err != nil {
+			return fmt.Errorf("fold proof %d: %w", i, err)
+		}
+	}
+	if err = verifier.AssertFoldedProof(c.VerifyingKey, foldedProof, foldedWitness); err != nil {
+		return fmt.Errorf("assert folded proof: %w", err)
+	}
+
+	// the folded instance is the one computed natively
+	arith, err := getFoldingArithmetic[G1El, G2El, GtEl](api)
+	if err != nil {
+		return err
+	}
+	verifier.curve.AssertIsEqual(&foldedProof.Ar, &c.ExpectedProof.Ar)
+	verifier.curve.AssertIsEqual(&foldedProof.Krs, &c.ExpectedProof.Krs)
+	bs, expectedBs := arith.marshalG2(&foldedProof.Bs), arith.marshalG2(&c.ExpectedProof.Bs)
+	for i := range bs {
+		api.AssertIsEqual(bs[i], expectedBs[i])
+	}
+	verifier.curve.AssertIsEqual(&foldedProof.CommitmentPok.G1El, &c.ExpectedProof.CommitmentPok.G1El)
+	verifier.curve.AssertIsEqual(&foldedWitness.H, &c.Expected.H)
+	verifier.pairing.AssertIsEqual(&foldedWitness.E, &c.Expected.E)
+	verifier.scalarApi.AssertIsEqual(&foldedWitness.Mu, &c.Expected.Mu)
+	verifier.curve.AssertIsEqual(&foldedWitness.Commitment.G1El, &c.Expected.Commitment.G1El)
+	return nil
+}
+
+func TestBLS12InBW6FoldStep(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbProofs = 2
+	for _, withCommitment := range []bool{false, true} {
+		assert.Run(func(assert *test.Assert) {
+			var circuit frontend.Circuit = &InnerCircuit{}
+			assign := func(i int) frontend.Circuit { return &InnerCircuit{P: 3, Q: 5 + i, N: 3 * (5 + i)} }
+			if withCommitment {
+				circuit = &InnerCircuitCommitment{}
+				assign = func(i int) frontend.Circuit { return &InnerCircuitCommitment{P: 3, Q: 5 + i, N: 3 * (5 + i)} }
+			}
+			proverOpt := GetNativeProverOptions(ecc.BW6_761.ScalarField(), ecc.BLS12_377.ScalarField())
+			innerCcs, innerVK, proofs, publicWitness := getInnerProofs(assert, ecc.BLS12_377.ScalarField(), circuit, assign, nbProofs, proverOpt)
+
+			outerCircuit := &OuterFoldStepCircuit[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+				Proofs:        make([]Proof[sw_bls12377.G1Affine, sw_bls12377.G2Affine], nbProofs),
+				InnerWitness:  make([]Witness[sw_bls12377.ScalarField], nbProofs),
+				CrossTerms:    make([]sw_bls12377.GT, nbProofs-1),
+				VerifyingKey:  PlaceholderFoldingVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs),
+				ExpectedProof: PlaceholderFoldedProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs),
+			}
+			for i := range outerCircuit.Proofs {
+				outerCircuit.Proofs[i] = PlaceholderProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerCcs)
+				outerCircuit.InnerWitness[i] = PlaceholderWitness[sw_bls12377.ScalarField](innerCcs)
+			}
+
+			// assignment returns the outer assignment for the public witnesses,
+			// folded natively as in FoldProofs and the accumulator.
+			assignment := func(publicWitness []fr_bls12377.Vector) *OuterFoldStepCircuit[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT] {
+				tVk := innerVK.(*groth16backend_bls12377.VerifyingKey)
+				tProofs := make([]groth16backend_bls12377.Proof, nbProofs)
+				for i := range proofs {
+					tProofs[i] = *proofs[i].(*groth16backend_bls12377.Proof)
+				}
+				_, foldingParameters, err := groth16backend_bls12377.FoldProofs(tProofs, tVk, publicWitness, proverOpt)
+				assert.NoError(err)
+				acc, err := groth16backend_bls12377.NewFoldingAccumulator(tVk, proverOpt)
+				assert.NoError(err)
+				for i := range tProofs {
+					assert.NoError(acc.Fold(&tProofs[i], publicWitness[i]))
+				}
+				foldedProof, foldedWitness := acc.Snapshot()
+
+				res := &OuterFoldStepCircuit[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.G2Affine, sw_bls12377.GT]{
+					Proofs:       make([]Proof[sw_bls12377.G1Affine, sw_bls12377.G2Affine], nbProofs),
+					InnerWitness: make([]Witness[sw_bls12377.ScalarField], nbProofs),
+					CrossTerms:   make([]sw_bls12377.GT, nbProofs-1),
+				}
+				for i := range proofs {
+					res.Proofs[i], err = ValueOfProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](proofs[i])
+					assert.NoError(err)
+					for j := range publicWitness[i] {
+						res.InnerWitness[i].Public = append(res.InnerWitness[i].Public, sw_bls12377.NewScalar(publicWitness[i][j]))
+					}
+				}
+				for i := range foldingParameters {
+					res.CrossTerms[i] = sw_bls12377.NewGTEl(foldingParameters[i].T)
+				}
+				res.VerifyingKey, err = ValueOfFoldingVerifyingKey[sw_bls12377.G1Affine, sw_bls12377.G2Affine](innerVK)
+				assert.NoError(err)
+				res.ExpectedProof, err = ValueOfFoldedProof[sw_bls12377.G1Affine, sw_bls12377.G2Affine](foldedProof)
+				assert.NoError(err)
+				res.Expected, err = ValueOfFoldedWitness[sw_bls12377.ScalarField, sw_bls12377.G1Affine, sw_bls12377.GT](foldedWitness)
+				assert.NoError(err)
+				return res
+			}
+
+			vectors := make([]fr_bls12377.Vector, nbProofs)
+			for i := range publicWitness {
+				vectors[i] = publicWitness[i].Vector().(fr_bls12377.Vector)
+			}
+			err := test.IsSolved(outerCircuit, assignment(vectors), ecc.BW6_761.ScalarField())
+			assert.NoError(err)
+
+			// the folded instance is recomputed from the public inputs, so it
+			// doesn't hold for public inputs the proofs are not valid for.
+			vectors[1] = append(fr_bls12377.Vector(nil), vectors[1]...)
+			vectors[1][0].SetUint64(42)
+			err = test.IsSolved(outerCircuit, assignment(vectors), ecc.BW6_761.ScalarField())
+			assert.Error(err)
+		}, fmt.Sprintf("commitment=%t", withCommitment))
+	}
+}
@@ -47,17 +47,27 @@ func newCfg(opts ...VerifierOption) (*verifierCfg, error) {
 }
 
 // GetNativeProverOptions returns Groth16 prover options for the native prover
-// to initialize the configuration suitable for in-circuit verification.
+// to initialize the configuration suitable for in-circuit verification. The
+// challenge hash function is used for deriving the folding challenges, see
+// [Verifier.FoldProof].
 func GetNativeProverOptions(outer, field *big.Int) backend.ProverOption {
 	return func(pc *backend.ProverConfig) error {
 		htfProverHasher, err := recursion.NewShort(outer, field)
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
+		fsProverHasher, err := recursion.NewShort(outer, field)
+		if err != nil {
+			return fmt.Errorf("get prover fs hash: %w", err)
+		}
 		htfOpt := backend.WithProverHashToFieldFunction(htfProverHasher)
 		if err = htfOpt(pc); err != nil {
 			return fmt.Errorf("apply prover htf option: %w", err)
 		}
+		fsOpt := backend.WithProverChallengeHashFunction(fsProverHasher)
+		if err = fsOpt(pc); err != nil {
+			return fmt.Errorf("apply prover fs option: %w", err)
+		}
 		return nil
 
 	}
@@ -71,10 +81,18 @@ func GetNativeVerifierOptions(outer, field *big.Int) backend.VerifierOption {
 		if err != nil {
 			return fmt.Errorf("get hash to field: %w", err)
 		}
+		fsVerifierHasher, err := recursion.NewShort(outer, field)
+		if err != nil {
+			return fmt.Errorf("get verifier fs hash: %w", err)
+		}
 		htfOpt := backend.WithVerifierHashToFieldFunction(htfVerifierHasher)
 		if err = htfOpt(vc); err != nil {
 			return fmt.Errorf("apply verifier htf option: %w", err)
 		}
+		fsOpt := backend.WithVerifierChallengeHashFunction(fsVerifierHasher)
+		if err = fsOpt(vc); err != nil {
+			return fmt.Errorf("apply verifier fs option: %w", err)
+		}
 		return nil
 	}
 }
@@ -13,7 +13,6 @@ import (
 	fr_bn254 "github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761"
 	fr_bw6761 "github.com/consensys/gnark-crypto/ecc/bw6-761/fr"
-	"github.com/consensys/gnark-crypto/utils"
 	"github.com/consensys/gnark/backend/groth16"
 	groth16backend_bls12377 "github.com/consensys/gnark/backend/groth16/bls12-377"
 	groth16backend_bls12381 "github.com/consensys/gnark/backend/groth16/bls12-381"
@@ -567,40 +566,65 @@ func NewVerifier[FR emulated.FieldParams, G1El algebra.G1ElementT, G2El algebra.
 // AssertProof asserts that the SNARK proof holds for the given witness and
 // verifying key.
 func (v *Verifier[FR, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[G1El, G2El, GtEl], proof Proof[G1El, G2El], witness Witness[FR], opts ...VerifierOption) error {
+	opt, err := newCfg(opts...)
+	if err != nil {
+		return fmt.Errorf("apply options: %w", err)
+	}
+
+	kSum, commitment, err := v.computeKSum(vk.G1.K, vk.PublicAndCommitmentCommitted, proof, witness, opt)
+	if err != nil {
+		return err
+	}
+	if len(vk.PublicAndCommitmentCommitted) > 0 {
+		err = v.commitment.AssertCommitment(commitment, proof.CommitmentPok, vk.CommitmentKey, opt.pedopt...)
+		if err != nil {
+			return fmt.Errorf("assert commitment: %w", err)
+		}
+	}
+
+	if opt.forceSubgroupCheck {
+		v.pairing.AssertIsOnG1(&proof.Ar)
+		v.pairing.AssertIsOnG1(&proof.Krs)
+		v.pairing.AssertIsOnG2(&proof.Bs)
+	}
+	pairing, err := v.pairing.Pair([]*G1El{kSum, &proof.Krs, &proof.Ar}, []*G2El{&vk.G2.GammaNeg, &vk.G2.DeltaNeg, &proof.Bs})
+	if err != nil {
+		return fmt.Errorf("pairing: %w", err)
+	}
+	v.pairing.AssertIsEqual(pairing, &vk.E)
+	return nil
+}
+
+// computeKSum returns the public input accumulator Σx.[Kvk(t)]₁ of the proof,
+// commitments included, and the batched commitment of the proof. The batched
+// commitment is only defined if the proof has commitments.
+func (v *Verifier[FR, G1El, G2El, GtEl]) computeKSum(k []G1El, publicAndCommitmentCommitted [][]int, proof Proof[G1El, G2El], witness Witness[FR], opt *verifierCfg) (*G1El, pedersen.Commitment[G1El], error) {
 	var fr FR
-	nbPublicVars := len(vk.G1.K) - len(vk.PublicAndCommitmentCommitted)
+	var commitment pedersen.Commitment[G1El]
+	nbPublicVars := len(k) - len(publicAndCommitmentCommitted)
 	if len(witness.Public) != nbPublicVars-1 {
-		return fmt.Errorf("invalid witness size, got %d, expected %d (public - ONE_WIRE)", len(witness.Public), len(vk.G1.K)-1)
+		return nil, commitment, fmt.Errorf("invalid witness size, got %d, expected %d (public - ONE_WIRE)", len(witness.Public), len(k)-1)
 	}
 
-	inP := make([]*G1El, len(vk.G1.K)-1) // first is for the one wire, we add it manually after MSM
+	inP := make([]*G1El, len(k)-1) // first is for the one wire, we add it manually after MSM
 	for i := range inP {
-		inP[i] = &vk.G1.K[i+1]
+		inP[i] = &k[i+1]
 	}
-	inS := make([]*emulated.Element[FR], len(witness.Public)+len(vk.PublicAndCommitmentCommitted))
+	inS := make([]*emulated.Element[FR], len(witness.Public)+len(publicAndCommitmentCommitted))
 	for i := range witness.Public {
 		inS[i] = &witness.Public[i]
 	}
 
-	opt, err := newCfg(opts...)
-	if err != nil {
-		return fmt.Errorf("apply options: %w", err)
-	}
 	hashToField, err := recursion.NewHash(v.api, fr.Modulus(), true)
 	if err != nil {
-		return fmt.Errorf("hash to field: %w", err)
+		return nil, commitment, fmt.Errorf("hash to field: %w", err)
 	}
 
-	maxNbPublicCommitted := 0
-	for _, s := range vk.PublicAndCommitmentCommitted { // iterate over commitments
-		maxNbPublicCommitted = utils.Max(maxNbPublicCommitted, len(s))
-	}
-
-	commitmentAuxData := make([]*emulated.Element[FR], len(vk.PublicAndCommitmentCommitted))
-	for i := range vk.PublicAndCommitmentCommitted { // solveCommitmentWire
+	commitmentAuxData := make([]*emulated.Element[FR], len(publicAndCommitmentCommitted))
+	for i := range publicAndCommitmentCommitted { // solveCommitmentWire
 		hashToField.Write(v.curve.MarshalG1(proof.Commitments[i].G1El)...)
-		for j := range vk.PublicAndCommitmentCommitted[i] {
-			hashToField.Write(v.curve.MarshalScalar(*inS[vk.PublicAndCommitmentCommitted[i][j]-1])...)
+		for j := range publicAndCommitmentCommitted[i] {
+			hashToField.Write(v.curve.MarshalScalar(*inS[publicAndCommitmentCommitted[i][j]-1])...)
 		}
 
 		h := hashToField.Sum()
@@ -612,36 +636,21 @@ func (v *Verifier[FR, G1El, G2El, GtEl]) AssertProof(vk VerifyingKey[G1El, G2El,
 		commitmentAuxData[i] = res
 	}
 
-	if len(vk.PublicAndCommitmentCommitted) > 0 {
-		folded, err := v.commitment.FoldCommitments(proof.Commitments, commitmentAuxData...)
+	if len(publicAndCommitmentCommitted) > 0 {
+		commitment, err = v.commitment.FoldCommitments(proof.Commitments, commitmentAuxData...)
 		if err != nil {
-			return fmt.Errorf("fold commitments: %w", err)
-		}
-		err = v.commitment.AssertCommitment(folded, proof.CommitmentPok, vk.CommitmentKey, opt.pedopt...)
-		if err != nil {
-			return fmt.Errorf("assert commitment: %w", err)
+			return nil, commitment, fmt.Errorf("fold commitments: %w", err)
 		}
 	}
 
 	kSum, err := v.curve.MultiScalarMul(inP, inS, opt.algopt...)
 	if err != nil {
-		return fmt.Errorf("multi scalar mul: %w", err)
+		return nil, commitment, fmt.Errorf("multi scalar mul: %w", err)
 	}
-	kSum = v.curve.Add(kSum, &vk.G1.K[0])
+	kSum = v.curve.Add(kSum, &k[0])
 
 	for i := range proof.Commitments {
 		kSum = v.curve.Add(kSum, &proof.Commitments[i].G1El)
 	}
-
-	if opt.forceSubgroupCheck {
-		v.pairing.AssertIsOnG1(&proof.Ar)
-		v.pairing.AssertIsOnG1(&proof.Krs)
-		v.pairing.AssertIsOnG2(&proof.Bs)
-	}
-	pairing, err := v.pairing.Pair([]*G1El{kSum, &proof.Krs, &proof.Ar}, []*G2El{&vk.G2.GammaNeg, &vk.G2.DeltaNeg, &proof.Bs})
-	if err != nil {
-		return fmt.Errorf("pairing: %w", err)
-	}
-	v.pairing.AssertIsEqual(pairing, &vk.E)
-	return nil
+	return kSum, commitment, nil
 }
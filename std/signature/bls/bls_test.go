@@ -0,0 +1,394 @@
+package bls
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bls12381"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+func randomSecretKey(t *testing.T, curve ecc.ID) *big.Int {
+	sk, err := rand.Int(rand.Reader, curve.ScalarField())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sk
+}
+
+type BLS12381MinPkCircuit struct {
+	PublicKeys []sw_bls12381.G1Affine
+	Messages   [][]uints.U8
+	Signature  sw_bls12381.G2Affine
+
+	fast bool
+}
+
+func (c *BLS12381MinPkCircuit) Define(api frontend.API) error {
+	v, err := NewBLS12381MinPk(api, []byte(DSTBLS12381MinPkPoP))
+	if err != nil {
+		return err
+	}
+	pks := make([]*sw_bls12381.G1Affine, len(c.PublicKeys))
+	for i := range pks {
+		pks[i] = &c.PublicKeys[i]
+	}
+	switch {
+	case c.fast:
+		return v.FastAggregateVerify(pks, c.Messages[0], &c.Signature)
+	case len(pks) == 1:
+		return v.Verify(pks[0], c.Messages[0], &c.Signature)
+	default:
+		return v.AggregateVerify(pks, c.Messages, &c.Signature)
+	}
+}
+
+// signBLS12381MinPk returns the public keys and the aggregate signature of
+// msgs in the minimal-pubkey-size variant.
+func signBLS12381MinPk(t *testing.T, msgs [][]byte) ([]bls12381.G1Affine, bls12381.G2Affine) {
+	_, _, g1, _ := bls12381.Generators()
+	pks := make([]bls12381.G1Affine, len(msgs))
+	var sig bls12381.G2Jac
+	for i := range msgs {
+		sk := randomSecretKey(t, ecc.BLS12_381)
+		pks[i].ScalarMultiplication(&g1, sk)
+		h, err := bls12381.HashToG2(msgs[i], []byte(DSTBLS12381MinPkPoP))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s bls12381.G2Jac
+		s.FromAffine(&h)
+		s.ScalarMultiplication(&s, sk)
+		sig.AddAssign(&s)
+	}
+	var res bls12381.G2Affine
+	res.FromJacobian(&sig)
+	return pks, res
+}
+
+func newBLS12381MinPkWitness(pks []bls12381.G1Affine, msgs [][]byte, sig bls12381.G2Affine, fast bool) (circuit, witness *BLS12381MinPkCircuit) {
+	circuit = &BLS12381MinPkCircuit{
+		PublicKeys: make([]sw_bls12381.G1Affine, len(pks)),
+		Messages:   make([][]uints.U8, len(msgs)),
+		fast:       fast,
+	}
+	witness = &BLS12381MinPkCircuit{
+		PublicKeys: make([]sw_bls12381.G1Affine, len(pks)),
+		Messages:   make([][]uints.U8, len(msgs)),
+		Signature:  sw_bls12381.NewG2Affine(sig),
+	}
+	for i := range pks {
+		witness.PublicKeys[i] = sw_bls12381.NewG1Affine(pks[i])
+	}
+	for i := range msgs {
+		circuit.Messages[i] = make([]uints.U8, len(msgs[i]))
+		witness.Messages[i] = uints.NewU8Array(msgs[i])
+	}
+	return circuit, witness
+}
+
+func TestBLS12381MinPk(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello")}
+		pks, sig := signBLS12381MinPk(t, msgs)
+		circuit, witness := newBLS12381MinPkWitness(pks, msgs, sig, false)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+
+		// invalid message
+		circuit, witness = newBLS12381MinPkWitness(pks, [][]byte{[]byte("hellp")}, sig, false)
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "verify")
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello"), []byte("world")}
+		pks, sig := signBLS12381MinPk(t, msgs)
+		circuit, witness := newBLS12381MinPkWitness(pks, msgs, sig, false)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "aggregate")
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello"), []byte("hello")}
+		pks, sig := signBLS12381MinPk(t, msgs)
+		circuit, witness := newBLS12381MinPkWitness(pks, msgs[:1], sig, true)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "fast-aggregate")
+	assert.Run(func(assert *test.Assert) {
+		// the aggregate of pk and -pk is the point at infinity, which is not a
+		// valid public key.
+		msgs := [][]byte{[]byte("hello"), []byte("hello")}
+		pks, _ := signBLS12381MinPk(t, msgs[:1])
+		pks = append(pks, *new(bls12381.G1Affine).Neg(&pks[0]))
+		circuit, witness := newBLS12381MinPkWitness(pks, msgs[:1], bls12381.G2Affine{}, true)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "fast-aggregate-infinity")
+}
+
+type BLS12381MinSigCircuit struct {
+	PublicKey sw_bls12381.G2Affine
+	Message   []uints.U8
+	Signature sw_bls12381.G1Affine
+}
+
+func (c *BLS12381MinSigCircuit) Define(api frontend.API) error {
+	v, err := NewBLS12381MinSig(api, []byte(DSTBLS12381MinSigBasic))
+	if err != nil {
+		return err
+	}
+	return v.Verify(&c.PublicKey, c.Message, &c.Signature)
+}
+
+func TestBLS12381MinSig(t *testing.T) {
+	assert := test.NewAssert(t)
+	msg := []byte("hello")
+	_, _, _, g2 := bls12381.Generators()
+	sk := randomSecretKey(t, ecc.BLS12_381)
+	var pk bls12381.G2Affine
+	pk.ScalarMultiplication(&g2, sk)
+	h, err := bls12381.HashToG1(msg, []byte(DSTBLS12381MinSigBasic))
+	assert.NoError(err)
+	var sig bls12381.G1Affine
+	sig.ScalarMultiplication(&h, sk)
+
+	circuit := BLS12381MinSigCircuit{Message: make([]uints.U8, len(msg))}
+	witness := BLS12381MinSigCircuit{
+		PublicKey: sw_bls12381.NewG2Affine(pk),
+		Message:   uints.NewU8Array(msg),
+		Signature: sw_bls12381.NewG1Affine(sig),
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+type BN254MinPkCircuit struct {
+	PublicKeys []sw_bn254.G1Affine
+	Messages   [][]uints.U8
+	Signature  sw_bn254.G2Affine
+
+	fast bool
+}
+
+func (c *BN254MinPkCircuit) Define(api frontend.API) error {
+	v, err := NewBN254MinPk(api, []byte(DSTBN254MinPkPoP))
+	if err != nil {
+		return err
+	}
+	pks := make([]*sw_bn254.G1Affine, len(c.PublicKeys))
+	for i := range pks {
+		pks[i] = &c.PublicKeys[i]
+	}
+	switch {
+	case c.fast:
+		return v.FastAggregateVerify(pks, c.Messages[0], &c.Signature)
+	case len(pks) == 1:
+		return v.Verify(pks[0], c.Messages[0], &c.Signature)
+	default:
+		return v.AggregateVerify(pks, c.Messages, &c.Signature)
+	}
+}
+
+// signBN254MinPk returns the public keys and the aggregate signature of msgs
+// in the minimal-pubkey-size variant.
+func signBN254MinPk(t *testing.T, msgs [][]byte) ([]bn254.G1Affine, bn254.G2Affine) {
+	_, _, g1, _ := bn254.Generators()
+	pks := make([]bn254.G1Affine, len(msgs))
+	var sig bn254.G2Jac
+	for i := range msgs {
+		sk := randomSecretKey(t, ecc.BN254)
+		pks[i].ScalarMultiplication(&g1, sk)
+		h, err := bn254.HashToG2(msgs[i], []byte(DSTBN254MinPkPoP))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s bn254.G2Jac
+		s.FromAffine(&h)
+		s.ScalarMultiplication(&s, sk)
+		sig.AddAssign(&s)
+	}
+	var res bn254.G2Affine
+	res.FromJacobian(&sig)
+	return pks, res
+}
+
+func newBN254MinPkWitness(pks []bn254.G1Affine, msgs [][]byte, sig bn254.G2Affine, fast bool) (circuit, witness *BN254MinPkCircuit) {
+	circuit = &BN254MinPkCircuit{
+		PublicKeys: make([]sw_bn254.G1Affine, len(pks)),
+		Messages:   make([][]uints.U8, len(msgs)),
+		fast:       fast,
+	}
+	witness = &BN254MinPkCircuit{
+		PublicKeys: make([]sw_bn254.G1Affine, len(pks)),
+		Messages:   make([][]uints.U8, len(msgs)),
+		Signature:  sw_bn254.NewG2Affine(sig),
+	}
+	for i := range pks {
+		witness.PublicKeys[i] = sw_bn254.NewG1Affine(pks[i])
+	}
+	for i := range msgs {
+		circuit.Messages[i] = make([]uints.U8, len(msgs[i]))
+		witness.Messages[i] = uints.NewU8Array(msgs[i])
+	}
+	return circuit, witness
+}
+
+func TestBN254MinPk(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello")}
+		pks, sig := signBN254MinPk(t, msgs)
+		circuit, witness := newBN254MinPkWitness(pks, msgs, sig, false)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+
+		// invalid message
+		circuit, witness = newBN254MinPkWitness(pks, [][]byte{[]byte("hellp")}, sig, false)
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "verify")
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello"), []byte("world")}
+		pks, sig := signBN254MinPk(t, msgs)
+		circuit, witness := newBN254MinPkWitness(pks, msgs, sig, false)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "aggregate")
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello"), []byte("hello")}
+		pks, sig := signBN254MinPk(t, msgs)
+		circuit, witness := newBN254MinPkWitness(pks, msgs[:1], sig, true)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "fast-aggregate")
+	assert.Run(func(assert *test.Assert) {
+		// the aggregate of pk and -pk is the point at infinity, which is not a
+		// valid public key.
+		msgs := [][]byte{[]byte("hello"), []byte("hello")}
+		pks, _ := signBN254MinPk(t, msgs[:1])
+		pks = append(pks, *new(bn254.G1Affine).Neg(&pks[0]))
+		circuit, witness := newBN254MinPkWitness(pks, msgs[:1], bn254.G2Affine{}, true)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "fast-aggregate-infinity")
+}
+
+type BN254MinSigCircuit struct {
+	PublicKeys []sw_bn254.G2Affine
+	Messages   [][]uints.U8
+	Signature  sw_bn254.G1Affine
+
+	fast bool
+}
+
+func (c *BN254MinSigCircuit) Define(api frontend.API) error {
+	v, err := NewBN254MinSig(api, []byte(DSTBN254MinSigPoP))
+	if err != nil {
+		return err
+	}
+	pks := make([]*sw_bn254.G2Affine, len(c.PublicKeys))
+	for i := range pks {
+		pks[i] = &c.PublicKeys[i]
+	}
+	switch {
+	case c.fast:
+		return v.FastAggregateVerify(pks, c.Messages[0], &c.Signature)
+	case len(pks) == 1:
+		return v.Verify(pks[0], c.Messages[0], &c.Signature)
+	default:
+		return v.AggregateVerify(pks, c.Messages, &c.Signature)
+	}
+}
+
+// signBN254MinSig returns the public keys and the aggregate signature of msgs
+// in the minimal-signature-size variant.
+func signBN254MinSig(t *testing.T, msgs [][]byte) ([]bn254.G2Affine, bn254.G1Affine) {
+	_, _, _, g2 := bn254.Generators()
+	pks := make([]bn254.G2Affine, len(msgs))
+	var sig bn254.G1Jac
+	for i := range msgs {
+		sk := randomSecretKey(t, ecc.BN254)
+		pks[i].ScalarMultiplication(&g2, sk)
+		h, err := bn254.HashToG1(msgs[i], []byte(DSTBN254MinSigPoP))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s bn254.G1Jac
+		s.FromAffine(&h)
+		s.ScalarMultiplication(&s, sk)
+		sig.AddAssign(&s)
+	}
+	var res bn254.G1Affine
+	res.FromJacobian(&sig)
+	return pks, res
+}
+
+func newBN254MinSigWitness(pks []bn254.G2Affine, msgs [][]byte, sig bn254.G1Affine, fast bool) (circuit, witness *BN254MinSigCircuit) {
+	circuit = &BN254MinSigCircuit{
+		PublicKeys: make([]sw_bn254.G2Affine, len(pks)),
+		Messages:   make([][]uints.U8, len(msgs)),
+		fast:       fast,
+	}
+	witness = &BN254MinSigCircuit{
+		PublicKeys: make([]sw_bn254.G2Affine, len(pks)),
+		Messages:   make([][]uints.U8, len(msgs)),
+		Signature:  sw_bn254.NewG1Affine(sig),
+	}
+	for i := range pks {
+		witness.PublicKeys[i] = sw_bn254.NewG2Affine(pks[i])
+	}
+	for i := range msgs {
+		circuit.Messages[i] = make([]uints.U8, len(msgs[i]))
+		witness.Messages[i] = uints.NewU8Array(msgs[i])
+	}
+	return circuit, witness
+}
+
+func TestBN254MinSig(t *testing.T) {
+	assert := test.NewAssert(t)
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello")}
+		pks, sig := signBN254MinSig(t, msgs)
+		circuit, witness := newBN254MinSigWitness(pks, msgs, sig, false)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+
+		// invalid public key
+		pks2, _ := signBN254MinSig(t, msgs)
+		circuit, witness = newBN254MinSigWitness(pks2, msgs, sig, false)
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "verify")
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello"), []byte("world")}
+		pks, sig := signBN254MinSig(t, msgs)
+		circuit, witness := newBN254MinSigWitness(pks, msgs, sig, false)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "aggregate")
+	assert.Run(func(assert *test.Assert) {
+		msgs := [][]byte{[]byte("hello"), []byte("hello")}
+		pks, sig := signBN254MinSig(t, msgs)
+		circuit, witness := newBN254MinSigWitness(pks, msgs[:1], sig, true)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}, "fast-aggregate")
+	assert.Run(func(assert *test.Assert) {
+		// the aggregate of pk and -pk is the point at infinity, which is not a
+		// valid public key.
+		msgs := [][]byte{[]byte("hello"), []byte("hello")}
+		pks, _ := signBN254MinSig(t, msgs[:1])
+		pks = append(pks, *new(bn254.G2Affine).Neg(&pks[0]))
+		circuit, witness := newBN254MinSigWitness(pks, msgs[:1], bn254.G1Affine{}, true)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "fast-aggregate-infinity")
+}
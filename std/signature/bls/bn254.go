@@ -0,0 +1,247 @@
+package bls
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_bn254"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+// Domain separation tags of the ciphersuites over BN254.
+const (
+	// DSTBN254MinPkBasic is the tag of the basic scheme with public keys in
+	// G1 and signatures in G2.
+	DSTBN254MinPkBasic = "BLS_SIG_BN254G2_XMD:SHA-256_SVDW_RO_NUL_"
+	// DSTBN254MinPkPoP is the tag of the proof-of-possession scheme with
+	// public keys in G1 and signatures in G2.
+	DSTBN254MinPkPoP = "BLS_SIG_BN254G2_XMD:SHA-256_SVDW_RO_POP_"
+	// DSTBN254MinSigBasic is the tag of the basic scheme with public keys in
+	// G2 and signatures in G1.
+	DSTBN254MinSigBasic = "BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_NUL_"
+	// DSTBN254MinSigPoP is the tag of the proof-of-possession scheme with
+	// public keys in G2 and signatures in G1.
+	DSTBN254MinSigPoP = "BLS_SIG_BN254G1_XMD:SHA-256_SVDW_RO_POP_"
+)
+
+// BN254MinPk verifies BLS signatures over BN254 with public keys in G1 and
+// signatures in G2.
+type BN254MinPk struct {
+	api     frontend.API
+	dst     []byte
+	pairing *sw_bn254.Pairing
+	curve   *sw_emulated.Curve[sw_bn254.BaseField, sw_bn254.ScalarField]
+	baseApi *emulated.Field[sw_bn254.BaseField]
+	g2      *sw_bn254.G2
+}
+
+// NewBN254MinPk returns a new verifier for the domain separation tag dst.
+func NewBN254MinPk(api frontend.API, dst []byte) (*BN254MinPk, error) {
+	pairing, err := sw_bn254.NewPairing(api)
+	if err != nil {
+		return nil, fmt.Errorf("new pairing: %w", err)
+	}
+	curve, err := sw_emulated.New[sw_bn254.BaseField, sw_bn254.ScalarField](api, sw_emulated.GetBN254Params())
+	if err != nil {
+		return nil, fmt.Errorf("new curve: %w", err)
+	}
+	ba, err := emulated.NewField[sw_bn254.BaseField](api)
+	if err != nil {
+		return nil, fmt.Errorf("new base api: %w", err)
+	}
+	return &BN254MinPk{
+		api:     api,
+		dst:     dst,
+		pairing: pairing,
+		curve:   curve,
+		baseApi: ba,
+		g2:      sw_bn254.NewG2(api),
+	}, nil
+}
+
+// assertPublicKey asserts that pk is in G1 and is not the point at infinity.
+func (v *BN254MinPk) assertPublicKey(pk *sw_bn254.G1Affine) {
+	v.pairing.AssertIsOnG1(pk)
+	// the only point with zero y-coordinate in G1 is the point at infinity
+	// (0,0), which would otherwise pass the curve check.
+	v.api.AssertIsEqual(v.baseApi.IsZero(&pk.Y), 0)
+}
+
+// pairingCheck asserts that e(-g1, sig) ∏ e(pks[i], hs[i]) == 1.
+func (v *BN254MinPk) pairingCheck(pks []*sw_bn254.G1Affine, hs []*sw_bn254.G2Affine, sig *sw_bn254.G2Affine) error {
+	_, _, g1, _ := bn254.Generators()
+	g1.Neg(&g1)
+	g1Neg := sw_bn254.NewG1Affine(g1)
+	P := append([]*sw_bn254.G1Affine{&g1Neg}, pks...)
+	Q := append([]*sw_bn254.G2Affine{sig}, hs...)
+	return v.pairing.PairingCheck(P, Q)
+}
+
+// Verify asserts that sig is a valid signature of the message msg for the
+// public key pk. It returns an error if the circuit cannot be built.
+//
+// The public key and the signature are checked to be in the correct
+// subgroups and the public key must not be the point at infinity.
+func (v *BN254MinPk) Verify(pk *sw_bn254.G1Affine, msg []uints.U8, sig *sw_bn254.G2Affine) error {
+	return v.AggregateVerify([]*sw_bn254.G1Affine{pk}, [][]uints.U8{msg}, sig)
+}
+
+// AggregateVerify asserts that sig is a valid aggregate signature of the
+// messages msgs for the corresponding public keys pks.
+//
+// ⚠️  In the basic scheme the messages must be distinct, which is not checked
+// in-circuit and must be ensured by the caller.
+func (v *BN254MinPk) AggregateVerify(pks []*sw_bn254.G1Affine, msgs [][]uints.U8, sig *sw_bn254.G2Affine) error {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return errors.New("invalid inputs sizes")
+	}
+	v.pairing.AssertIsOnG2(sig)
+	hs := make([]*sw_bn254.G2Affine, len(msgs))
+	for i := range pks {
+		v.assertPublicKey(pks[i])
+		h, err := v.g2.HashToG2(msgs[i], v.dst)
+		if err != nil {
+			return fmt.Errorf("hash to G2: %w", err)
+		}
+		hs[i] = h
+	}
+	return v.pairingCheck(pks, hs, sig)
+}
+
+// FastAggregateVerify asserts that sig is a valid aggregate signature of the
+// common message msg for the public keys pks.
+//
+// The aggregate of the public keys must not be the point at infinity.
+//
+// ⚠️  The method is only secure in the proof-of-possession scheme, where the
+// caller has verified the proofs of possession of all public keys.
+func (v *BN254MinPk) FastAggregateVerify(pks []*sw_bn254.G1Affine, msg []uints.U8, sig *sw_bn254.G2Affine) error {
+	if len(pks) == 0 {
+		return errors.New("no public keys")
+	}
+	v.assertPublicKey(pks[0])
+	apk := pks[0]
+	for i := 1; i < len(pks); i++ {
+		v.assertPublicKey(pks[i])
+		apk = v.curve.AddUnified(apk, pks[i])
+	}
+	// the aggregate public key is validated as a single public key. It is in
+	// G1 as a sum of points of G1, but may be the point at infinity.
+	v.api.AssertIsEqual(v.baseApi.IsZero(&apk.Y), 0)
+	v.pairing.AssertIsOnG2(sig)
+	h, err := v.g2.HashToG2(msg, v.dst)
+	if err != nil {
+		return fmt.Errorf("hash to G2: %w", err)
+	}
+	return v.pairingCheck([]*sw_bn254.G1Affine{apk}, []*sw_bn254.G2Affine{h}, sig)
+}
+
+// BN254MinSig verifies BLS signatures over BN254 with public keys in G2 and
+// signatures in G1.
+type BN254MinSig struct {
+	api     frontend.API
+	dst     []byte
+	pairing *sw_bn254.Pairing
+	g1      *sw_bn254.G1
+	g2      *sw_bn254.G2
+}
+
+// NewBN254MinSig returns a new verifier for the domain separation tag dst.
+func NewBN254MinSig(api frontend.API, dst []byte) (*BN254MinSig, error) {
+	pairing, err := sw_bn254.NewPairing(api)
+	if err != nil {
+		return nil, fmt.Errorf("new pairing: %w", err)
+	}
+	g1, err := sw_bn254.NewG1(api)
+	if err != nil {
+		return nil, fmt.Errorf("new G1 struct: %w", err)
+	}
+	return &BN254MinSig{
+		api:     api,
+		dst:     dst,
+		pairing: pairing,
+		g1:      g1,
+		g2:      sw_bn254.NewG2(api),
+	}, nil
+}
+
+// assertPublicKey asserts that pk is in G2 and is not the point at infinity.
+func (v *BN254MinSig) assertPublicKey(pk *sw_bn254.G2Affine) {
+	v.pairing.AssertIsOnG2(pk)
+	// the only point with zero y-coordinate in G2 is the point at infinity
+	// (0,0), which would otherwise pass the subgroup check.
+	v.api.AssertIsEqual(v.g2.Ext2.IsZero(&pk.P.Y), 0)
+}
+
+// pairingCheck asserts that e(sig, -g2) ∏ e(hs[i], pks[i]) == 1.
+func (v *BN254MinSig) pairingCheck(pks []*sw_bn254.G2Affine, hs []*sw_bn254.G1Affine, sig *sw_bn254.G1Affine) error {
+	_, _, _, g2 := bn254.Generators()
+	g2.Neg(&g2)
+	g2Neg := sw_bn254.NewG2AffineFixed(g2)
+	P := append([]*sw_bn254.G1Affine{sig}, hs...)
+	Q := append([]*sw_bn254.G2Affine{&g2Neg}, pks...)
+	return v.pairing.PairingCheck(P, Q)
+}
+
+// Verify asserts that sig is a valid signature of the message msg for the
+// public key pk. It returns an error if the circuit cannot be built.
+//
+// The public key and the signature are checked to be in the correct
+// subgroups and the public key must not be the point at infinity.
+func (v *BN254MinSig) Verify(pk *sw_bn254.G2Affine, msg []uints.U8, sig *sw_bn254.G1Affine) error {
+	return v.AggregateVerify([]*sw_bn254.G2Affine{pk}, [][]uints.U8{msg}, sig)
+}
+
+// AggregateVerify asserts that sig is a valid aggregate signature of the
+// messages msgs for the corresponding public keys pks.
+//
+// ⚠️  In the basic scheme the messages must be distinct, which is not checked
+// in-circuit and must be ensured by the caller.
+func (v *BN254MinSig) AggregateVerify(pks []*sw_bn254.G2Affine, msgs [][]uints.U8, sig *sw_bn254.G1Affine) error {
+	if len(pks) == 0 || len(pks) != len(msgs) {
+		return errors.New("invalid inputs sizes")
+	}
+	v.pairing.AssertIsOnG1(sig)
+	hs := make([]*sw_bn254.G1Affine, len(msgs))
+	for i := range pks {
+		v.assertPublicKey(pks[i])
+		h, err := v.g1.HashToG1(msgs[i], v.dst)
+		if err != nil {
+			return fmt.Errorf("hash to G1: %w", err)
+		}
+		hs[i] = h
+	}
+	return v.pairingCheck(pks, hs, sig)
+}
+
+// FastAggregateVerify asserts that sig is a valid aggregate signature of the
+// common message msg for the public keys pks.
+//
+// The aggregate of the public keys must not be the point at infinity.
+//
+// ⚠️  The method is only secure in the proof-of-possession scheme, where the
+// caller has verified the proofs of possession of all public keys.
+func (v *BN254MinSig) FastAggregateVerify(pks []*sw_bn254.G2Affine, msg []uints.U8, sig *sw_bn254.G1Affine) error {
+	if len(pks) == 0 {
+		return errors.New("no public keys")
+	}
+	v.assertPublicKey(pks[0])
+	apk := pks[0]
+	for i := 1; i < len(pks); i++ {
+		v.assertPublicKey(pks[i])
+		apk = v.g2.AddUnified(apk, pks[i])
+	}
+	// the aggregate public key is validated as a single public key. It is in
+	// G2 as a sum of points of G2, but may be the point at infinity.
+	v.api.AssertIsEqual(v.g2.Ext2.IsZero(&apk.P.Y), 0)
+	v.pairing.AssertIsOnG1(sig)
+	h, err := v.g1.HashToG1(msg, v.dst)
+	if err != nil {
+		return fmt.Errorf("hash to G1: %w", err)
+	}
+	return v.pairingCheck([]*sw_bn254.G2Affine{apk}, []*sw_bn254.G1Affine{h}, sig)
+}
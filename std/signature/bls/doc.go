@@ -0,0 +1,25 @@
+// Package bls implements BLS signature verification over the BLS12-381 and
+// BN254 curves as defined in the IETF draft [draft-irtf-cfrg-bls-signature].
+//
+// The package depends on the [emulated/sw_bls12381] and [emulated/sw_bn254]
+// packages for the group operations and pairing checks using non-native
+// arithmetic. Messages are hashed to the curve in-circuit using the
+// hash-to-curve suites of [RFC 9380] (expand_message_xmd over SHA-256 followed
+// by the SSWU map, isogeny and cofactor clearing for BLS12-381 or the SVDW map
+// for BN254).
+//
+// Both variants of the scheme are available for both curves:
+//   - minimal-pubkey-size, where public keys are in G1 and signatures are in
+//     G2, see [BLS12381MinPk] and [BN254MinPk]. This is the variant used by the
+//     Ethereum consensus layer over BLS12-381;
+//   - minimal-signature-size, where public keys are in G2 and signatures are in
+//     G1, see [BLS12381MinSig] and [BN254MinSig].
+//
+// Every verifier provides single signature verification, aggregate signature
+// verification over distinct messages and fast aggregate verification over a
+// common message. The domain separation tag given when initialising the
+// verifier selects the scheme (basic or proof-of-possession).
+//
+// [draft-irtf-cfrg-bls-signature]: https://datatracker.ietf.org/doc/html/draft-irtf-cfrg-bls-signature-05
+// [RFC 9380]: https://www.rfc-editor.org/rfc/rfc9380.html
+package bls
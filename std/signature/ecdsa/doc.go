@@ -6,6 +6,10 @@
 // verification in a BN254-SNARK is approximately 122k constraints in R1CS and
 // 453k constraints in PLONKish.
 //
+// Additionally, the package provides public key recovery from the signature
+// (see [Recover]) and batch verification of several signatures with a single
+// randomized multi-scalar multiplication (see [BatchVerify]).
+//
 // See [ECDSA] for the signature verification algorithm.
 //
 // [ECDSA]:
@@ -1,8 +1,14 @@
 package ecdsa
 
 import (
+	"fmt"
+	"math/big"
+
 	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/mimc"
+	"github.com/consensys/gnark/std/math/bits"
 	"github.com/consensys/gnark/std/math/emulated"
 )
 
@@ -15,10 +21,11 @@ type Signature[Scalar emulated.FieldParams] struct {
 type PublicKey[Base, Scalar emulated.FieldParams] sw_emulated.AffinePoint[Base]
 
 // Verify asserts that the signature sig verifies for the message msg and public
-// key pk. The curve parameters params define the elliptic curve.
+// key pk. The curve parameters params define the elliptic curve. The options
+// opts are passed to the joint scalar multiplication.
 //
 // We assume that the message msg is already hashed to the scalar field.
-func (pk PublicKey[T, S]) Verify(api frontend.API, params sw_emulated.CurveParams, msg *emulated.Element[S], sig *Signature[S]) {
+func (pk PublicKey[T, S]) Verify(api frontend.API, params sw_emulated.CurveParams, msg *emulated.Element[S], sig *Signature[S], opts ...algopts.AlgebraOption) {
 	cr, err := sw_emulated.New[T, S](api, params)
 	if err != nil {
 		panic(err)
@@ -37,7 +44,7 @@ func (pk PublicKey[T, S]) Verify(api frontend.API, params sw_emulated.CurveParam
 	rsInv := scalarApi.MulMod(&sig.R, sInv)
 
 	// q = [rsInv]pkpt + [msInv]g
-	q := cr.JointScalarMulBase(&pkpt, rsInv, msInv)
+	q := cr.JointScalarMulBase(&pkpt, rsInv, msInv, opts...)
 	qx := baseApi.Reduce(&q.X)
 	qxBits := baseApi.ToBits(qx)
 	rbits := scalarApi.ToBits(&sig.R)
@@ -48,3 +55,184 @@ func (pk PublicKey[T, S]) Verify(api frontend.API, params sw_emulated.CurveParam
 		api.AssertIsEqual(rbits[i], qxBits[i])
 	}
 }
+
+// Recover recovers the public key from the signature sig of the message msg
+// and the recovery identifier v. The curve parameters params define the
+// elliptic curve.
+//
+// The recovery identifier v ∈ {0,1,2,3} defines the commitment point R of the
+// signature: its least significant bit is the parity of the y-coordinate of R
+// and its second bit is set when the x-coordinate of R is r + n, where n is the
+// order of the group. The method asserts that r and s are non-zero and less
+// than n, that R is on the curve and that the recovered key is not (0,0).
+//
+// As R is given by the prover, it may be ±G and the scalar multiplication uses
+// complete arithmetic.
+//
+// We assume that the message msg is already hashed to the scalar field.
+func Recover[T, S emulated.FieldParams](api frontend.API, params sw_emulated.CurveParams, msg *emulated.Element[S], sig *Signature[S], v frontend.Variable) *PublicKey[T, S] {
+	cr, err := sw_emulated.New[T, S](api, params)
+	if err != nil {
+		panic(err)
+	}
+	scalarApi, err := emulated.NewField[S](api)
+	if err != nil {
+		panic(err)
+	}
+	baseApi, err := emulated.NewField[T](api)
+	if err != nil {
+		panic(err)
+	}
+	var fp T
+	var fr S
+	vbits := bits.ToBinary(api, v, bits.WithNbDigits(2))
+
+	// 0 < r,s < n. If x = r + n, then additionally r + n < p.
+	nMinusOne := new(big.Int).Sub(fr.Modulus(), big.NewInt(1))
+	rBound := scalarApi.NewElement(nMinusOne)
+	if overflowBound := new(big.Int).Sub(fp.Modulus(), fr.Modulus()); overflowBound.Sign() > 0 {
+		overflowBound.Sub(overflowBound, big.NewInt(1))
+		if overflowBound.Cmp(nMinusOne) < 0 {
+			rBound = scalarApi.Select(vbits[1], scalarApi.NewElement(overflowBound), rBound)
+		}
+	} else {
+		api.AssertIsEqual(vbits[1], 0)
+	}
+	scalarApi.AssertIsLessOrEqual(&sig.R, rBound)
+	scalarApi.AssertIsLessOrEqual(&sig.S, scalarApi.NewElement(nMinusOne))
+	api.AssertIsEqual(scalarApi.IsZero(&sig.R), 0)
+	api.AssertIsEqual(scalarApi.IsZero(&sig.S), 0)
+
+	// R.x = r + v[1]⋅n and R.y = ±sqrt(x³ + ax + b) with parity v[0]
+	Rx := baseApi.FromBits(scalarApi.ToBits(&sig.R)...)
+	Rx = baseApi.Add(Rx, baseApi.Select(vbits[1], baseApi.NewElement(fr.Modulus()), baseApi.Zero()))
+	Ry := baseApi.Mul(Rx, Rx)
+	Ry = baseApi.Add(Ry, baseApi.NewElement(params.A))
+	Ry = baseApi.Mul(Ry, Rx)
+	Ry = baseApi.Add(Ry, baseApi.NewElement(params.B))
+	Ry = baseApi.Reduce(baseApi.Sqrt(Ry))
+	baseApi.AssertIsInRange(Ry)
+	Ry = baseApi.Select(api.Xor(vbits[0], baseApi.ToBits(Ry)[0]), baseApi.Neg(Ry), Ry)
+	R := sw_emulated.AffinePoint[T]{X: *Rx, Y: *Ry}
+
+	// P = [-msg/r]G + [s/r]R
+	u1 := scalarApi.Reduce(scalarApi.Neg(scalarApi.Div(msg, &sig.R)))
+	u2 := scalarApi.Reduce(scalarApi.Div(&sig.S, &sig.R))
+	P := cr.JointScalarMulBase(&R, u2, u1, algopts.WithCompleteArithmetic())
+	api.AssertIsEqual(api.And(baseApi.IsZero(&P.X), baseApi.IsZero(&P.Y)), 0)
+	return (*PublicKey[T, S])(P)
+}
+
+// BatchVerify asserts that the signatures sigs verify for the messages msgs and
+// public keys pks. The curve parameters params define the elliptic curve. It
+// returns an error if the input lengths mismatch or if the circuit cannot be
+// built.
+//
+// Instead of verifying every signature (r,s) separately, the method computes
+// the commitment points R with x-coordinate r in a hint and checks the single
+// randomized equation
+//
+//	∑ ρᵢ⋅(msgᵢ/sᵢ)⋅G + ∑ ρᵢ⋅(rᵢ/sᵢ)⋅Pᵢ - ∑ ρᵢ⋅Rᵢ = 0
+//
+// with one multi-scalar multiplication, where ρ₁ = 1 and ρᵢ = ρⁱ⁻¹ for a
+// random ρ derived with MiMC from all the inputs. Thus the native field must
+// have MiMC implementation.
+//
+// As the commitment points Rᵢ are computed by the prover, they may be ±Pᵢ and
+// the multi-scalar multiplication always uses complete arithmetic. The options
+// opts are passed to the multi-scalar multiplication.
+//
+// ⚠️  The signatures where the x-coordinate of R is r + n are rejected. This
+// happens with negligible probability for honestly generated signatures.
+//
+// We assume that the messages msgs are already hashed to the scalar field.
+func BatchVerify[T, S emulated.FieldParams](api frontend.API, params sw_emulated.CurveParams, pks []*PublicKey[T, S], msgs []*emulated.Element[S], sigs []*Signature[S], opts ...algopts.AlgebraOption) error {
+	if len(pks) == 0 || len(pks) != len(msgs) || len(pks) != len(sigs) {
+		return fmt.Errorf("mismatching input lengths")
+	}
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new config: %w", err)
+	}
+	if !cfg.CompleteArithmetic {
+		opts = append(opts, algopts.WithCompleteArithmetic())
+	}
+	cr, err := sw_emulated.New[T, S](api, params)
+	if err != nil {
+		return fmt.Errorf("new curve: %w", err)
+	}
+	scalarApi, err := emulated.NewField[S](api)
+	if err != nil {
+		return fmt.Errorf("new scalar field: %w", err)
+	}
+	baseApi, err := emulated.NewField[T](api)
+	if err != nil {
+		return fmt.Errorf("new base field: %w", err)
+	}
+	h, err := mimc.NewMiMC(api)
+	if err != nil {
+		return fmt.Errorf("new hasher: %w", err)
+	}
+	var fp T
+	var fr S
+	a := baseApi.NewElement(params.A)
+	g := cr.Generator()
+
+	u1s := make([]*emulated.Element[S], len(pks))
+	u2s := make([]*emulated.Element[S], len(pks))
+	Rs := make([]*sw_emulated.AffinePoint[T], len(pks))
+	for i := range pks {
+		api.AssertIsEqual(scalarApi.IsZero(&sigs[i].R), 0)
+		api.AssertIsEqual(scalarApi.IsZero(&sigs[i].S), 0)
+		sInv := scalarApi.Inverse(&sigs[i].S)
+		u1s[i] = scalarApi.MulMod(msgs[i], sInv)
+		u2s[i] = scalarApi.MulMod(&sigs[i].R, sInv)
+
+		pk := (*sw_emulated.AffinePoint[T])(pks[i])
+		yLimbs, err := api.Compiler().NewHint(commitmentYHint, int(fp.NbLimbs()),
+			commitmentYHintArgs(baseApi, scalarApi, a, &g.X, &g.Y, &pk.X, &pk.Y, u1s[i], u2s[i])...)
+		if err != nil {
+			return fmt.Errorf("commitment hint: %w", err)
+		}
+		Rs[i] = &sw_emulated.AffinePoint[T]{
+			X: *baseApi.FromBits(scalarApi.ToBits(scalarApi.Reduce(&sigs[i].R))...),
+			Y: *baseApi.NewElement(yLimbs),
+		}
+		// R is on the curve and not (0,0) as r is non-zero.
+		cr.AssertIsOnCurve(Rs[i])
+
+		for _, e := range []*emulated.Element[T]{&pk.X, &pk.Y, &Rs[i].X, &Rs[i].Y} {
+			h.Write(e.Limbs...)
+		}
+		for _, e := range []*emulated.Element[S]{msgs[i], &sigs[i].R, &sigs[i].S} {
+			h.Write(e.Limbs...)
+		}
+	}
+	// ρ is derived from all the inputs and the commitment points
+	nbRhoBits := fr.Modulus().BitLen() - 1
+	if nbRhoBits > 128 {
+		nbRhoBits = 128
+	}
+	rhoBits := bits.ToBinary(api, h.Sum())
+	rho := scalarApi.FromBits(rhoBits[:nbRhoBits]...)
+
+	// the points are ordered so that the joint scalar multiplications are on
+	// distinct points: (P₁, G), (P₂, R₂), ..., (Pₙ, Rₙ).
+	points := []*sw_emulated.AffinePoint[T]{(*sw_emulated.AffinePoint[T])(pks[0]), g}
+	scalars := []*emulated.Element[S]{u2s[0], u1s[0]}
+	rhoi := scalarApi.One()
+	for i := 1; i < len(pks); i++ {
+		rhoi = scalarApi.MulMod(rhoi, rho)
+		scalars[1] = scalarApi.Add(scalars[1], scalarApi.MulMod(rhoi, u1s[i]))
+		points = append(points, (*sw_emulated.AffinePoint[T])(pks[i]), Rs[i])
+		scalars = append(scalars, scalarApi.MulMod(rhoi, u2s[i]), scalarApi.Reduce(scalarApi.Neg(rhoi)))
+	}
+	scalars[1] = scalarApi.Reduce(scalars[1])
+	q, err := cr.MultiScalarMul(points, scalars, opts...)
+	if err != nil {
+		return fmt.Errorf("multi-scalar multiplication: %w", err)
+	}
+	// ρ₁⋅R₁ is moved to the right-hand side to avoid the point at infinity.
+	cr.AssertIsEqual(q, Rs[0])
+	return nil
+}
@@ -10,8 +10,6 @@ import (
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
-	"github.com/consensys/gnark/frontend"
-	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
 	"github.com/consensys/gnark/std/math/emulated"
 	"github.com/consensys/gnark/test"
 	"golang.org/x/crypto/cryptobyte"
@@ -61,9 +59,6 @@ func TestEcdsaP256PreHashed(t *testing.T) {
 	}
 	assert := test.NewAssert(t)
 	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
-
-	api := frontend.API(nil)
-	witness.Pub.Verify(api, sw_emulated.GetCurveParams[emulated.Secp256k1Fp](), &witness.Msg, &witness.Sig)
 	assert.NoError(err)
 
 }
@@ -114,3 +109,68 @@ func TestEcdsaP384PreHashed(t *testing.T) {
 	assert.NoError(err)
 
 }
+
+func TestRecoverP256(t *testing.T) {
+	assert := test.NewAssert(t)
+	privKey, err := cryptoecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(err)
+	msgHash := sha256.Sum256([]byte("testing ECDSA recovery"))
+	r, s, err := cryptoecdsa.Sign(rand.Reader, privKey, msgHash[:])
+	assert.NoError(err)
+
+	circuit := RecoverCircuit[emulated.P256Fp, emulated.P256Fr]{}
+	witness := RecoverCircuit[emulated.P256Fp, emulated.P256Fr]{
+		Sig: Signature[emulated.P256Fr]{
+			R: emulated.ValueOf[emulated.P256Fr](r),
+			S: emulated.ValueOf[emulated.P256Fr](s),
+		},
+		Msg: emulated.ValueOf[emulated.P256Fr](msgHash[:]),
+		Pub: PublicKey[emulated.P256Fp, emulated.P256Fr]{
+			X: emulated.ValueOf[emulated.P256Fp](privKey.PublicKey.X),
+			Y: emulated.ValueOf[emulated.P256Fp](privKey.PublicKey.Y),
+		},
+	}
+	// the parity of R is not returned by the signer, so exactly one of the
+	// recovery identifiers 0 and 1 recovers the public key.
+	var nbSolved int
+	for v := 0; v < 2; v++ {
+		witness.V = v
+		if err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField()); err == nil {
+			nbSolved++
+		}
+	}
+	assert.Equal(1, nbSolved)
+}
+
+func TestBatchVerifyP256(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbSigs = 2
+	circuit := BatchVerifyCircuit[emulated.P256Fp, emulated.P256Fr]{
+		Sigs: make([]Signature[emulated.P256Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.P256Fr], nbSigs),
+		Pubs: make([]PublicKey[emulated.P256Fp, emulated.P256Fr], nbSigs),
+	}
+	witness := BatchVerifyCircuit[emulated.P256Fp, emulated.P256Fr]{
+		Sigs: make([]Signature[emulated.P256Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.P256Fr], nbSigs),
+		Pubs: make([]PublicKey[emulated.P256Fp, emulated.P256Fr], nbSigs),
+	}
+	for i := 0; i < nbSigs; i++ {
+		privKey, err := cryptoecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(err)
+		msgHash := sha256.Sum256([]byte{byte(i)})
+		r, s, err := cryptoecdsa.Sign(rand.Reader, privKey, msgHash[:])
+		assert.NoError(err)
+		witness.Sigs[i] = Signature[emulated.P256Fr]{
+			R: emulated.ValueOf[emulated.P256Fr](r),
+			S: emulated.ValueOf[emulated.P256Fr](s),
+		}
+		witness.Msgs[i] = emulated.ValueOf[emulated.P256Fr](msgHash[:])
+		witness.Pubs[i] = PublicKey[emulated.P256Fp, emulated.P256Fr]{
+			X: emulated.ValueOf[emulated.P256Fp](privKey.PublicKey.X),
+			Y: emulated.ValueOf[emulated.P256Fp](privKey.PublicKey.Y),
+		}
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -3,10 +3,12 @@ package ecdsa
 import (
 	"crypto/rand"
 	"crypto/sha256"
+	"fmt"
 	"math/big"
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
 	"github.com/consensys/gnark-crypto/ecc/secp256k1/ecdsa"
 	"github.com/consensys/gnark/frontend"
 	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
@@ -158,3 +160,172 @@ func ExamplePublicKey_Verify_create() {
 	// can continue in the PublicKey Verify example
 	_, _, _, _, _ = sig.R, sig.S, msg, pubx, puby
 }
+
+type RecoverCircuit[T, S emulated.FieldParams] struct {
+	Sig Signature[S]
+	Msg emulated.Element[S]
+	V   frontend.Variable
+	Pub PublicKey[T, S]
+}
+
+func (c *RecoverCircuit[T, S]) Define(api frontend.API) error {
+	cr, err := sw_emulated.New[T, S](api, sw_emulated.GetCurveParams[T]())
+	if err != nil {
+		return err
+	}
+	pub := Recover[T, S](api, sw_emulated.GetCurveParams[T](), &c.Msg, &c.Sig, c.V)
+	cr.AssertIsEqual((*sw_emulated.AffinePoint[T])(pub), (*sw_emulated.AffinePoint[T])(&c.Pub))
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	assert := test.NewAssert(t)
+	privKey, err := ecdsa.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing ECDSA recovery")
+	v, r, s, err := privKey.SignForRecover(msg, nil)
+	assert.NoError(err)
+
+	circuit := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+	witness := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Sig: Signature[emulated.Secp256k1Fr]{
+			R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Msg: emulated.ValueOf[emulated.Secp256k1Fr](ecdsa.HashToInt(msg)),
+		V:   v,
+		Pub: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](privKey.PublicKey.A.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](privKey.PublicKey.A.Y),
+		},
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// wrong parity of R
+	witness.V = v ^ 1
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+func TestRecoverGeneratorCommitment(t *testing.T) {
+	// the signature with nonce k = 1 has commitment R = G, on which the
+	// incomplete joint scalar multiplication fails.
+	assert := test.NewAssert(t)
+	_, g := secp256k1.Generators()
+	n := ecc.SECP256K1.ScalarField()
+	d, err := rand.Int(rand.Reader, n)
+	assert.NoError(err)
+	var pk secp256k1.G1Affine
+	pk.ScalarMultiplicationBase(d)
+	msg := big.NewInt(42)
+	r := g.X.BigInt(new(big.Int))
+	r.Mod(r, n)
+	s := new(big.Int).Mul(r, d)
+	s.Add(s, msg).Mod(s, n)
+
+	circuit := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{}
+	witness := RecoverCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Sig: Signature[emulated.Secp256k1Fr]{
+			R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		},
+		Msg: emulated.ValueOf[emulated.Secp256k1Fr](msg),
+		V:   g.Y.BigInt(new(big.Int)).Bit(0),
+		Pub: PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](pk.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](pk.Y),
+		},
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// s = msg recovers the point at infinity, represented as (0,0).
+	witness.Sig.S = emulated.ValueOf[emulated.Secp256k1Fr](msg)
+	witness.Pub = PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		X: emulated.ValueOf[emulated.Secp256k1Fp](0),
+		Y: emulated.ValueOf[emulated.Secp256k1Fp](0),
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+}
+
+type BatchVerifyCircuit[T, S emulated.FieldParams] struct {
+	Sigs []Signature[S]
+	Msgs []emulated.Element[S]
+	Pubs []PublicKey[T, S]
+}
+
+func (c *BatchVerifyCircuit[T, S]) Define(api frontend.API) error {
+	sigs := make([]*Signature[S], len(c.Sigs))
+	msgs := make([]*emulated.Element[S], len(c.Msgs))
+	pubs := make([]*PublicKey[T, S], len(c.Pubs))
+	for i := range sigs {
+		sigs[i], msgs[i], pubs[i] = &c.Sigs[i], &c.Msgs[i], &c.Pubs[i]
+	}
+	return BatchVerify(api, sw_emulated.GetCurveParams[T](), pubs, msgs, sigs)
+}
+
+func TestBatchVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	const nbSigs = 3
+	circuit := BatchVerifyCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Sigs: make([]Signature[emulated.Secp256k1Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.Secp256k1Fr], nbSigs),
+		Pubs: make([]PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], nbSigs),
+	}
+	witness := BatchVerifyCircuit[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		Sigs: make([]Signature[emulated.Secp256k1Fr], nbSigs),
+		Msgs: make([]emulated.Element[emulated.Secp256k1Fr], nbSigs),
+		Pubs: make([]PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr], nbSigs),
+	}
+	for i := 0; i < nbSigs; i++ {
+		privKey, err := ecdsa.GenerateKey(rand.Reader)
+		assert.NoError(err)
+		msg := []byte(fmt.Sprintf("testing ECDSA batch %d", i))
+		_, r, s, err := privKey.SignForRecover(msg, nil)
+		assert.NoError(err)
+		witness.Sigs[i] = Signature[emulated.Secp256k1Fr]{
+			R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+			S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+		}
+		witness.Msgs[i] = emulated.ValueOf[emulated.Secp256k1Fr](ecdsa.HashToInt(msg))
+		witness.Pubs[i] = PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+			X: emulated.ValueOf[emulated.Secp256k1Fp](privKey.PublicKey.A.X),
+			Y: emulated.ValueOf[emulated.Secp256k1Fp](privKey.PublicKey.A.Y),
+		}
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+
+	// swapped messages
+	witness.Msgs[1], witness.Msgs[2] = witness.Msgs[2], witness.Msgs[1]
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.Error(err)
+	witness.Msgs[1], witness.Msgs[2] = witness.Msgs[2], witness.Msgs[1]
+
+	// the signature with nonce k = d has commitment R = P, on which the
+	// incomplete joint scalar multiplication fails.
+	n := ecc.SECP256K1.ScalarField()
+	d, err := rand.Int(rand.Reader, n)
+	assert.NoError(err)
+	var pk secp256k1.G1Affine
+	pk.ScalarMultiplicationBase(d)
+	msg := big.NewInt(42)
+	r := pk.X.BigInt(new(big.Int))
+	r.Mod(r, n)
+	s := new(big.Int).Mul(r, d)
+	s.Add(s, msg)
+	s.Mul(s, new(big.Int).ModInverse(d, n)).Mod(s, n)
+	witness.Sigs[1] = Signature[emulated.Secp256k1Fr]{
+		R: emulated.ValueOf[emulated.Secp256k1Fr](r),
+		S: emulated.ValueOf[emulated.Secp256k1Fr](s),
+	}
+	witness.Msgs[1] = emulated.ValueOf[emulated.Secp256k1Fr](msg)
+	witness.Pubs[1] = PublicKey[emulated.Secp256k1Fp, emulated.Secp256k1Fr]{
+		X: emulated.ValueOf[emulated.Secp256k1Fp](pk.X),
+		Y: emulated.ValueOf[emulated.Secp256k1Fp](pk.Y),
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
@@ -0,0 +1,151 @@
+package ecdsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all the hints used in this package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{commitmentYHint}
+}
+
+// commitmentYHintArgs packs the inputs of [commitmentYHint]. The emulated
+// elements are given with their number of limbs as they may be non-reduced.
+func commitmentYHintArgs[T, S emulated.FieldParams](
+	baseApi *emulated.Field[T], scalarApi *emulated.Field[S],
+	a, gx, gy, px, py *emulated.Element[T], u1, u2 *emulated.Element[S]) []frontend.Variable {
+	var fp T
+	var fr S
+	args := []frontend.Variable{fp.BitsPerLimb(), fp.NbLimbs(), fr.BitsPerLimb()}
+	for _, e := range []*emulated.Element[T]{baseApi.Modulus(), a, gx, gy, px, py} {
+		args = append(args, len(e.Limbs))
+		args = append(args, e.Limbs...)
+	}
+	for _, e := range []*emulated.Element[S]{scalarApi.Modulus(), u1, u2} {
+		args = append(args, len(e.Limbs))
+		args = append(args, e.Limbs...)
+	}
+	return args
+}
+
+// commitmentYHint computes the point [u1]G + [u2]P and returns the limbs of its
+// y-coordinate, which is zero if the point is the point at infinity. The inputs
+// are packed by [commitmentYHintArgs].
+func commitmentYHint(_ *big.Int, inputs []*big.Int, outputs []*big.Int) error {
+	if len(inputs) < 3 {
+		return fmt.Errorf("expecting header of 3 elements")
+	}
+	fpBits, fpLimbs, frBits := uint(inputs[0].Uint64()), int(inputs[1].Uint64()), uint(inputs[2].Uint64())
+	if len(outputs) != fpLimbs {
+		return fmt.Errorf("expecting %d outputs", fpLimbs)
+	}
+	inputs = inputs[3:]
+	elems := make([]*big.Int, 9)
+	for i := range elems {
+		nbBits := fpBits
+		if i >= 6 {
+			nbBits = frBits
+		}
+		if len(inputs) == 0 || !inputs[0].IsInt64() || len(inputs) < 1+int(inputs[0].Int64()) {
+			return fmt.Errorf("cannot read %d-th element", i)
+		}
+		n := int(inputs[0].Int64())
+		elems[i] = recompose(inputs[1:1+n], nbBits)
+		inputs = inputs[1+n:]
+	}
+	p, a, n := elems[0], elems[1], elems[6]
+	g := &nativePoint{X: elems[2], Y: elems[3]}
+	pk := &nativePoint{X: elems[4], Y: elems[5]}
+	u1 := new(big.Int).Mod(elems[7], n)
+	u2 := new(big.Int).Mod(elems[8], n)
+	q := nativeAdd(p, a, nativeScalarMul(p, a, g, u1), nativeScalarMul(p, a, pk, u2))
+	y := new(big.Int)
+	if q != nil {
+		y.Set(q.Y)
+	}
+	return decompose(y, fpBits, outputs)
+}
+
+// nativePoint is a point in affine coordinates. The point at infinity is
+// represented by nil.
+type nativePoint struct {
+	X, Y *big.Int
+}
+
+// nativeAdd returns p1 + p2 on the curve y² = x³ + ax + b over the prime field
+// of order p.
+func nativeAdd(p, a *big.Int, p1, p2 *nativePoint) *nativePoint {
+	if p1 == nil {
+		return p2
+	}
+	if p2 == nil {
+		return p1
+	}
+	var lambda *big.Int
+	if p1.X.Cmp(p2.X) == 0 {
+		ySum := new(big.Int).Add(p1.Y, p2.Y)
+		if ySum.Mod(ySum, p).Sign() == 0 {
+			return nil
+		}
+		// λ = (3x² + a) / 2y
+		num := new(big.Int).Mul(p1.X, p1.X)
+		num.Mul(num, big.NewInt(3)).Add(num, a)
+		den := new(big.Int).Lsh(p1.Y, 1)
+		lambda = num.Mul(num, den.ModInverse(den.Mod(den, p), p))
+	} else {
+		// λ = (y2 - y1) / (x2 - x1)
+		num := new(big.Int).Sub(p2.Y, p1.Y)
+		den := new(big.Int).Sub(p2.X, p1.X)
+		lambda = num.Mul(num, den.ModInverse(den.Mod(den, p), p))
+	}
+	lambda.Mod(lambda, p)
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, p1.X).Sub(x, p2.X).Mod(x, p)
+	y := new(big.Int).Sub(p1.X, x)
+	y.Mul(y, lambda).Sub(y, p1.Y).Mod(y, p)
+	return &nativePoint{X: x, Y: y}
+}
+
+// nativeScalarMul returns [s]q on the curve y² = x³ + ax + b over the prime
+// field of order p.
+func nativeScalarMul(p, a *big.Int, q *nativePoint, s *big.Int) *nativePoint {
+	var res *nativePoint
+	for i := s.BitLen() - 1; i >= 0; i-- {
+		res = nativeAdd(p, a, res, res)
+		if s.Bit(i) == 1 {
+			res = nativeAdd(p, a, res, q)
+		}
+	}
+	return res
+}
+
+func recompose(inputs []*big.Int, nbBits uint) *big.Int {
+	res := new(big.Int)
+	for i := range inputs {
+		res.Lsh(res, nbBits)
+		res.Add(res, inputs[len(inputs)-i-1])
+	}
+	return res
+}
+
+func decompose(input *big.Int, nbBits uint, res []*big.Int) error {
+	if input.BitLen() > len(res)*int(nbBits) {
+		return fmt.Errorf("decomposed integer does not fit into res")
+	}
+	base := new(big.Int).Lsh(big.NewInt(1), nbBits)
+	tmp := new(big.Int).Set(input)
+	for i := 0; i < len(res); i++ {
+		res[i].Mod(tmp, base)
+		tmp.Rsh(tmp, nbBits)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+// Package ed25519 implements Ed25519 signature verification as defined in
+// RFC 8032.
+//
+// The package depends on the [emulated/te_emulated] package for the
+// Edwards25519 group operations using non-native arithmetic and on the
+// [hash/sha2] package for computing the SHA-512 digest of the encoded signature
+// point, public key and message. The public key and signature are given in
+// their standard byte encodings, which are decoded and checked in-circuit.
+//
+// See [RFC 8032] for the signature verification algorithm.
+//
+// [RFC 8032]: https://www.rfc-editor.org/rfc/rfc8032#section-5.1.7
+package ed25519
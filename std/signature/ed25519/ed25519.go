@@ -0,0 +1,150 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/emulated/te_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+type (
+	// Base is the emulated base field of Edwards25519.
+	Base = emulated.Curve25519Fp
+	// Scalar is the emulated scalar field of Edwards25519.
+	Scalar = emulated.Curve25519Fr
+)
+
+// PublicKey represents the public key to verify the signature for, in its
+// 32-byte encoding.
+type PublicKey struct {
+	A [ed25519.PublicKeySize]uints.U8
+}
+
+// Signature represents the signature for some message. R is the 32-byte
+// encoding of the commitment point and S the 32-byte little-endian encoding of
+// the scalar.
+type Signature struct {
+	R [32]uints.U8
+	S [32]uints.U8
+}
+
+// ValueOfPublicKey returns the witness assignment of the encoded public key pk.
+func ValueOfPublicKey(pk ed25519.PublicKey) PublicKey {
+	if len(pk) != ed25519.PublicKeySize {
+		panic(fmt.Sprintf("public key must be %d bytes", ed25519.PublicKeySize))
+	}
+	var res PublicKey
+	copy(res.A[:], uints.NewU8Array(pk))
+	return res
+}
+
+// ValueOfSignature returns the witness assignment of the encoded signature
+// sig.
+func ValueOfSignature(sig []byte) Signature {
+	if len(sig) != ed25519.SignatureSize {
+		panic(fmt.Sprintf("signature must be %d bytes", ed25519.SignatureSize))
+	}
+	var res Signature
+	copy(res.R[:], uints.NewU8Array(sig[:32]))
+	copy(res.S[:], uints.NewU8Array(sig[32:]))
+	return res
+}
+
+// Verify asserts that the signature sig verifies for the message msg and public
+// key pk.
+//
+// The encodings of the public key and of the signature point R must be
+// canonical and decode to points on the curve, and the scalar S must be less
+// than the group order. The verification uses the cofactored equation
+//
+//	[8][S]B = [8]R + [8][k]A
+//
+// where k is the SHA-512 digest of R || A || msg interpreted as a
+// little-endian integer.
+func (pk PublicKey) Verify(api frontend.API, msg []uints.U8, sig *Signature) error {
+	cr, err := te_emulated.New[Base, Scalar](api, te_emulated.GetEd25519Params())
+	if err != nil {
+		return fmt.Errorf("new curve: %w", err)
+	}
+	baseApi, err := emulated.NewField[Base](api)
+	if err != nil {
+		return fmt.Errorf("new base field: %w", err)
+	}
+	scalarApi, err := emulated.NewField[Scalar](api)
+	if err != nil {
+		return fmt.Errorf("new scalar field: %w", err)
+	}
+	h, err := sha2.New512(api)
+	if err != nil {
+		return fmt.Errorf("new hasher: %w", err)
+	}
+
+	A := decodePoint(api, baseApi, pk.A)
+	R := decodePoint(api, baseApi, sig.R)
+	S := scalarApi.FromBits(bytesToBits(api, sig.S[:])...)
+	scalarApi.AssertIsInRange(S)
+
+	// k = SHA-512(R || A || msg) mod ℓ
+	h.Write(sig.R[:])
+	h.Write(pk.A[:])
+	h.Write(msg)
+	kBits := bytesToBits(api, h.Sum())
+	kLo := scalarApi.FromBits(kBits[:256]...)
+	kHi := scalarApi.FromBits(kBits[256:]...)
+	shift := new(big.Int).Lsh(big.NewInt(1), 256)
+	k := scalarApi.Add(kLo, scalarApi.Mul(kHi, scalarApi.NewElement(shift)))
+
+	// [8]([S]B - [k]A - R) = 0. The scalar k is not reduced to its canonical
+	// value, but as A may have a small order component the results only differ
+	// by a point of small order, which is cleared by the cofactor.
+	q := cr.JointScalarMulBase(cr.Neg(A), k, S)
+	q = cr.Add(q, cr.Neg(R))
+	q = cr.MulByCofactor(q)
+	cr.AssertIsEqual(q, cr.Neutral())
+	return nil
+}
+
+// bytesToBits returns the bits of the little-endian encoded integer in.
+func bytesToBits(api frontend.API, in []uints.U8) []frontend.Variable {
+	res := make([]frontend.Variable, 0, 8*len(in))
+	for i := range in {
+		res = append(res, api.ToBinary(in[i].Val, 8)...)
+	}
+	return res
+}
+
+// decodePoint decodes the 32-byte encoding of a point as defined in RFC 8032
+// Section 5.1.3. The encoding is the little-endian y-coordinate with the most
+// significant bit set to the least significant bit of the x-coordinate. It
+// asserts that the encoding is canonical and that it decodes to a point on the
+// curve.
+func decodePoint(api frontend.API, baseApi *emulated.Field[Base], enc [32]uints.U8) *te_emulated.AffinePoint[Base] {
+	params := te_emulated.GetEd25519Params()
+	encBits := bytesToBits(api, enc[:])
+	sign := encBits[255]
+	y := baseApi.FromBits(encBits[:255]...)
+	baseApi.AssertIsInRange(y)
+
+	// from the curve equation x² = (y² - 1) / (dy² - a). The denominator is
+	// never zero as -a/d is not a square.
+	yy := baseApi.Mul(y, y)
+	u := baseApi.Sub(yy, baseApi.One())
+	v := baseApi.Sub(baseApi.Mul(baseApi.NewElement(params.D), yy), baseApi.NewElement(params.A))
+	// the hint fails when u/v is not a square, in which case the encoding is
+	// invalid.
+	x := baseApi.Reduce(baseApi.Sqrt(baseApi.Div(u, v)))
+	baseApi.AssertIsInRange(x)
+	parity := baseApi.ToBits(x)[0]
+	// x = 0 has no negative, so the sign bit must be unset
+	api.AssertIsEqual(api.Mul(baseApi.IsZero(x), sign), 0)
+	x = baseApi.Select(api.Xor(parity, sign), baseApi.Neg(x), x)
+	return &te_emulated.AffinePoint[Base]{
+		X: *x,
+		Y: *y,
+	}
+}
@@ -0,0 +1,115 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type verifyCircuit struct {
+	Pub PublicKey
+	Msg []uints.U8
+	Sig Signature
+}
+
+func (c *verifyCircuit) Define(api frontend.API) error {
+	return c.Pub.Verify(api, c.Msg, &c.Sig)
+}
+
+func newWitness(pub ed25519.PublicKey, msg, sig []byte) (*verifyCircuit, *verifyCircuit) {
+	circuit := &verifyCircuit{Msg: make([]uints.U8, len(msg))}
+	witness := &verifyCircuit{
+		Pub: ValueOfPublicKey(pub),
+		Msg: uints.NewU8Array(msg),
+		Sig: ValueOfSignature(sig),
+	}
+	return circuit, witness
+}
+
+func TestVerify(t *testing.T) {
+	assert := test.NewAssert(t)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing Ed25519 verification in-circuit")
+	sig := ed25519.Sign(priv, msg)
+	assert.True(ed25519.Verify(pub, msg, sig))
+
+	circuit, witness := newWitness(pub, msg, sig)
+	err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestVerifyRFC8032(t *testing.T) {
+	assert := test.NewAssert(t)
+	// RFC 8032 Section 7.1, tests 1 and 2
+	for _, tc := range []struct{ pub, msg, sig string }{
+		{
+			pub: "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a",
+			msg: "",
+			sig: "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b",
+		},
+		{
+			pub: "3d4017c3e843895a92b70aa74d1b7ebc9c982ccf2ec4968cc0cd55f12af4660c",
+			msg: "72",
+			sig: "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00",
+		},
+	} {
+		pub, _ := hex.DecodeString(tc.pub)
+		msg, _ := hex.DecodeString(tc.msg)
+		sig, _ := hex.DecodeString(tc.sig)
+		circuit, witness := newWitness(pub, msg, sig)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.NoError(err)
+	}
+}
+
+func TestVerifyInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(err)
+	msg := []byte("testing Ed25519 verification in-circuit")
+	sig := ed25519.Sign(priv, msg)
+
+	assert.Run(func(assert *test.Assert) {
+		wrongMsg := append([]byte{}, msg...)
+		wrongMsg[0] ^= 1
+		circuit, witness := newWitness(pub, wrongMsg, sig)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "message")
+
+	assert.Run(func(assert *test.Assert) {
+		otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(err)
+		circuit, witness := newWitness(otherPub, msg, sig)
+		err = test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "public key")
+
+	assert.Run(func(assert *test.Assert) {
+		// S + ℓ satisfies the group equation but is not canonical
+		s := new(big.Int).SetBytes(reverse(sig[32:]))
+		s.Add(s, emulated.Curve25519Fr{}.Modulus())
+		malleable := append([]byte{}, sig[:32]...)
+		malleable = append(malleable, reverse(s.FillBytes(make([]byte, 32)))...)
+		circuit, witness := newWitness(pub, msg, malleable)
+		err := test.IsSolved(circuit, witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "malleable")
+}
+
+func reverse(b []byte) []byte {
+	res := make([]byte, len(b))
+	for i := range b {
+		res[len(b)-1-i] = b[i]
+	}
+	return res
+}
@@ -0,0 +1,13 @@
+// Package schnorr implements BIP-340 Schnorr signature verification over the
+// secp256k1 curve.
+//
+// The package depends on the [emulated/sw_emulated] package for the secp256k1
+// group operations using non-native arithmetic and on the [hash/sha2] package
+// for computing the tagged challenge hash. Public keys are given in their
+// 32-byte x-only encoding and are lifted in-circuit to the point with even
+// y-coordinate. Signatures are given in their 64-byte encoding.
+//
+// See [BIP-340] for the signature verification algorithm.
+//
+// [BIP-340]: https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+package schnorr
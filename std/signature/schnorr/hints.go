@@ -0,0 +1,46 @@
+package schnorr
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/consensys/gnark-crypto/ecc/secp256k1/fp"
+	"github.com/consensys/gnark/constraint/solver"
+	"github.com/consensys/gnark/std/math/emulated"
+)
+
+func init() {
+	solver.RegisterHint(GetHints()...)
+}
+
+// GetHints returns all the hints used in this package.
+func GetHints() []solver.Hint {
+	return []solver.Hint{liftXHint}
+}
+
+// liftXHint returns the even y-coordinate of the secp256k1 point with
+// x-coordinate x. It fails if there is no such point.
+func liftXHint(nativeMod *big.Int, nativeInputs, nativeOutputs []*big.Int) error {
+	return emulated.UnwrapHint(nativeInputs, nativeOutputs, func(mod *big.Int, inputs, outputs []*big.Int) error {
+		if len(inputs) != 1 {
+			return fmt.Errorf("expecting one input")
+		}
+		if len(outputs) != 1 {
+			return fmt.Errorf("expecting one output")
+		}
+		var x, y fp.Element
+		x.SetBigInt(inputs[0])
+		// y² = x³ + 7
+		y.Square(&x).Mul(&y, &x)
+		y.Add(&y, new(fp.Element).SetUint64(7))
+		if y.Sqrt(&y) == nil {
+			return fmt.Errorf("x is not on the curve")
+		}
+		y.BigInt(outputs[0])
+		if outputs[0].Bit(0) == 1 {
+			y.Neg(&y)
+			y.BigInt(outputs[0])
+		}
+		return nil
+	})
+}
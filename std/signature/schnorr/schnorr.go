@@ -0,0 +1,176 @@
+package schnorr
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/algebra/algopts"
+	"github.com/consensys/gnark/std/algebra/emulated/sw_emulated"
+	"github.com/consensys/gnark/std/hash/sha2"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+)
+
+type (
+	// Base is the emulated base field of secp256k1.
+	Base = emulated.Secp256k1Fp
+	// Scalar is the emulated scalar field of secp256k1.
+	Scalar = emulated.Secp256k1Fr
+)
+
+const (
+	// PublicKeySize is the size of the x-only public key encoding.
+	PublicKeySize = 32
+	// SignatureSize is the size of the signature encoding.
+	SignatureSize = 64
+)
+
+// challengeTag is the tag of the hash computing the challenge.
+const challengeTag = "BIP0340/challenge"
+
+// PublicKey represents the public key to verify the signature for, in its
+// 32-byte big-endian x-only encoding.
+type PublicKey struct {
+	X [PublicKeySize]uints.U8
+}
+
+// Signature represents the signature for some message. R is the 32-byte
+// big-endian encoding of the x-coordinate of the commitment point and S the
+// 32-byte big-endian encoding of the scalar.
+type Signature struct {
+	R [32]uints.U8
+	S [32]uints.U8
+}
+
+// ValueOfPublicKey returns the witness assignment of the encoded x-only public
+// key pk.
+func ValueOfPublicKey(pk []byte) PublicKey {
+	if len(pk) != PublicKeySize {
+		panic(fmt.Sprintf("public key must be %d bytes", PublicKeySize))
+	}
+	var res PublicKey
+	copy(res.X[:], uints.NewU8Array(pk))
+	return res
+}
+
+// ValueOfSignature returns the witness assignment of the encoded signature
+// sig.
+func ValueOfSignature(sig []byte) Signature {
+	if len(sig) != SignatureSize {
+		panic(fmt.Sprintf("signature must be %d bytes", SignatureSize))
+	}
+	var res Signature
+	copy(res.R[:], uints.NewU8Array(sig[:32]))
+	copy(res.S[:], uints.NewU8Array(sig[32:]))
+	return res
+}
+
+// Verify asserts that the signature sig verifies for the message msg and public
+// key pk as defined in BIP-340.
+//
+// The public key must be the x-coordinate of a point on the curve, which is
+// lifted to the point P with even y-coordinate. The x-coordinate of R must be
+// less than the field modulus and S must be less than the group order. The
+// verification asserts that the point
+//
+//	R' = [S]G - [e]P
+//
+// is not the point at infinity, has even y-coordinate and x-coordinate equal to
+// R, where e is the tagged SHA-256 digest of R || pk || msg.
+//
+// As P = ±G is a valid public key and S is chosen by the prover, the scalar
+// multiplication always uses complete arithmetic. The options opts are passed
+// to the scalar multiplication.
+func (pk PublicKey) Verify(api frontend.API, msg []uints.U8, sig *Signature, opts ...algopts.AlgebraOption) error {
+	cfg, err := algopts.NewConfig(opts...)
+	if err != nil {
+		return fmt.Errorf("new config: %w", err)
+	}
+	if !cfg.CompleteArithmetic {
+		opts = append(opts, algopts.WithCompleteArithmetic())
+	}
+	cr, err := sw_emulated.New[Base, Scalar](api, sw_emulated.GetSecp256k1Params())
+	if err != nil {
+		return fmt.Errorf("new curve: %w", err)
+	}
+	baseApi, err := emulated.NewField[Base](api)
+	if err != nil {
+		return fmt.Errorf("new base field: %w", err)
+	}
+	scalarApi, err := emulated.NewField[Scalar](api)
+	if err != nil {
+		return fmt.Errorf("new scalar field: %w", err)
+	}
+	h, err := sha2.New(api, false)
+	if err != nil {
+		return fmt.Errorf("new hasher: %w", err)
+	}
+
+	P, err := liftX(api, baseApi, pk.X)
+	if err != nil {
+		return fmt.Errorf("lift x: %w", err)
+	}
+	r := baseApi.FromBits(bytesToBits(api, sig.R[:])...)
+	baseApi.AssertIsInRange(r)
+	s := scalarApi.FromBits(bytesToBits(api, sig.S[:])...)
+	scalarApi.AssertIsInRange(s)
+
+	// e = SHA-256(SHA-256(tag) || SHA-256(tag) || R || pk || msg) mod n
+	tagHash := sha256.Sum256([]byte(challengeTag))
+	h.Write(uints.NewU8Array(tagHash[:]))
+	h.Write(uints.NewU8Array(tagHash[:]))
+	h.Write(sig.R[:])
+	h.Write(pk.X[:])
+	h.Write(msg)
+	e := scalarApi.Reduce(scalarApi.FromBits(bytesToBits(api, h.Sum())...))
+
+	// R' = [s]G + [-e]P
+	q := cr.JointScalarMulBase(P, scalarApi.Neg(e), s, opts...)
+	// the only point with zero y-coordinate is the point at infinity (0,0),
+	// as there are no points of order 2 on secp256k1.
+	api.AssertIsEqual(baseApi.IsZero(&q.Y), 0)
+	api.AssertIsEqual(isOdd(baseApi, &q.Y), 0)
+	baseApi.AssertIsEqual(&q.X, r)
+	return nil
+}
+
+// bytesToBits returns the bits of the big-endian encoded integer in, starting
+// from the least significant bit.
+func bytesToBits(api frontend.API, in []uints.U8) []frontend.Variable {
+	res := make([]frontend.Variable, 0, 8*len(in))
+	for i := len(in) - 1; i >= 0; i-- {
+		res = append(res, api.ToBinary(in[i].Val, 8)...)
+	}
+	return res
+}
+
+// isOdd returns the least significant bit of the canonical representation of
+// x.
+func isOdd(baseApi *emulated.Field[Base], x *emulated.Element[Base]) frontend.Variable {
+	xr := baseApi.Reduce(x)
+	baseApi.AssertIsInRange(xr)
+	return baseApi.ToBits(xr)[0]
+}
+
+// liftX returns the point with even y-coordinate and x-coordinate given by the
+// 32-byte big-endian encoding enc. It asserts that the x-coordinate is less
+// than the field modulus and that the point is on the curve.
+func liftX(api frontend.API, baseApi *emulated.Field[Base], enc [PublicKeySize]uints.U8) (*sw_emulated.AffinePoint[Base], error) {
+	x := baseApi.FromBits(bytesToBits(api, enc[:])...)
+	baseApi.AssertIsInRange(x)
+	res, err := baseApi.NewHint(liftXHint, 1, x)
+	if err != nil {
+		return nil, err
+	}
+	y := res[0]
+	// y² = x³ + 7. We do not use [sw_emulated.Curve.AssertIsOnCurve] as it
+	// also accepts (0,0).
+	xxx := baseApi.Mul(x, baseApi.Mul(x, x))
+	baseApi.AssertIsEqual(baseApi.Mul(y, y), baseApi.Add(xxx, baseApi.NewElement(7)))
+	api.AssertIsEqual(isOdd(baseApi, y), 0)
+	return &sw_emulated.AffinePoint[Base]{
+		X: *x,
+		Y: *y,
+	}, nil
+}
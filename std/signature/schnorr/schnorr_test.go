@@ -0,0 +1,196 @@
+package schnorr
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc"
+	"github.com/consensys/gnark-crypto/ecc/secp256k1"
+	"github.com/consensys/gnark/frontend"
+	"github.com/consensys/gnark/std/math/emulated"
+	"github.com/consensys/gnark/std/math/uints"
+	"github.com/consensys/gnark/test"
+)
+
+type SchnorrCircuit struct {
+	PublicKey PublicKey
+	Message   []uints.U8
+	Signature Signature
+}
+
+func (c *SchnorrCircuit) Define(api frontend.API) error {
+	return c.PublicKey.Verify(api, c.Message, &c.Signature)
+}
+
+func taggedHash(tag string, msgs ...[]byte) []byte {
+	tagHash := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	h.Write(tagHash[:])
+	h.Write(tagHash[:])
+	for _, m := range msgs {
+		h.Write(m)
+	}
+	return h.Sum(nil)
+}
+
+// sign returns the x-only public key and the BIP-340 signature of msg using
+// the secret key sk and a random nonce.
+func sign(t *testing.T, sk *big.Int, msg []byte) (pk, sig []byte) {
+	n := emulated.Secp256k1Fr{}.Modulus()
+	_, g := secp256k1.Generators()
+	var P, R secp256k1.G1Affine
+	d := new(big.Int).Set(sk)
+	P.ScalarMultiplication(&g, d)
+	if P.Y.BigInt(new(big.Int)).Bit(0) == 1 {
+		d.Sub(n, d)
+	}
+	k, err := rand.Int(rand.Reader, n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	R.ScalarMultiplication(&g, k)
+	if R.Y.BigInt(new(big.Int)).Bit(0) == 1 {
+		k.Sub(n, k)
+	}
+	px, rx := P.X.Bytes(), R.X.Bytes()
+	e := new(big.Int).SetBytes(taggedHash(challengeTag, rx[:], px[:], msg))
+	e.Mod(e, n)
+	s := e.Mul(e, d)
+	s.Add(s, k).Mod(s, n)
+	sig = make([]byte, SignatureSize)
+	copy(sig, rx[:])
+	s.FillBytes(sig[32:])
+	return px[:], sig
+}
+
+func TestSchnorr(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk, err := rand.Int(rand.Reader, emulated.Secp256k1Fr{}.Modulus())
+	assert.NoError(err)
+	msg := []byte("hello")
+	pk, sig := sign(t, sk, msg)
+	circuit := SchnorrCircuit{Message: make([]uints.U8, len(msg))}
+	witness := SchnorrCircuit{
+		PublicKey: ValueOfPublicKey(pk),
+		Message:   uints.NewU8Array(msg),
+		Signature: ValueOfSignature(sig),
+	}
+	err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestSchnorrGenerator(t *testing.T) {
+	// P = G is an edge case of the scalar multiplication
+	assert := test.NewAssert(t)
+	msg := []byte("hello")
+	pk, sig := sign(t, big.NewInt(1), msg)
+	circuit := SchnorrCircuit{Message: make([]uints.U8, len(msg))}
+	witness := SchnorrCircuit{
+		PublicKey: ValueOfPublicKey(pk),
+		Message:   uints.NewU8Array(msg),
+		Signature: ValueOfSignature(sig),
+	}
+	err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+	assert.NoError(err)
+}
+
+func TestSchnorrVectors(t *testing.T) {
+	// test vectors from https://github.com/bitcoin/bips/blob/master/bip-0340/test-vectors.csv
+	vectors := []struct {
+		pk, msg, sig string
+		valid        bool
+	}{
+		{
+			pk:    "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+			msg:   "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:   "E907831F80848D1069A5371B402410364BDF1C5F8307B0084C55F1CE2DCA821525F66A4A85EA8B71E482A74F382D2CE5EBEEE8FDB2172F477DF4900D310536C0",
+			valid: true,
+		},
+		{
+			pk:    "DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+			msg:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			sig:   "6896BD60EEAE296DB48A229FF71DFE071BDE413E6D43F917DC8DCF8C78DE33418906D11AC976ABCCB20B091292BFF4EA897EFCB639EA871CFA95F6DE339E4B0A",
+			valid: true,
+		},
+		{
+			// public key not on the curve
+			pk:    "EEFDEA4CDB677750A420FEE807EACF21EB9898AE79B9768766E4FAA04A2D4A34",
+			msg:   "243F6A8885A308D313198A2E03707344A4093822299F31D0082EFA98EC4E6C89",
+			sig:   "6CFF5C3BA86C69EA4B7376F31A9BCB4F74C1976089B2D9963DA2E5543E17776969E89B4C5564D00349106B8497785DD7D1D713A8AE82B32FA79D5F7FC407D39B",
+			valid: false,
+		},
+	}
+	assert := test.NewAssert(t)
+	for _, v := range vectors {
+		pk, err := hex.DecodeString(v.pk)
+		assert.NoError(err)
+		msg, err := hex.DecodeString(v.msg)
+		assert.NoError(err)
+		sig, err := hex.DecodeString(v.sig)
+		assert.NoError(err)
+		circuit := SchnorrCircuit{Message: make([]uints.U8, len(msg))}
+		witness := SchnorrCircuit{
+			PublicKey: ValueOfPublicKey(pk),
+			Message:   uints.NewU8Array(msg),
+			Signature: ValueOfSignature(sig),
+		}
+		err = test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		if v.valid {
+			assert.NoError(err)
+		} else {
+			assert.Error(err)
+		}
+	}
+}
+
+func TestSchnorrInvalid(t *testing.T) {
+	assert := test.NewAssert(t)
+	sk, err := rand.Int(rand.Reader, emulated.Secp256k1Fr{}.Modulus())
+	assert.NoError(err)
+	msg := []byte("hello")
+	pk, sig := sign(t, sk, msg)
+	circuit := SchnorrCircuit{Message: make([]uints.U8, len(msg))}
+
+	assert.Run(func(assert *test.Assert) {
+		witness := SchnorrCircuit{
+			PublicKey: ValueOfPublicKey(pk),
+			Message:   uints.NewU8Array([]byte("hellp")),
+			Signature: ValueOfSignature(sig),
+		}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "message")
+	assert.Run(func(assert *test.Assert) {
+		// x = 0 is not on the curve as 7 is not a square
+		witness := SchnorrCircuit{
+			PublicKey: ValueOfPublicKey(make([]byte, PublicKeySize)),
+			Message:   uints.NewU8Array(msg),
+			Signature: ValueOfSignature(sig),
+		}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "public-key")
+	assert.Run(func(assert *test.Assert) {
+		// s + n is a non-canonical encoding of s
+		n := emulated.Secp256k1Fr{}.Modulus()
+		s := new(big.Int).SetBytes(sig[32:])
+		s.Add(s, n)
+		if s.BitLen() > 256 {
+			assert.Log("s + n overflows, skipping")
+			return
+		}
+		malleable := make([]byte, SignatureSize)
+		copy(malleable, sig[:32])
+		s.FillBytes(malleable[32:])
+		witness := SchnorrCircuit{
+			PublicKey: ValueOfPublicKey(pk),
+			Message:   uints.NewU8Array(msg),
+			Signature: ValueOfSignature(malleable),
+		}
+		err := test.IsSolved(&circuit, &witness, ecc.BN254.ScalarField())
+		assert.Error(err)
+	}, "malleable")
+}